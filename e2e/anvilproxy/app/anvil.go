@@ -92,6 +92,9 @@ func startAnvil(ctx context.Context, cfg anvilConfig) (anvilInstance, error) {
 	if cfg.ForkURL != "" {
 		args = append(args, "--fork-url", cfg.ForkURL)
 	}
+	if cfg.AutoImpersonate {
+		args = append(args, "--auto-impersonate")
+	}
 
 	dir, err := os.MkdirTemp("", "")
 	if err != nil {
@@ -1,23 +1,25 @@
 package app
 
 type Config struct {
-	ListenAddr    string
-	ChainID       uint64
-	LoadState     string
-	BlockTimeSecs uint64
-	Silent        bool
-	SlotsInEpoch  uint64
-	ForkURL       string
+	ListenAddr      string
+	ChainID         uint64
+	LoadState       string
+	BlockTimeSecs   uint64
+	Silent          bool
+	SlotsInEpoch    uint64
+	ForkURL         string
+	AutoImpersonate bool
 }
 
 func DefaultConfig() Config {
 	return Config{
-		ListenAddr:    "0.0.0.0:8545",
-		ChainID:       1337,
-		LoadState:     "",
-		Silent:        true,
-		BlockTimeSecs: 1,
-		SlotsInEpoch:  32,
-		ForkURL:       "",
+		ListenAddr:      "0.0.0.0:8545",
+		ChainID:         1337,
+		LoadState:       "",
+		Silent:          true,
+		BlockTimeSecs:   1,
+		SlotsInEpoch:    32,
+		ForkURL:         "",
+		AutoImpersonate: false,
 	}
 }
@@ -47,4 +47,5 @@ func bindFlags(flags *pflag.FlagSet, cfg *app.Config) {
 	flags.BoolVar(&cfg.Silent, "silent", cfg.Silent, "Don't print anything on startup and don't print logs")
 	flags.Uint64Var(&cfg.SlotsInEpoch, "slots-in-an-epoch", cfg.SlotsInEpoch, "Slots in an epoch")
 	flags.StringVar(&cfg.ForkURL, "fork-url", cfg.ForkURL, "URL to fork from")
+	flags.BoolVar(&cfg.AutoImpersonate, "auto-impersonate", cfg.AutoImpersonate, "Enable auto impersonation, allowing unsigned txs from any account")
 }
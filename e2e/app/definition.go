@@ -377,6 +377,8 @@ func TestnetFromManifest(ctx context.Context, manifest types.Manifest, infd type
 			InternalRPC: fmt.Sprintf("http://%s:8545", internalIP),
 			ExternalRPC: fmt.Sprintf("http://%s:%d", inst.ExtIPAddress.String(), inst.Port),
 			ForkRPC:     forkRPC(chain.Name),
+
+			AutoImpersonate: manifest.AnvilAutoImpersonate,
 		})
 	}
 
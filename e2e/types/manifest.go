@@ -102,6 +102,10 @@ type Manifest struct {
 	// Forks maps devnet chain name to name of public chain to fork from.
 	Forks map[string]string
 
+	// AnvilAutoImpersonate enables auto impersonation on anvil chains, allowing unsigned txs from any account.
+	// This is required by dev stand-ins administering forked contracts (e.g. CCTP attesters, LayerZero delivery).
+	AnvilAutoImpersonate bool `toml:"anvil_auto_impersonate"`
+
 	// Keys contains long-lived private keys (address by type) by node name.
 	Keys map[string]map[key.Type]string `toml:"keys"`
 
@@ -268,6 +268,8 @@ type AnvilChain struct {
 	ExternalRPC string   // For JSON-RPC queries from e2e app.
 	ForkRPC     string   // JSON-RPC to fork from
 	LoadState   string   // File path to load anvil state from
+	// AutoImpersonate enables anvil auto impersonation
+	AutoImpersonate bool
 }
 
 // PublicChain represents a public chain in a omni network.
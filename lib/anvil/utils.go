@@ -27,6 +27,18 @@ func FundAccounts(ctx context.Context, ethCl ethclient.Client, amount *big.Int,
 	return nil
 }
 
+// Reset resets a fork to the latest block of the forked chain via the anvil_reset RPC method.
+func Reset(ctx context.Context, ethCl ethclient.Client, forkURL string) error {
+	err := ethCl.CallContext(ctx, nil, "anvil_reset", map[string]any{
+		"forking": map[string]any{"jsonRpcUrl": forkURL},
+	})
+	if err != nil {
+		return errors.Wrap(err, "reset fork")
+	}
+
+	return nil
+}
+
 type FundERC20Option func(*fundERC20Options)
 
 type fundERC20Options struct {
@@ -15,7 +15,6 @@ import (
 	"github.com/omni-network/omni/lib/evmchain"
 	"github.com/omni-network/omni/lib/expbackoff"
 	"github.com/omni-network/omni/lib/log"
-	"github.com/omni-network/omni/lib/tutil"
 	"github.com/omni-network/omni/lib/xchain"
 
 	"github.com/ethereum/go-ethereum"
@@ -228,6 +227,29 @@ func newMessageTransmitters(clients map[uint64]ethclient.Client) (map[uint64]*Me
 	return transmitters, addrs, nil
 }
 
+// StartDevClient starts a dev client for devnets.
+// It enables the attester on all chains, and starts attesting.
+// It requires rpcs to be anvil forks run with auto impersonation.
+func StartDevClient(
+	ctx context.Context,
+	attesterPk *ecdsa.PrivateKey,
+	xprov xchain.Provider,
+	chains []evmchain.Metadata,
+	clients map[uint64]ethclient.Client,
+) (Client, error) {
+	cctpClient := NewDevClient(attesterPk, clients)
+
+	if err := EnableDevAttester(ctx, clients, crypto.PubkeyToAddress(attesterPk.PublicKey)); err != nil {
+		return nil, errors.Wrap(err, "enable attester")
+	}
+
+	if err := cctpClient.AttestForever(ctx, chains, xprov); err != nil {
+		return nil, errors.Wrap(err, "attest forever")
+	}
+
+	return cctpClient, nil
+}
+
 // StartTestClient is starts a dev client for integration tests.
 // It creates a new attester account, enables it on all chains, and starts attesting.
 // It requires rpcs to be anvil forks run with auto impersonation.
@@ -241,78 +263,88 @@ func StartTestClient(
 ) Client {
 	t.Helper()
 
-	attesterPk, attesterAddr := testutil.NewAccount(t)
-
-	// Create CCTP client, start attesting
-	cctpClient := NewDevClient(attesterPk, clients)
-
-	// Enable attester on all chains
-	enableAttester(ctx, t, clients, attesterAddr)
+	attesterPk, _ := testutil.NewAccount(t)
 
-	// Start attesting
-	err := cctpClient.AttestForever(ctx, chains, xprov)
+	cctpClient, err := StartDevClient(ctx, attesterPk, xprov, chains, clients)
 	require.NoError(t, err)
 
 	return cctpClient
 }
 
-// enableAttester enables the attester on each chains MessageTransmitter, and sets the signature threshold to 1.
-func enableAttester(ctx context.Context, t *testing.T, clients map[uint64]ethclient.Client, newAttester common.Address) {
-	t.Helper()
-
+// EnableDevAttester enables the attester on each chains MessageTransmitter, and sets the signature threshold to 1.
+// It requires rpcs to be anvil forks run with auto impersonation.
+func EnableDevAttester(ctx context.Context, clients map[uint64]ethclient.Client, newAttester common.Address) error {
 	for chainID, client := range clients {
 		mtAddr, ok := MessageTransmitterAddr(chainID)
-		require.True(t, ok)
+		if !ok {
+			return errors.New("no message transmitter", "chain", chainID)
+		}
 
 		mt, err := NewMessageTransmitter(mtAddr, client)
-		require.NoError(t, err)
+		if err != nil {
+			return errors.Wrap(err, "new message transmitter")
+		}
 
 		// AttesterManager is account allowed to enable attesters
 		mngr, err := mt.AttesterManager(&bind.CallOpts{Context: ctx})
-		require.NoError(t, err)
+		if err != nil {
+			return errors.Wrap(err, "attester manager")
+		}
 
 		// Send unsigned MessageTransmitter.enableAttester tx from attester manager
 		// This requires anvil auto impersonation
 		calldata, err := messageTransmitterABI.Pack("enableAttester", newAttester)
-		require.NoError(t, err)
-		txHash, err := sendUnsignedTransaction(ctx, client, txArgs{
-			from:  mngr,
-			to:    mtAddr,
-			value: nil,
-			data:  calldata,
-		})
-		require.NoError(t, err)
-
-		_, err = bind.WaitMinedHash(ctx, client, txHash)
-		require.NoError(t, err)
+		if err != nil {
+			return errors.Wrap(err, "pack enable attester")
+		}
+		if err := sendUnsignedAndWait(ctx, client, txArgs{from: mngr, to: mtAddr, data: calldata}); err != nil {
+			return errors.Wrap(err, "enable attester")
+		}
 
 		// Verify attester is enabled
 		enabled, err := mt.IsEnabledAttester(&bind.CallOpts{Context: ctx}, newAttester)
-		require.NoError(t, err)
-		require.True(t, enabled, "attester not enabled on chain %d", chainID)
+		if err != nil {
+			return errors.Wrap(err, "is enabled attester")
+		} else if !enabled {
+			return errors.New("attester not enabled", "chain", chainID)
+		}
 
 		log.Info(ctx, "Enabled attester", "chain", chainID, "attester", newAttester)
 
 		// Reduce signature threshold to 1 (number of attesters required)
 		calldata, err = messageTransmitterABI.Pack("setSignatureThreshold", bi.One())
-		require.NoError(t, err)
-		txHash, err = sendUnsignedTransaction(ctx, client, txArgs{
-			from:  mngr,
-			to:    mtAddr,
-			value: nil,
-			data:  calldata,
-		})
-		require.NoError(t, err)
-
-		_, err = bind.WaitMinedHash(ctx, client, txHash)
-		require.NoError(t, err)
+		if err != nil {
+			return errors.Wrap(err, "pack set signature threshold")
+		}
+		if err := sendUnsignedAndWait(ctx, client, txArgs{from: mngr, to: mtAddr, data: calldata}); err != nil {
+			return errors.Wrap(err, "set signature threshold")
+		}
 
 		threshold, err := mt.SignatureThreshold(&bind.CallOpts{Context: ctx})
-		require.NoError(t, err)
-		tutil.RequireEQ(t, threshold, bi.One(), "signature threshold not set to 1 on chain %d", chainID)
+		if err != nil {
+			return errors.Wrap(err, "signature threshold")
+		} else if !bi.EQ(threshold, bi.One()) {
+			return errors.New("signature threshold not set to 1", "chain", chainID, "threshold", threshold)
+		}
 
 		log.Info(ctx, "Signature threshold set", "chain", chainID, "threshold", threshold)
 	}
+
+	return nil
+}
+
+// sendUnsignedAndWait sends an auto impersonated tx on anvil and waits for it to be mined.
+func sendUnsignedAndWait(ctx context.Context, client ethclient.Client, args txArgs) error {
+	txHash, err := sendUnsignedTransaction(ctx, client, args)
+	if err != nil {
+		return err
+	}
+
+	if _, err := bind.WaitMinedHash(ctx, client, txHash); err != nil {
+		return errors.Wrap(err, "wait mined")
+	}
+
+	return nil
 }
 
 type txArgs struct {
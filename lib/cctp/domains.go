@@ -37,7 +37,7 @@ func IsSupportedChain(chainID uint64) bool {
 
 func domainIDForChain(networkID netconf.ID, chainID uint64) (uint32, bool) {
 	switch networkID {
-	case netconf.Mainnet:
+	case netconf.Mainnet, netconf.Devnet: // Devnet CCTP chains are anvil forks of mainnet chains.
 		d, ok := mainnetDomains[chainID]
 		return d, ok
 	case netconf.Omega, netconf.Staging:
@@ -60,7 +60,7 @@ func chainIDForDomain(networkID netconf.ID, domainID uint32) (uint64, bool) {
 	}
 
 	switch networkID {
-	case netconf.Mainnet:
+	case netconf.Mainnet, netconf.Devnet:
 		return findIn(mainnetDomains)
 	case netconf.Omega, netconf.Staging:
 		return findIn(testnetDomains)
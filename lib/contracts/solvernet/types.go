@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/gagliardetto/solana-go"
 )
@@ -35,6 +36,22 @@ func (id OrderID) Sol() solana.PublicKey {
 	return solana.PublicKey(id)
 }
 
+// EVMOrderID returns the ID of an order opened on an EVM inbox by the user with the nonce.
+// This is equivalent to SolverNetInbox._getOrderId: keccak256(abi.encode(user, nonce, gasless, chainid)).
+func EVMOrderID(user common.Address, nonce *big.Int, gasless bool, chainID uint64) OrderID {
+	var gaslessWord byte
+	if gasless {
+		gaslessWord = 1
+	}
+
+	return OrderID(crypto.Keccak256Hash(
+		common.LeftPadBytes(user.Bytes(), 32),
+		common.BigToHash(nonce).Bytes(),
+		common.LeftPadBytes([]byte{gaslessWord}, 32),
+		common.BigToHash(new(big.Int).SetUint64(chainID)).Bytes(),
+	))
+}
+
 func (s OrderStatus) String() string {
 	switch s {
 	case StatusInvalid:
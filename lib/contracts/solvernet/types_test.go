@@ -5,9 +5,12 @@ import (
 
 	"github.com/omni-network/omni/lib/bi"
 	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/evmchain"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/stretchr/testify/require"
 )
@@ -70,3 +73,33 @@ func TestCallBindings(t *testing.T) {
 	require.Equal(t, [4]byte{}, bindings[3].Selector)
 	require.Equal(t, []byte(nil), bindings[3].Params)
 }
+
+func TestEVMOrderID(t *testing.T) {
+	t.Parallel()
+
+	newType := func(typ string) abi.Type {
+		t.Helper()
+		resp, err := abi.NewType(typ, "", nil)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	args := abi.Arguments{
+		{Type: newType("address")},
+		{Type: newType("uint256")},
+		{Type: newType("bool")},
+		{Type: newType("uint256")},
+	}
+
+	user := common.HexToAddress("0x36e66fbbce51e4cd5bd3c62b637eb411b18949d4")
+	for _, gasless := range []bool{false, true} {
+		packed, err := args.Pack(user, bi.N(7), gasless, bi.N(evmchain.IDBase))
+		require.NoError(t, err)
+
+		require.Equal(t,
+			solvernet.OrderID(crypto.Keccak256Hash(packed)),
+			solvernet.EVMOrderID(user, bi.N(7), gasless, evmchain.IDBase),
+		)
+	}
+}
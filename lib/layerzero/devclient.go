@@ -0,0 +1,252 @@
+package layerzero
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"sync"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/log"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// devABI is the subset of the EndpointV2 and OApp ABIs used to deliver messages on devnet.
+const devABI = `[
+	{"type":"event","name":"PacketSent","anonymous":false,"inputs":[
+		{"name":"encodedPayload","type":"bytes","indexed":false},
+		{"name":"options","type":"bytes","indexed":false},
+		{"name":"sendLibrary","type":"address","indexed":false}]},
+	{"type":"function","name":"endpoint","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"lzReceive","stateMutability":"payable","inputs":[
+		{"name":"_origin","type":"tuple","components":[
+			{"name":"srcEid","type":"uint32"},
+			{"name":"sender","type":"bytes32"},
+			{"name":"nonce","type":"uint64"}]},
+		{"name":"_guid","type":"bytes32"},
+		{"name":"_message","type":"bytes"},
+		{"name":"_executor","type":"address"},
+		{"name":"_extraData","type":"bytes"}],"outputs":[]}
+]`
+
+var (
+	devContractABI  = mustParseABI(devABI)
+	packetSentEvent = devContractABI.Events["PacketSent"]
+)
+
+// packetHeaderLen is the length of the encoded packet header (PacketV1Codec):
+// version (1), nonce (8), srcEid (4), sender (32), dstEid (4), receiver (32), guid (32).
+const packetHeaderLen = 113
+
+// DevClient is a devnet stand-in for the LayerZero scan API and executor.
+// It derives message status from the source tx receipt: reverted txs are reported as failed,
+// unknown txs as in-flight. Packets sent by successful txs are delivered to the destination
+// OApp by impersonating its endpoint, and reported as delivered once mined.
+// It requires rpcs to be anvil forks run with auto impersonation.
+type DevClient struct {
+	mu         sync.Mutex
+	ethClients map[uint64]ethclient.Client
+	delivered  map[common.Hash]bool // Delivered packets by guid
+}
+
+var _ Client = (*DevClient)(nil)
+
+// NewDevClient returns a new devnet LayerZero client delivering packets between the given eth clients.
+func NewDevClient(ethClients map[uint64]ethclient.Client) *DevClient {
+	return &DevClient{
+		ethClients: ethClients,
+		delivered:  make(map[common.Hash]bool),
+	}
+}
+
+// GetMessagesByTx returns a single message with status derived from the source tx receipt,
+// delivering its packets first if not already delivered.
+func (c *DevClient) GetMessagesByTx(ctx context.Context, txHash string) ([]Message, error) {
+	hash := common.HexToHash(txHash)
+
+	for _, client := range c.ethClients {
+		receipt, err := client.TransactionReceipt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "get receipt")
+		}
+
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			return []Message{{Status: Status{Name: MsgStatusFailed.String()}}}, nil
+		}
+
+		if err := c.deliver(ctx, receipt); err != nil {
+			return nil, errors.Wrap(err, "deliver", "tx", txHash)
+		}
+
+		return []Message{{Status: Status{Name: MsgStatusDelivered.String()}}}, nil
+	}
+
+	return []Message{{Status: Status{Name: MsgStatusInFlight.String()}}}, nil
+}
+
+// deliver delivers all packets sent in the receipt that are not already delivered.
+func (c *DevClient) deliver(ctx context.Context, receipt *ethtypes.Receipt) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, elog := range receipt.Logs {
+		if len(elog.Topics) == 0 || elog.Topics[0] != packetSentEvent.ID {
+			continue
+		}
+
+		values, err := packetSentEvent.Inputs.Unpack(elog.Data)
+		if err != nil {
+			return errors.Wrap(err, "unpack packet sent")
+		}
+
+		pkt, err := decodePacket(values[0].([]byte)) //nolint:forcetypeassert // Type known from ABI
+		if err != nil {
+			return err
+		}
+
+		if c.delivered[pkt.GUID] {
+			continue
+		}
+
+		if err := c.deliverPacket(ctx, pkt); err != nil {
+			return err
+		}
+
+		c.delivered[pkt.GUID] = true
+	}
+
+	return nil
+}
+
+// deliverPacket calls the receiver's lzReceive from its endpoint, like EndpointV2.lzReceive after verification.
+func (c *DevClient) deliverPacket(ctx context.Context, pkt packet) error {
+	destChainID, ok := ChainByEID(pkt.DstEID)
+	if !ok {
+		return errors.New("unknown destination eid", "eid", pkt.DstEID)
+	}
+
+	client, ok := c.ethClients[destChainID]
+	if !ok {
+		return errors.New("no eth client for destination", "chain_id", destChainID)
+	}
+
+	receiver := common.BytesToAddress(pkt.Receiver[:])
+
+	endpoint, err := callEndpoint(ctx, client, receiver)
+	if err != nil {
+		return err
+	}
+
+	// Fund endpoint gas, since it is impersonated
+	if err := client.CallContext(ctx, nil, "anvil_setBalance", endpoint, hexutil.EncodeBig(bi.Ether(1))); err != nil {
+		return errors.Wrap(err, "fund endpoint")
+	}
+
+	type origin struct {
+		SrcEid uint32
+		Sender [32]byte
+		Nonce  uint64
+	}
+	calldata, err := devContractABI.Pack("lzReceive",
+		origin{SrcEid: pkt.SrcEID, Sender: pkt.Sender, Nonce: pkt.Nonce},
+		pkt.GUID, pkt.Message, common.Address{}, []byte{})
+	if err != nil {
+		return errors.Wrap(err, "pack lz receive")
+	}
+
+	msg := ethereum.CallMsg{From: endpoint, To: &receiver, Data: calldata}
+	gas, err := client.EstimateGas(ctx, msg)
+	if err != nil {
+		return errors.Wrap(err, "estimate lz receive gas")
+	}
+
+	var txHash common.Hash
+	err = client.CallContext(ctx, &txHash, "eth_sendTransaction", map[string]any{
+		"from": endpoint,
+		"to":   receiver,
+		"data": hexutil.Bytes(calldata),
+		"gas":  hexutil.Uint64(gas),
+	})
+	if err != nil {
+		return errors.Wrap(err, "send lz receive")
+	}
+
+	rec, err := bind.WaitMinedHash(ctx, client, txHash)
+	if err != nil {
+		return errors.Wrap(err, "wait mined")
+	} else if rec.Status != ethtypes.ReceiptStatusSuccessful {
+		return errors.New("lz receive reverted", "tx", txHash)
+	}
+
+	log.Debug(ctx, "Delivered LayerZero packet", "guid", common.Hash(pkt.GUID), "receiver", receiver, "tx", txHash)
+
+	return nil
+}
+
+// callEndpoint returns the endpoint of the OApp.
+func callEndpoint(ctx context.Context, client ethclient.Client, oapp common.Address) (common.Address, error) {
+	calldata, err := devContractABI.Pack("endpoint")
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "pack endpoint")
+	}
+
+	bz, err := client.CallContract(ctx, ethereum.CallMsg{To: &oapp, Data: calldata}, nil)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "call endpoint")
+	}
+
+	values, err := devContractABI.Unpack("endpoint", bz)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "unpack endpoint")
+	}
+
+	return values[0].(common.Address), nil //nolint:forcetypeassert // Type known from ABI
+}
+
+// packet is a decoded LayerZero V2 packet.
+type packet struct {
+	Nonce    uint64
+	SrcEID   uint32
+	Sender   [32]byte
+	DstEID   uint32
+	Receiver [32]byte
+	GUID     common.Hash
+	Message  []byte
+}
+
+// decodePacket decodes a PacketV1Codec encoded packet.
+func decodePacket(bz []byte) (packet, error) {
+	if len(bz) < packetHeaderLen {
+		return packet{}, errors.New("packet too short", "len", len(bz))
+	}
+
+	var pkt packet
+	pkt.Nonce = binary.BigEndian.Uint64(bz[1:9])
+	pkt.SrcEID = binary.BigEndian.Uint32(bz[9:13])
+	copy(pkt.Sender[:], bz[13:45])
+	pkt.DstEID = binary.BigEndian.Uint32(bz[45:49])
+	copy(pkt.Receiver[:], bz[49:81])
+	pkt.GUID = common.BytesToHash(bz[81:113])
+	pkt.Message = bz[packetHeaderLen:]
+
+	return pkt, nil
+}
+
+func mustParseABI(s string) abi.ABI {
+	resp, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+
+	return resp
+}
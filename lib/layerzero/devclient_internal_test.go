@@ -0,0 +1,50 @@
+package layerzero
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tutil"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodePacket(t *testing.T) {
+	t.Parallel()
+
+	sender := tutil.RandomHash()
+	receiver := tutil.RandomHash()
+	guid := tutil.RandomHash()
+	message := []byte("message")
+
+	bz := []byte{1} // Version
+	bz = binary.BigEndian.AppendUint64(bz, 7)
+	bz = binary.BigEndian.AppendUint32(bz, eidByChain[evmchain.IDEthereum])
+	bz = append(bz, sender[:]...)
+	bz = binary.BigEndian.AppendUint32(bz, eidByChain[evmchain.IDHyperEVM])
+	bz = append(bz, receiver[:]...)
+	bz = append(bz, guid[:]...)
+	bz = append(bz, message...)
+
+	pkt, err := decodePacket(bz)
+	require.NoError(t, err)
+	require.Equal(t, packet{
+		Nonce:    7,
+		SrcEID:   eidByChain[evmchain.IDEthereum],
+		Sender:   sender,
+		DstEID:   eidByChain[evmchain.IDHyperEVM],
+		Receiver: receiver,
+		GUID:     common.Hash(guid),
+		Message:  message,
+	}, pkt)
+
+	chainID, ok := ChainByEID(pkt.DstEID)
+	require.True(t, ok)
+	require.Equal(t, evmchain.IDHyperEVM, chainID)
+
+	_, err = decodePacket(bz[:packetHeaderLen-1])
+	require.ErrorContains(t, err, "packet too short")
+}
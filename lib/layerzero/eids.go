@@ -18,3 +18,14 @@ func EIDByChain(chainID uint64) (uint32, bool) {
 	eid, ok := eidByChain[chainID]
 	return eid, ok
 }
+
+// ChainByEID returns the chain ID of LayerZero's Endpoint ID (EID).
+func ChainByEID(eid uint32) (uint64, bool) {
+	for chainID, e := range eidByChain {
+		if e == eid {
+			return chainID, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,292 @@
+package tokenpricer
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/tokens"
+)
+
+// Source is a USD price source queried by the Aggregator.
+type Source interface {
+	// Name returns the name of the source, used in logs and metrics.
+	Name() string
+	// USDPrice returns the price of the asset in USD and the time it was observed.
+	USDPrice(ctx context.Context, asset tokens.Asset) (*big.Rat, time.Time, error)
+}
+
+// minOutlierSources is the min number of fresh source prices required to identify (and drop) outliers.
+const minOutlierSources = 3
+
+// Aggregator is a Pricer that queries multiple sources and returns the median USD price.
+// Prices older than the max age are ignored, as are outliers deviating from the median by more than the max deviation.
+// Outliers can only be identified with at least minOutlierSources fresh prices; if fewer prices disagree,
+// the primary (first) source price is returned, or the static price if the primary price isn't fresh.
+// Static prices are never part of the median, they are only returned if no source price is fresh.
+type Aggregator struct {
+	sources      []Source
+	static       map[tokens.Asset]float64
+	maxAge       time.Duration
+	maxDeviation float64
+	now          func() time.Time
+}
+
+var _ Pricer = (*Aggregator)(nil)
+
+// NewAggregator returns a new Aggregator of the provided sources, the first being the primary source.
+// The maxDeviation is the relative deviation from the median (e.g. 0.05 for 5%) beyond which a source price is dropped.
+func NewAggregator(maxAge time.Duration, maxDeviation float64, sources ...Source) *Aggregator {
+	return &Aggregator{
+		sources:      sources,
+		maxAge:       maxAge,
+		maxDeviation: maxDeviation,
+		now:          time.Now,
+	}
+}
+
+// WithStaticFallback returns a copy of the aggregator that falls back to the static (configured) USD prices
+// if no source price is fresh. Static prices should only be configured for assets with stable prices (e.g. stablecoins).
+func (a *Aggregator) WithStaticFallback(prices map[tokens.Asset]float64) *Aggregator {
+	clone := *a
+	clone.static = prices
+
+	return &clone
+}
+
+// Price returns the price of the base asset denominated in the quote asset.
+// Note that for canonical solver prices, base=deposit and quote=expense.
+func (a *Aggregator) Price(ctx context.Context, base, quote tokens.Asset) (*big.Rat, error) {
+	if base == quote {
+		return big.NewRat(1, 1), nil
+	}
+
+	prices, err := a.USDPricesRat(ctx, base, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if prices[quote].Sign() == 0 {
+		return nil, errors.New("zero quote price", "quote", quote)
+	}
+
+	return new(big.Rat).Quo(prices[base], prices[quote]), nil
+}
+
+func (a *Aggregator) USDPrice(ctx context.Context, asset tokens.Asset) (float64, error) {
+	price, err := a.USDPriceRat(ctx, asset)
+	if err != nil {
+		return 0, err
+	}
+
+	f, _ := price.Float64()
+
+	return f, nil
+}
+
+func (a *Aggregator) USDPriceRat(ctx context.Context, asset tokens.Asset) (*big.Rat, error) {
+	return a.aggregate(ctx, asset)
+}
+
+func (a *Aggregator) USDPrices(ctx context.Context, assets ...tokens.Asset) (map[tokens.Asset]float64, error) {
+	rats, err := a.USDPricesRat(ctx, assets...)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[tokens.Asset]float64)
+	for asset, price := range rats {
+		f, _ := price.Float64()
+		prices[asset] = f
+	}
+
+	return prices, nil
+}
+
+func (a *Aggregator) USDPricesRat(ctx context.Context, assets ...tokens.Asset) (map[tokens.Asset]*big.Rat, error) {
+	prices := make(map[tokens.Asset]*big.Rat)
+	for _, asset := range assets {
+		price, err := a.aggregate(ctx, asset)
+		if err != nil {
+			return nil, err
+		}
+
+		prices[asset] = price
+	}
+
+	return prices, nil
+}
+
+type sourcePrice struct {
+	Source string
+	Price  *big.Rat
+}
+
+// aggregate returns the median USD price of the asset across all fresh non-outlier source prices.
+func (a *Aggregator) aggregate(ctx context.Context, asset tokens.Asset) (*big.Rat, error) {
+	prices := a.fetch(ctx, asset)
+	if len(prices) == 0 {
+		return a.staticFallback(ctx, asset)
+	}
+
+	median := medianOf(prices)
+	if median.Sign() <= 0 {
+		return nil, errors.New("non-positive median price", "asset", asset)
+	}
+
+	var inliers []sourcePrice
+	for _, p := range prices {
+		deviation := relativeDeviation(p.Price, median)
+		sourceDeviation.WithLabelValues(p.Source, asset.Symbol).Set(deviation)
+
+		if abs(deviation) > a.maxDeviation && len(prices) < minOutlierSources {
+			return a.primary(ctx, asset, prices)
+		} else if abs(deviation) > a.maxDeviation {
+			sourceOutliers.WithLabelValues(p.Source, asset.Symbol).Inc()
+			log.Warn(ctx, "Dropping outlier source price", nil,
+				"source", p.Source,
+				"asset", asset,
+				"price", p.Price.FloatString(6),
+				"median", median.FloatString(6),
+				"deviation", deviation,
+			)
+
+			continue
+		}
+
+		inliers = append(inliers, p)
+	}
+
+	if len(inliers) == 0 {
+		return nil, errors.New("source prices disagree", "asset", asset, "sources", len(prices))
+	}
+
+	return medianOf(inliers), nil
+}
+
+// primary returns the primary source price of the asset if fresh, otherwise its static price.
+// It is used when too few source prices disagree to identify the outlier.
+func (a *Aggregator) primary(ctx context.Context, asset tokens.Asset, prices []sourcePrice) (*big.Rat, error) {
+	primary := a.sources[0].Name()
+	for _, p := range prices {
+		if p.Source != primary {
+			continue
+		}
+
+		primaryFallbacks.WithLabelValues(asset.Symbol).Inc()
+		log.Warn(ctx, "Source prices disagree, using primary source price", nil,
+			"source", primary,
+			"asset", asset,
+			"price", p.Price.FloatString(6),
+			"sources", len(prices),
+		)
+
+		return p.Price, nil
+	}
+
+	price, err := a.staticFallback(ctx, asset)
+	if err != nil {
+		return nil, errors.New("source prices disagree", "asset", asset, "sources", len(prices))
+	}
+
+	return price, nil
+}
+
+// fetch returns the fresh USD prices of the asset from all sources, concurrently.
+// Source errors and stale prices are instrumented and dropped.
+func (a *Aggregator) fetch(ctx context.Context, asset tokens.Asset) []sourcePrice {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		prices []sourcePrice
+	)
+
+	for _, source := range a.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			price, observed, err := source.USDPrice(ctx, asset)
+			if err != nil {
+				sourceErrors.WithLabelValues(source.Name(), asset.Symbol).Inc()
+				log.Debug(ctx, "Source price failed", "source", source.Name(), "asset", asset, "err", err)
+
+				return
+			}
+
+			if age := a.now().Sub(observed); age > a.maxAge {
+				sourceStale.WithLabelValues(source.Name(), asset.Symbol).Inc()
+				log.Debug(ctx, "Ignoring stale source price", "source", source.Name(), "asset", asset, "age", age)
+
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			prices = append(prices, sourcePrice{Source: source.Name(), Price: price})
+		}()
+	}
+
+	wg.Wait()
+
+	return prices
+}
+
+// staticFallback returns the static USD price of the asset, if configured.
+func (a *Aggregator) staticFallback(ctx context.Context, asset tokens.Asset) (*big.Rat, error) {
+	price, ok := a.static[asset]
+	if !ok {
+		return nil, errors.New("no fresh source prices", "asset", asset)
+	}
+
+	rat := new(big.Rat)
+	if rat.SetFloat64(price) == nil || rat.Sign() <= 0 {
+		return nil, errors.New("invalid static price", "asset", asset, "price", price)
+	}
+
+	staticFallbacks.WithLabelValues(asset.Symbol).Inc()
+	log.Warn(ctx, "No usable source prices, using static price", nil, "asset", asset, "price", price)
+
+	return rat, nil
+}
+
+// medianOf returns the median of the prices, averaging the middle two if even.
+func medianOf(prices []sourcePrice) *big.Rat {
+	sorted := make([]*big.Rat, 0, len(prices))
+	for _, p := range prices {
+		sorted = append(sorted, p.Price)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+
+	sum := new(big.Rat).Add(sorted[mid-1], sorted[mid])
+
+	return sum.Quo(sum, big.NewRat(2, 1))
+}
+
+// relativeDeviation returns (price - median) / median.
+func relativeDeviation(price, median *big.Rat) float64 {
+	diff := new(big.Rat).Sub(price, median)
+	f, _ := diff.Quo(diff, median).Float64()
+
+	return f
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+
+	return f
+}
@@ -0,0 +1,126 @@
+package tokenpricer_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregator(t *testing.T) {
+	t.Parallel()
+	const epsilon = 1e-6
+
+	ctx := t.Context()
+	ETH := tokens.ETH
+	USDC := tokens.USDC
+	OMNI := tokens.OMNI
+
+	sources := []tokenpricer.Source{
+		testSource{name: "a", prices: map[tokens.Asset]float64{ETH: 2990, USDC: 1, OMNI: 5}},
+		testSource{name: "b", prices: map[tokens.Asset]float64{ETH: 3010, USDC: 1}},
+		testSource{name: "outlier", prices: map[tokens.Asset]float64{ETH: 6000, USDC: 1}},
+		testSource{name: "stale", prices: map[tokens.Asset]float64{ETH: 1, OMNI: 1}, age: time.Hour},
+		testSource{name: "error"},
+	}
+
+	agg := tokenpricer.NewAggregator(time.Minute, 0.05, sources...)
+
+	// Median of fresh non-outlier prices: 2990, 3010
+	price, err := agg.USDPrice(ctx, ETH)
+	require.NoError(t, err)
+	require.InEpsilon(t, 3000.0, price, epsilon)
+
+	// Only a single fresh OMNI price
+	prices, err := agg.USDPrices(ctx, ETH, OMNI)
+	require.NoError(t, err)
+	require.InEpsilon(t, 3000.0, prices[ETH], epsilon)
+	require.InEpsilon(t, 5.0, prices[OMNI], epsilon)
+
+	rat, err := agg.Price(ctx, ETH, USDC)
+	require.NoError(t, err)
+	require.Equal(t, big.NewRat(3000, 1), rat)
+
+	// No fresh prices
+	_, err = agg.USDPrice(ctx, tokens.WSTETH)
+	require.ErrorContains(t, err, "no fresh source prices")
+
+	// Static prices are only used if no source price is fresh, never in the median
+	static := agg.WithStaticFallback(map[tokens.Asset]float64{tokens.WSTETH: 4000, ETH: 1})
+	price, err = static.USDPrice(ctx, tokens.WSTETH)
+	require.NoError(t, err)
+	require.InEpsilon(t, 4000.0, price, epsilon)
+	price, err = static.USDPrice(ctx, ETH)
+	require.NoError(t, err)
+	require.InEpsilon(t, 3000.0, price, epsilon)
+
+	// Even number of sources averages the middle two
+	agg = tokenpricer.NewAggregator(time.Minute, 0.05,
+		testSource{name: "a", prices: map[tokens.Asset]float64{ETH: 3000}},
+		testSource{name: "b", prices: map[tokens.Asset]float64{ETH: 3020}},
+	)
+	price, err = agg.USDPrice(ctx, ETH)
+	require.NoError(t, err)
+	require.InEpsilon(t, 3010.0, price, epsilon)
+}
+
+func TestAggregatorTwoSources(t *testing.T) {
+	t.Parallel()
+	const epsilon = 1e-6
+
+	ctx := t.Context()
+	ETH := tokens.ETH
+	USDC := tokens.USDC
+
+	// Outliers cannot be identified with two sources, so disagreeing prices use the primary (first) source.
+	agg := tokenpricer.NewAggregator(time.Minute, 0.05,
+		testSource{name: "primary", prices: map[tokens.Asset]float64{ETH: 3000, USDC: 1}},
+		testSource{name: "broken", prices: map[tokens.Asset]float64{ETH: 4000, USDC: 1.001}},
+	)
+	price, err := agg.USDPrice(ctx, ETH)
+	require.NoError(t, err)
+	require.InEpsilon(t, 3000.0, price, epsilon)
+
+	// Agreeing prices use the median
+	price, err = agg.USDPrice(ctx, USDC)
+	require.NoError(t, err)
+	require.InEpsilon(t, 1.0005, price, epsilon)
+
+	// Disagreeing prices without a fresh primary price use the static price, if configured.
+	agg = tokenpricer.NewAggregator(time.Minute, 0.05,
+		testSource{name: "primary", prices: map[tokens.Asset]float64{USDC: 1}, age: time.Hour},
+		testSource{name: "a", prices: map[tokens.Asset]float64{USDC: 1}},
+		testSource{name: "b", prices: map[tokens.Asset]float64{USDC: 0.5}},
+	)
+	_, err = agg.USDPrice(ctx, USDC)
+	require.ErrorContains(t, err, "source prices disagree")
+
+	price, err = agg.WithStaticFallback(map[tokens.Asset]float64{USDC: 1}).USDPrice(ctx, USDC)
+	require.NoError(t, err)
+	require.InEpsilon(t, 1.0, price, epsilon)
+}
+
+type testSource struct {
+	name   string
+	prices map[tokens.Asset]float64
+	age    time.Duration
+}
+
+func (s testSource) Name() string {
+	return s.name
+}
+
+func (s testSource) USDPrice(_ context.Context, asset tokens.Asset) (*big.Rat, time.Time, error) {
+	price, ok := s.prices[asset]
+	if !ok {
+		return nil, time.Time{}, errors.New("no price")
+	}
+
+	return new(big.Rat).SetFloat64(price), time.Now().Add(-s.age), nil
+}
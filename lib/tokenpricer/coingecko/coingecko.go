@@ -26,6 +26,7 @@ const (
 	proProdHost         = "https://pro-api.coingecko.com"
 	apikeyHeader        = "x-cg-pro-api-key" //nolint:gosec // This is the header
 	currencyUSD         = "usd"
+	keyLastUpdatedAt    = "last_updated_at"
 )
 
 type Client struct {
@@ -124,12 +125,18 @@ func (c Client) USDPriceRat(ctx context.Context, asset tokens.Asset) (*big.Rat,
 	return rats[asset], nil
 }
 
-// map[asset.CoingeckoID]map[currency]price.
+// map[asset.CoingeckoID]map[currency|last_updated_at]value.
 type simplePriceResponse map[string]map[string]json.Number
 
 // GetPrice returns the price of each asset in the given currency.
 // See supported currencies: https://api.coingecko.com/api/v3/simple/supported_vs_currencies
 func (c Client) getPrice(ctx context.Context, currency string, assets ...tokens.Asset) (map[tokens.Asset]*big.Rat, error) {
+	prices, _, err := c.getPriceUpdated(ctx, currency, assets...)
+	return prices, err
+}
+
+// getPriceUpdated returns the price of each asset in the given currency and the time CoinGecko last updated it.
+func (c Client) getPriceUpdated(ctx context.Context, currency string, assets ...tokens.Asset) (map[tokens.Asset]*big.Rat, map[tokens.Asset]time.Time, error) {
 	ctx, span := tracer.Start(ctx, "coingecko/price", trace.WithAttributes(
 		attribute.String("currency", currency),
 		attribute.String("assets", fmt.Sprint(assets)),
@@ -142,41 +149,48 @@ func (c Client) getPrice(ctx context.Context, currency string, assets ...tokens.
 	}
 
 	params := url.Values{
-		"ids":           {strings.Join(ids, ",")},
-		"vs_currencies": {currency},
+		"ids":                     {strings.Join(ids, ",")},
+		"vs_currencies":           {currency},
+		"include_last_updated_at": {"true"},
 	}
 
 	var resp simplePriceResponse
 	if err := c.doReq(ctx, endpointSimplePrice, params, &resp); err != nil {
-		return nil, errors.Wrap(err, "do req", "endpoint", "get_price")
+		return nil, nil, errors.Wrap(err, "do req", "endpoint", "get_price")
 	}
 
 	prices := make(map[tokens.Asset]*big.Rat)
+	updated := make(map[tokens.Asset]time.Time)
 
 	for _, asset := range assets {
 		priceByCurrency, ok := resp[asset.CoingeckoID]
 		if !ok {
-			return nil, errors.New("missing asset in response", "asset", asset)
+			return nil, nil, errors.New("missing asset in response", "asset", asset)
 		}
 
 		priceStr, ok := priceByCurrency[currency]
 		if !ok {
-			return nil, errors.New("missing price in response", "asset", asset, "currency", currency)
+			return nil, nil, errors.New("missing price in response", "asset", asset, "currency", currency)
 		}
 
 		// Parsing the json.Number as big.Rat floating point number
 		// This avoid going through float64 which can lose precision
 		price, ok := new(big.Rat).SetString(priceStr.String()) //nolint:gosec // CVE-2022-23772 fixed in go1.17
 		if !ok {
-			return nil, errors.New("invalid price string", "asset", asset, "price", priceStr)
+			return nil, nil, errors.New("invalid price string", "asset", asset, "price", priceStr)
 		} else if price.Sign() <= 0 {
-			return nil, errors.New("proic enot positive", "asset", asset, "price", price)
+			return nil, nil, errors.New("proic enot positive", "asset", asset, "price", price)
 		}
 
 		prices[asset] = price
+
+		// Prices without an update time are omitted from updated.
+		if updatedAt, err := priceByCurrency[keyLastUpdatedAt].Int64(); err == nil {
+			updated[asset] = time.Unix(updatedAt, 0)
+		}
 	}
 
-	return prices, nil
+	return prices, updated, nil
 }
 
 // doReq makes a GET request to the given path & params, and decodes the response into response.
@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/omni-network/omni/lib/tokenpricer/coingecko"
 	"github.com/omni-network/omni/lib/tokens"
@@ -66,6 +67,7 @@ type testCase struct {
 	omitCurrency string       // omit a requested currency
 	zeros        bool         // include zero prices
 	negatives    bool         // include negative prices
+	omitUpdated  bool         // omit last updated at
 }
 
 func TestGetUSDPrice(t *testing.T) {
@@ -182,6 +184,10 @@ func makeTestServer(t *testing.T, test testCase) (*httptest.Server, map[string]m
 				// also store the price, so we can assert against it
 				servedPrices[id][currency] = resp[id][currency]
 			}
+
+			if q.Get("include_last_updated_at") == "true" && !test.omitUpdated {
+				resp[id]["last_updated_at"] = float64(testUpdatedAt.Unix())
+			}
 		}
 
 		bz, _ := json.Marshal(resp)
@@ -191,6 +197,34 @@ func makeTestServer(t *testing.T, test testCase) (*httptest.Server, map[string]m
 	return server, servedPrices, apikey
 }
 
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []testCase{{name: "success"}, {name: "omit updated", omitUpdated: true}} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, servedPrices, token := makeTestServer(t, test)
+			defer server.Close()
+
+			source := coingecko.NewSource(coingecko.New(coingecko.WithHost(server.URL), coingecko.WithAPIKey(token)))
+			price, observed, err := source.USDPrice(t.Context(), tokens.ETH)
+			if test.omitUpdated {
+				require.ErrorContains(t, err, "missing last updated at")
+				return
+			}
+
+			require.NoError(t, err)
+			f, _ := price.Float64()
+			require.InEpsilon(t, servedPrices[tokens.ETH.CoingeckoID]["usd"], f, 0.01)
+			require.Equal(t, testUpdatedAt, observed)
+		})
+	}
+}
+
+// testUpdatedAt is the last updated at time served by the test server.
+var testUpdatedAt = time.Unix(1711356300, 0)
+
 func randPrice() float64 {
 	return float64(int((rand.Float64()+0.01)*10000)) / 100
 }
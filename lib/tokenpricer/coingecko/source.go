@@ -0,0 +1,43 @@
+package coingecko
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+)
+
+// Source is a tokenpricer.Source of CoinGecko USD prices.
+// Prices are observed when CoinGecko last updated them, not when fetched.
+type Source struct {
+	c Client
+}
+
+var _ tokenpricer.Source = Source{}
+
+// NewSource returns a new Source querying the client.
+func NewSource(c Client) Source {
+	return Source{c: c}
+}
+
+func (Source) Name() string {
+	return "coingecko"
+}
+
+// USDPrice returns the USD price of the asset and the time CoinGecko last updated it.
+func (s Source) USDPrice(ctx context.Context, asset tokens.Asset) (*big.Rat, time.Time, error) {
+	prices, updated, err := s.c.getPriceUpdated(ctx, currencyUSD, asset)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "get price")
+	}
+
+	updatedAt, ok := updated[asset]
+	if !ok {
+		return nil, time.Time{}, errors.New("missing last updated at", "asset", asset)
+	}
+
+	return prices[asset], updatedAt, nil
+}
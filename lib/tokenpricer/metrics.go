@@ -0,0 +1,72 @@
+package tokenpricer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	sourceDeviation = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "lib",
+		Subsystem: "tokenpricer",
+		Name:      "source_deviation_ratio",
+		Help:      "Relative deviation of a source's USD price from the median of all sources, by source and asset",
+	}, []string{"source", "asset"})
+
+	sourceOutliers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "tokenpricer",
+		Name:      "source_outliers_total",
+		Help:      "Total number of source USD prices dropped as outliers, by source and asset",
+	}, []string{"source", "asset"})
+
+	sourceStale = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "tokenpricer",
+		Name:      "source_stale_total",
+		Help:      "Total number of source USD prices ignored as stale, by source and asset",
+	}, []string{"source", "asset"})
+
+	sourceErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "tokenpricer",
+		Name:      "source_errors_total",
+		Help:      "Total number of source USD price errors, by source and asset",
+	}, []string{"source", "asset"})
+
+	staticFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "tokenpricer",
+		Name:      "static_fallback_total",
+		Help:      "Total number of static USD prices returned since no source (or primary source if disagreeing) price was fresh, by asset",
+	}, []string{"asset"})
+
+	primaryFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "tokenpricer",
+		Name:      "primary_fallback_total",
+		Help:      "Total number of primary source USD prices returned since too few source prices disagreed to identify outliers, by asset",
+	}, []string{"asset"})
+
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "tokenpricer",
+		Name:      "cache_hits_total",
+		Help:      "Total number of cached price hits, by pair",
+	}, []string{"pair"})
+
+	cacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lib",
+		Subsystem: "tokenpricer",
+		Name:      "cache_misses_total",
+		Help:      "Total number of cached price misses (absent or expired), by pair",
+	}, []string{"pair"})
+
+	cacheAge = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "lib",
+		Subsystem: "tokenpricer",
+		Name:      "cache_hit_age_seconds",
+		Help:      "Age in seconds of cached prices on hit, by pair",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	}, []string{"pair"})
+)
@@ -3,11 +3,16 @@ package tokenpricer
 import (
 	"context"
 	"math/big"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
 	"github.com/omni-network/omni/lib/tokens"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // Pricer is the token price provider interface.
@@ -25,10 +30,19 @@ type Pricer interface {
 	Price(ctx context.Context, base, quote tokens.Asset) (*big.Rat, error)
 }
 
+// fetchTimeout is the timeout of coalesced fetches, which are detached from the callers' contexts.
+const fetchTimeout = 30 * time.Second
+
+// Cached is a Pricer that caches prices per (base, quote) pair.
+// Entries expire after the TTL (if any), and concurrent fetches of the same prices are coalesced.
+// Hot entries can be refreshed in the background via RefreshForever, so they are never fetched on the hot path.
 type Cached struct {
 	p     Pricer
+	ttl   time.Duration
+	now   func() time.Time
+	group singleflight.Group
 	mu    sync.RWMutex
-	cache map[pair]*big.Rat
+	cache map[pair]cacheEntry
 }
 
 type pair struct {
@@ -36,27 +50,84 @@ type pair struct {
 	Quote tokens.Asset
 }
 
-func NewCached(p Pricer) *Cached {
+func (p pair) String() string {
+	return p.Base.Symbol + "/" + p.Quote.Symbol
+}
+
+type cacheEntry struct {
+	Price   *big.Rat
+	Fetched time.Time
+	LastHit time.Time
+}
+
+type cachedOptions struct {
+	TTL time.Duration
+}
+
+// WithTTL returns an option that expires cached prices after the TTL.
+// By default, cached prices never expire.
+func WithTTL(ttl time.Duration) func(*cachedOptions) {
+	return func(o *cachedOptions) {
+		o.TTL = ttl
+	}
+}
+
+func NewCached(p Pricer, opts ...func(*cachedOptions)) *Cached {
+	var o cachedOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return &Cached{
 		p:     p,
-		cache: make(map[pair]*big.Rat),
+		ttl:   o.TTL,
+		now:   time.Now,
+		cache: make(map[pair]cacheEntry),
 	}
 }
 
+// get returns the cached price of the pair if present and not expired.
 func (c *Cached) get(base, quote tokens.Asset) (*big.Rat, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	price, ok := c.cache[pair{Base: base, Quote: quote}]
+	key := pair{Base: base, Quote: quote}
+	now := c.now()
+
+	entry, ok := c.cache[key]
+	if !ok || c.expired(entry, now) {
+		cacheMisses.WithLabelValues(key.String()).Inc()
+		return nil, false
+	}
 
-	return price, ok
+	cacheHits.WithLabelValues(key.String()).Inc()
+	cacheAge.WithLabelValues(key.String()).Observe(now.Sub(entry.Fetched).Seconds())
+
+	entry.LastHit = now
+	c.cache[key] = entry
+
+	return entry.Price, true
 }
 
 func (c *Cached) set(base, quote tokens.Asset, price *big.Rat) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.cache[pair{Base: base, Quote: quote}] = price
+	key := pair{Base: base, Quote: quote}
+	now := c.now()
+
+	entry := c.cache[key]
+	entry.Price = price
+	entry.Fetched = now
+	if entry.LastHit.IsZero() {
+		entry.LastHit = now
+	}
+
+	c.cache[key] = entry
+}
+
+func (c *Cached) expired(entry cacheEntry, now time.Time) bool {
+	return c.ttl > 0 && now.Sub(entry.Fetched) > c.ttl
 }
 
 // Price returns the price of the base asset denominated in the quote asset.
@@ -71,12 +142,31 @@ func (c *Cached) Price(ctx context.Context, base, quote tokens.Asset) (*big.Rat,
 		return price, nil
 	}
 
-	price, err := c.p.Price(ctx, base, quote)
+	return c.fetchPrice(ctx, base, quote)
+}
+
+// fetchPrice fetches and caches the price of the pair, coalescing concurrent fetches.
+func (c *Cached) fetchPrice(ctx context.Context, base, quote tokens.Asset) (*big.Rat, error) {
+	key := pair{Base: base, Quote: quote}.String()
+
+	v, err := c.do(ctx, key, func(ctx context.Context) (any, error) {
+		price, err := c.p.Price(ctx, base, quote)
+		if err != nil {
+			return nil, err
+		}
+
+		c.set(base, quote, price)
+
+		return price, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	c.set(base, quote, price)
+	price, ok := v.(*big.Rat)
+	if !ok {
+		return nil, errors.New("invalid price type [BUG]")
+	}
 
 	return price, nil
 }
@@ -137,28 +227,82 @@ func (c *Cached) USDPricesRat(ctx context.Context, assets ...tokens.Asset) (map[
 		return prices, nil
 	}
 
-	newPrices, err := c.p.USDPricesRat(ctx, uncached...)
+	newPrices, err := c.fetchUSDPrices(ctx, uncached)
 	if err != nil {
 		return nil, err
 	}
 
 	for token, price := range newPrices {
 		prices[token] = price
-		c.set(token, tokens.USDC, price)
 	}
 
 	return prices, nil
 }
 
+// fetchUSDPrices fetches and caches the USD prices of the assets, coalescing concurrent fetches of the same assets.
+func (c *Cached) fetchUSDPrices(ctx context.Context, assets []tokens.Asset) (map[tokens.Asset]*big.Rat, error) {
+	symbols := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		symbols = append(symbols, asset.Symbol)
+	}
+	sort.Strings(symbols)
+	key := strings.Join(symbols, ",") + "/USD"
+
+	v, err := c.do(ctx, key, func(ctx context.Context) (any, error) {
+		prices, err := c.p.USDPricesRat(ctx, assets...)
+		if err != nil {
+			return nil, err
+		}
+
+		for token, price := range prices {
+			c.set(token, tokens.USDC, price)
+		}
+
+		return prices, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prices, ok := v.(map[tokens.Asset]*big.Rat)
+	if !ok {
+		return nil, errors.New("invalid prices type [BUG]")
+	}
+
+	return prices, nil
+}
+
+// do calls fn once for concurrent callers of the same key.
+// The shared fn is detached from the callers' contexts (with fetchTimeout instead),
+// so a canceled caller doesn't fail the others, while each caller only waits for its own context.
+func (c *Cached) do(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+	resp := c.group.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+
+		return fn(ctx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "await price fetch")
+	case res := <-resp:
+		return res.Val, res.Err
+	}
+}
+
 func (c *Cached) ClearCache() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.cache = make(map[pair]*big.Rat)
+	c.cache = make(map[pair]cacheEntry)
 }
 
-func (c *Cached) ClearCacheForever(ctx context.Context, evictInterval time.Duration) {
-	ticker := time.NewTicker(evictInterval)
+// RefreshForever blocks and refreshes hot cache entries every interval, until the context is canceled.
+// Entries hit since the previous refresh are refetched, while cold expired entries are evicted.
+// The interval should be shorter than the TTL for hot entries to never expire.
+func (c *Cached) RefreshForever(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -166,7 +310,36 @@ func (c *Cached) ClearCacheForever(ctx context.Context, evictInterval time.Durat
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			c.ClearCache()
+			c.refreshOnce(ctx, interval)
+		}
+	}
+}
+
+// refreshOnce refetches entries hit within the interval and evicts cold expired entries.
+func (c *Cached) refreshOnce(ctx context.Context, interval time.Duration) {
+	var hot []pair
+
+	c.mu.Lock()
+	now := c.now()
+	for key, entry := range c.cache {
+		if now.Sub(entry.LastHit) <= interval {
+			hot = append(hot, key)
+		} else if c.expired(entry, now) {
+			delete(c.cache, key)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, key := range hot {
+		var err error
+		if key.Quote == tokens.USDC {
+			_, err = c.fetchUSDPrices(ctx, []tokens.Asset{key.Base})
+		} else {
+			_, err = c.fetchPrice(ctx, key.Base, key.Quote)
+		}
+
+		if err != nil {
+			log.Warn(ctx, "Failed refreshing cached price (will retry)", err, "pair", key)
 		}
 	}
 }
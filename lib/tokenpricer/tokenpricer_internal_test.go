@@ -0,0 +1,163 @@
+package tokenpricer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/tokens"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedTTL(t *testing.T) {
+	t.Parallel()
+	const epsilon = 1e-6
+
+	ctx := t.Context()
+	ETH := tokens.ETH
+	OMNI := tokens.OMNI
+
+	pricer := &countingPricer{Pricer: NewUSDMock(map[tokens.Asset]float64{ETH: 100, OMNI: 200})}
+	cached := NewCached(pricer, WithTTL(time.Minute))
+
+	now := time.Unix(1700000000, 0)
+	cached.now = func() time.Time { return now }
+
+	price, err := cached.USDPrice(ctx, ETH)
+	require.NoError(t, err)
+	require.InEpsilon(t, 100.0, price, epsilon)
+	require.EqualValues(t, 1, pricer.calls.Load())
+
+	pricer.Pricer.(*Mock).SetUSDPrice(ETH, 150)
+
+	// Not expired yet
+	now = now.Add(time.Minute)
+	price, err = cached.USDPrice(ctx, ETH)
+	require.NoError(t, err)
+	require.InEpsilon(t, 100.0, price, epsilon)
+	require.EqualValues(t, 1, pricer.calls.Load())
+
+	// Expired entries are refetched, other entries are unaffected
+	price, err = cached.USDPrice(ctx, OMNI)
+	require.NoError(t, err)
+	require.InEpsilon(t, 200.0, price, epsilon)
+
+	now = now.Add(time.Second)
+	prices, err := cached.USDPrices(ctx, ETH, OMNI)
+	require.NoError(t, err)
+	require.InEpsilon(t, 150.0, prices[ETH], epsilon)
+	require.InEpsilon(t, 200.0, prices[OMNI], epsilon)
+	require.EqualValues(t, 3, pricer.calls.Load())
+
+	// Hot entries are refreshed, cold expired entries are evicted
+	pricer.Pricer.(*Mock).SetUSDPrice(ETH, 175)
+	now = now.Add(2 * time.Minute)
+	_, ok := cached.get(ETH, tokens.USDC)
+	require.False(t, ok)
+
+	_, err = cached.Price(ctx, ETH, OMNI)
+	require.NoError(t, err)
+
+	now = now.Add(10 * time.Second)
+	cached.refreshOnce(ctx, 30*time.Second)
+	require.Len(t, cached.cache, 1)
+
+	rat, err := cached.Price(ctx, ETH, OMNI)
+	require.NoError(t, err)
+	require.Zero(t, big.NewRat(175, 200).Cmp(rat))
+}
+
+func TestCachedSingleflight(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	release := make(chan struct{})
+	pricer := &countingPricer{
+		Pricer: NewUSDMock(map[tokens.Asset]float64{tokens.ETH: 100}),
+		block:  release,
+	}
+	cached := NewCached(pricer, WithTTL(time.Minute))
+
+	const n = 10
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			price, err := cached.USDPrice(ctx, tokens.ETH)
+			require.NoError(t, err)
+			require.InEpsilon(t, 100.0, price, 1e-6)
+		}()
+	}
+
+	// Wait for the first fetch to start, then give the rest time to coalesce.
+	require.Eventually(t, func() bool { return pricer.calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.EqualValues(t, 1, pricer.calls.Load())
+}
+
+func TestCachedSingleflightCancel(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	pricer := &countingPricer{
+		Pricer: NewUSDMock(map[tokens.Asset]float64{tokens.ETH: 100}),
+		block:  release,
+	}
+	cached := NewCached(pricer, WithTTL(time.Minute))
+
+	// First caller starts the fetch, then cancels.
+	firstCtx, cancel := context.WithCancel(t.Context())
+	first := make(chan error, 1)
+	go func() {
+		_, err := cached.USDPrice(firstCtx, tokens.ETH)
+		first <- err
+	}()
+	require.Eventually(t, func() bool { return pricer.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	second := make(chan error, 1)
+	go func() {
+		price, err := cached.USDPrice(t.Context(), tokens.ETH)
+		if err == nil && price != 100 {
+			err = errors.New("unexpected price")
+		}
+		second <- err
+	}()
+	time.Sleep(10 * time.Millisecond) // Give the second caller time to coalesce
+
+	cancel()
+	require.ErrorIs(t, <-first, context.Canceled)
+
+	// The shared fetch isn't canceled, so coalesced callers still get the price.
+	close(release)
+	require.NoError(t, <-second)
+	require.EqualValues(t, 1, pricer.calls.Load())
+}
+
+// countingPricer wraps a Pricer, counting (and optionally blocking) USD price requests.
+type countingPricer struct {
+	Pricer
+	calls atomic.Int64
+	block chan struct{}
+}
+
+func (p *countingPricer) USDPricesRat(ctx context.Context, assets ...tokens.Asset) (map[tokens.Asset]*big.Rat, error) {
+	p.calls.Add(1)
+	if p.block != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-p.block:
+		}
+	}
+
+	return p.Pricer.USDPricesRat(ctx, assets...)
+}
@@ -0,0 +1,75 @@
+package uniswap
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient/ethbackend"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+)
+
+// PriceSource is a tokenpricer.Source of on-chain USD prices quoted by the Uniswap V3 QuoterV2.
+// It quotes swapping one unit of the asset to USDC, so prices include pool fees.
+type PriceSource struct {
+	chainID uint64
+	backend *ethbackend.Backend
+	quoter  *UniQuoterV2
+}
+
+var _ tokenpricer.Source = PriceSource{}
+
+// NewPriceSource returns a new PriceSource quoting on the backend's chain.
+func NewPriceSource(backend *ethbackend.Backend) (PriceSource, error) {
+	_, chainID := backend.Chain()
+
+	quoter, err := newQuoter(chainID, backend)
+	if err != nil {
+		return PriceSource{}, errors.Wrap(err, "new quoter")
+	}
+
+	return PriceSource{chainID: chainID, backend: backend, quoter: quoter}, nil
+}
+
+func (PriceSource) Name() string {
+	return "uniswap"
+}
+
+// USDPrice returns the USDC amount out of swapping one unit of the asset.
+// It quotes at the latest block, returning that block's timestamp as the observation time.
+func (s PriceSource) USDPrice(ctx context.Context, asset tokens.Asset) (*big.Rat, time.Time, error) {
+	tkn, ok := tokens.ByAsset(s.chainID, asset)
+	if !ok {
+		return nil, time.Time{}, errors.New("unsupported asset", "asset", asset, "chain", s.chainID)
+	}
+
+	swaps, err := routeToUSDC(tkn)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "route to USDC")
+	}
+
+	path, err := encodePath(swaps)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "encode path")
+	}
+
+	usdc := swaps[len(swaps)-1].TokenOut
+	amountIn := bi.Rebase(bi.One(), 0, tkn.Decimals)
+
+	header, err := s.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "latest header")
+	}
+
+	amountOut, err := s.quoter.CallQuoteExactInputAt(ctx, path, amountIn, header.Number)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "quote")
+	}
+
+	observed := time.Unix(int64(header.Time), 0) //nolint:gosec // Block timestamps fit in int64
+
+	return new(big.Rat).SetFrac(amountOut, bi.Rebase(bi.One(), 0, usdc.Decimals)), observed, nil
+}
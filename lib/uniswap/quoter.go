@@ -14,8 +14,14 @@ import (
 // This is needed because quoteExactInput is a mutator, not a view function,
 // so we need to use contract.Call instead of the generated QuoteExactInput method.
 func (q *UniQuoterV2) CallQuoteExactInput(ctx context.Context, path []byte, amountIn *big.Int) (*big.Int, error) {
+	return q.CallQuoteExactInputAt(ctx, path, amountIn, nil)
+}
+
+// CallQuoteExactInputAt calls the quoteExactInput method on the QuoterV2 contract at the provided block number.
+// A nil block number quotes at the latest block.
+func (q *UniQuoterV2) CallQuoteExactInputAt(ctx context.Context, path []byte, amountIn *big.Int, blockNumber *big.Int) (*big.Int, error) {
 	var result []any
-	err := q.UniQuoterV2Caller.contract.Call(&bind.CallOpts{Context: ctx}, &result, "quoteExactInput", path, amountIn)
+	err := q.UniQuoterV2Caller.contract.Call(&bind.CallOpts{Context: ctx, BlockNumber: blockNumber}, &result, "quoteExactInput", path, amountIn)
 	if err != nil {
 		return nil, errors.Wrap(err, "quote exact input")
 	}
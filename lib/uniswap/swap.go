@@ -3,21 +3,82 @@ package uniswap
 import (
 	"context"
 	"encoding/binary"
+	"math"
 	"math/big"
 
 	"github.com/omni-network/omni/contracts/bindings"
 	"github.com/omni-network/omni/lib/bi"
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/ethclient/ethbackend"
+	"github.com/omni-network/omni/lib/evmchain"
 	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/pnl"
+	"github.com/omni-network/omni/lib/tokenpricer"
 	"github.com/omni-network/omni/lib/tokens"
 	"github.com/omni-network/omni/lib/umath"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
+const (
+	// DefaultMaxSlippageBips is the default max slippage of realized vs quoted swap output (0.5%).
+	DefaultMaxSlippageBips = 50
+
+	// DefaultMaxDeviationBips is the default max deviation of quoted swap output vs the reference price output (1%).
+	DefaultMaxDeviationBips = 100
+)
+
+// transferTopic is the ERC20 Transfer event topic.
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// SwapOption configures a swap.
+type SwapOption func(*swapOptions)
+
+// WithMaxSlippage sets the max slippage in bips of the realized vs quoted swap output.
+// The swap reverts if the realized output is lower. Defaults to 50 bips (0.5%).
+func WithMaxSlippage(bips uint64) SwapOption {
+	return func(o *swapOptions) {
+		o.maxSlippageBips = bips
+	}
+}
+
+// WithPriceCheck aborts swaps if the quoted output deviates from the output implied by
+// pricer reference prices by more than maxDeviationBips. It also enables USD slippage PnL.
+func WithPriceCheck(pricer tokenpricer.Pricer, maxDeviationBips uint64) SwapOption {
+	return func(o *swapOptions) {
+		o.pricer = pricer
+		o.maxDeviationBips = maxDeviationBips
+	}
+}
+
+type swapOptions struct {
+	maxSlippageBips  uint64
+	pricer           tokenpricer.Pricer // Reference pricer, nil disables price checks.
+	maxDeviationBips uint64
+}
+
+func defaultSwapOptions() swapOptions {
+	return swapOptions{
+		maxSlippageBips:  DefaultMaxSlippageBips,
+		maxDeviationBips: DefaultMaxDeviationBips,
+	}
+}
+
+func (o swapOptions) validate() error {
+	if o.maxSlippageBips >= 10_000 {
+		return errors.New("invalid max slippage bips", "bips", o.maxSlippageBips)
+	}
+
+	if o.maxDeviationBips >= 10_000 {
+		return errors.New("invalid max deviation bips", "bips", o.maxDeviationBips)
+	}
+
+	return nil
+}
+
 // Swap represents a single hop in a Uniswap V3 swap path.
 type Swap struct {
 	TokenIn  tokens.Token
@@ -25,13 +86,15 @@ type Swap struct {
 	PoolFee  uint32
 }
 
-// SwapToUSDC swaps a token for USDC using Uniswap V3.
+// SwapToUSDC swaps a token for USDC using Uniswap V3, returning the realized USDC amount.
+// Swaps are pre-quoted and protected from slippage, see WithMaxSlippage and WithPriceCheck.
 func SwapToUSDC(
 	ctx context.Context,
 	backend *ethbackend.Backend,
 	user common.Address,
 	token tokens.Token,
 	amount *big.Int,
+	opts ...SwapOption,
 ) (*big.Int, error) {
 	if token.Is(tokens.USDC) {
 		return amount, nil
@@ -46,7 +109,7 @@ func SwapToUSDC(
 		return nil, errors.Wrap(err, "approve router")
 	}
 
-	amountOut, receipt, err := executeSwaps(ctx, backend, user, token, swaps, amount)
+	amountOut, receipt, err := executeSwaps(ctx, backend, user, token, swaps, amount, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "execute swaps")
 	}
@@ -61,12 +124,15 @@ func SwapToUSDC(
 	return amountOut, nil
 }
 
+// SwapFromUSDC swaps USDC for a token using Uniswap V3, returning the realized token amount.
+// Swaps are pre-quoted and protected from slippage, see WithMaxSlippage and WithPriceCheck.
 func SwapFromUSDC(
 	ctx context.Context,
 	backend *ethbackend.Backend,
 	user common.Address,
 	token tokens.Token,
 	amount *big.Int,
+	opts ...SwapOption,
 ) (*big.Int, error) {
 	if token.Is(tokens.USDC) {
 		return amount, nil
@@ -84,7 +150,7 @@ func SwapFromUSDC(
 		return nil, errors.Wrap(err, "approve router")
 	}
 
-	amountOut, receipt, err := executeSwaps(ctx, backend, user, swaps[0].TokenIn, swaps, amount)
+	amountOut, receipt, err := executeSwaps(ctx, backend, user, swaps[0].TokenIn, swaps, amount, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "execute swaps")
 	}
@@ -168,7 +234,9 @@ func reverse(swaps []Swap) []Swap {
 	return reversed
 }
 
-// executeSwaps executes a series of Uniswap V3 swaps and returns the amount received.
+// executeSwaps executes a series of Uniswap V3 swaps and returns the realized amount received.
+// The swaps are pre-quoted, checked against reference prices (if configured) and
+// submitted with a minimum output of the quote less the max slippage.
 func executeSwaps(
 	ctx context.Context,
 	backend *ethbackend.Backend,
@@ -176,8 +244,19 @@ func executeSwaps(
 	tokenIn tokens.Token,
 	swaps []Swap,
 	amountIn *big.Int,
+	opts ...SwapOption,
 ) (*big.Int, *ethtypes.Receipt, error) {
+	o := defaultSwapOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := o.validate(); err != nil {
+		return nil, nil, err
+	}
+
 	chainID := swaps[0].TokenIn.ChainID
+	tokenOut := swaps[len(swaps)-1].TokenOut
 
 	router, err := newRouter(chainID, backend)
 	if err != nil {
@@ -194,11 +273,17 @@ func executeSwaps(
 		return nil, nil, errors.Wrap(err, "encode path")
 	}
 
-	amountOut, err := quoter.CallQuoteExactInput(ctx, path, amountIn)
+	quoted, err := quoter.CallQuoteExactInput(ctx, path, amountIn)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "quote exact input")
 	}
 
+	if o.pricer != nil {
+		if err := checkQuote(ctx, o, tokenIn, amountIn, tokenOut, quoted); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	txOpts, err := backend.BindOpts(ctx, user)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "bind opts")
@@ -212,7 +297,7 @@ func executeSwaps(
 		Path:             path,
 		Recipient:        user,
 		AmountIn:         amountIn,
-		AmountOutMinimum: amountOut,
+		AmountOutMinimum: minAmountOut(quoted, o.maxSlippageBips),
 	}
 
 	tx, err := router.ExactInput(txOpts, params)
@@ -223,11 +308,154 @@ func executeSwaps(
 	receipt, err := bind.WaitMined(ctx, backend, tx)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "wait mined")
+	} else if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return nil, nil, errors.New("swap reverted", "tx", receipt.TxHash)
 	}
 
+	amountOut, err := transferredTo(receipt, tokenOut, user)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "realized amount out")
+	}
+
+	slippagePnL(ctx, o, tokenIn, tokenOut, quoted, amountOut, receipt)
+
 	return amountOut, receipt, nil
 }
 
+// checkQuote returns an error if the quoted output deviates from the output implied by reference prices by more than the max deviation.
+func checkQuote(
+	ctx context.Context,
+	o swapOptions,
+	tokenIn tokens.Token,
+	amountIn *big.Int,
+	tokenOut tokens.Token,
+	quoted *big.Int,
+) error {
+	prices, err := o.pricer.USDPrices(ctx, priceAsset(tokenIn.Asset), priceAsset(tokenOut.Asset))
+	if err != nil {
+		return errors.Wrap(err, "reference prices")
+	}
+
+	priceIn, priceOut := prices[priceAsset(tokenIn.Asset)], prices[priceAsset(tokenOut.Asset)]
+	if priceIn <= 0 || priceOut <= 0 {
+		return errors.New("invalid reference prices", "in", priceIn, "out", priceOut)
+	}
+
+	deviation := quoteDeviation(bi.ToF64(amountIn, tokenIn.Decimals)*priceIn/priceOut, bi.ToF64(quoted, tokenOut.Decimals))
+	if deviation*10_000 > float64(o.maxDeviationBips) {
+		return errors.New("quote deviates from reference price",
+			"token_in", tokenIn.Symbol,
+			"token_out", tokenOut.Symbol,
+			"quoted", tokenOut.FormatAmt(quoted),
+			"deviation_bips", int64(deviation*10_000),
+			"max_bips", o.maxDeviationBips,
+		)
+	}
+
+	return nil
+}
+
+// quoteDeviation returns the relative absolute deviation of the quoted output vs the reference output.
+func quoteDeviation(reference, quoted float64) float64 {
+	return math.Abs(quoted-reference) / reference
+}
+
+// minAmountOut returns the quoted amount less the max slippage.
+func minAmountOut(quoted *big.Int, slippageBips uint64) *big.Int {
+	return bi.DivRaw(bi.MulRaw(quoted, 10_000-slippageBips), 10_000)
+}
+
+// priceAsset returns the asset used to reference price `asset`; WETH is priced as ETH.
+func priceAsset(asset tokens.Asset) tokens.Asset {
+	if asset == tokens.WETH {
+		return tokens.ETH
+	}
+
+	return asset
+}
+
+// transferredTo returns the total amount of ERC20 `token` transferred to `recipient` in the receipt.
+func transferredTo(receipt *ethtypes.Receipt, token tokens.Token, recipient common.Address) (*big.Int, error) {
+	filterer, err := bindings.NewIERC20Filterer(token.Address, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "new filterer")
+	}
+
+	total := bi.Zero()
+	for _, l := range receipt.Logs {
+		if l.Address != token.Address || len(l.Topics) == 0 || l.Topics[0] != transferTopic {
+			continue
+		}
+
+		transfer, err := filterer.ParseTransfer(*l)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse transfer")
+		}
+
+		if transfer.To == recipient {
+			total = bi.Add(total, transfer.Value)
+		}
+	}
+
+	if bi.IsZero(total) {
+		return nil, errors.New("no transfer to recipient", "token", token.Symbol)
+	}
+
+	return total, nil
+}
+
+// slippagePnL logs the realized slippage (quoted less realized output) as PnL.
+// Positive slippage is logged as expense, negative as income. This is best effort.
+func slippagePnL(
+	ctx context.Context,
+	o swapOptions,
+	tokenIn tokens.Token,
+	tokenOut tokens.Token,
+	quoted *big.Int,
+	realized *big.Int,
+	receipt *ethtypes.Receipt,
+) {
+	slippage := bi.Sub(quoted, realized)
+	if bi.IsZero(slippage) {
+		return
+	}
+
+	typ := pnl.Expense
+	if bi.IsNegative(slippage) {
+		typ = pnl.Income
+		slippage = new(big.Int).Neg(slippage)
+	}
+
+	p := pnl.LogP{
+		Type:        typ,
+		AmountGwei:  bi.ToGweiF64(bi.ToWei(slippage, tokenOut.Decimals)),
+		Currency:    pnl.Currency(tokenOut.Symbol),
+		Category:    "swap_slippage",
+		Subcategory: tokenIn.Symbol + "_" + tokenOut.Symbol,
+		Chain:       evmchain.Name(tokenOut.ChainID),
+		ID:          receipt.TxHash.Hex(),
+		Metadata: map[string]any{
+			"quoted":   tokenOut.FormatAmt(quoted),
+			"realized": tokenOut.FormatAmt(realized),
+		},
+	}
+	pnl.Log(ctx, p)
+
+	if o.pricer == nil {
+		return
+	}
+
+	price, err := o.pricer.USDPrice(ctx, priceAsset(tokenOut.Asset))
+	if err != nil {
+		log.Warn(ctx, "Failed to get token USD price (will retry)", err, "token", tokenOut.Symbol)
+		return
+	}
+
+	p.Currency = pnl.USD
+	p.AmountGwei *= price
+	pnl.Log(ctx, p)
+}
+
 func unwrapWETH(
 	ctx context.Context,
 	chainID uint64,
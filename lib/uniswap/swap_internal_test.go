@@ -0,0 +1,74 @@
+package uniswap
+
+import (
+	"testing"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tokens"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinAmountOut(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, bi.Dec6(995), minAmountOut(bi.Dec6(1000), 50))
+	require.Equal(t, bi.Dec6(1000), minAmountOut(bi.Dec6(1000), 0))
+	require.Equal(t, bi.Ether(0.99), minAmountOut(bi.Ether(1), 100))
+}
+
+func TestQuoteDeviation(t *testing.T) {
+	t.Parallel()
+
+	require.InDelta(t, 0, quoteDeviation(1000, 1000), 1e-9)
+	require.InDelta(t, 0.01, quoteDeviation(1000, 990), 1e-9)
+	require.InDelta(t, 0.01, quoteDeviation(1000, 1010), 1e-9)
+}
+
+func TestSwapOptions(t *testing.T) {
+	t.Parallel()
+
+	o := defaultSwapOptions()
+	require.NoError(t, o.validate())
+
+	WithMaxSlippage(10_000)(&o)
+	require.Error(t, o.validate())
+}
+
+func TestTransferredTo(t *testing.T) {
+	t.Parallel()
+
+	usdc, ok := tokens.ByAsset(evmchain.IDEthereum, tokens.USDC)
+	require.True(t, ok)
+	usdt, ok := tokens.ByAsset(evmchain.IDEthereum, tokens.USDT)
+	require.True(t, ok)
+
+	user := common.HexToAddress("0x1111")
+	pool := common.HexToAddress("0x2222")
+
+	transfer := func(token common.Address, from, to common.Address, amount int64) *ethtypes.Log {
+		return &ethtypes.Log{
+			Address: token,
+			Topics:  []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:    common.BigToHash(bi.Dec6(amount)).Bytes(),
+		}
+	}
+
+	receipt := &ethtypes.Receipt{Logs: []*ethtypes.Log{
+		transfer(usdt.Address, user, pool, 100),            // Input token
+		transfer(usdc.Address, pool, user, 99),             // Output to user
+		transfer(usdc.Address, pool, pool, 1),              // Output to other
+		{Address: usdc.Address, Topics: []common.Hash{{}}}, // Other event
+	}}
+
+	amount, err := transferredTo(receipt, usdc, user)
+	require.NoError(t, err)
+	require.Equal(t, bi.Dec6(99), amount)
+
+	_, err = transferredTo(receipt, usdc, common.HexToAddress("0x3333"))
+	require.Error(t, err)
+}
@@ -23,3 +23,9 @@ func mustToken(chainID uint64, asset tokens.Asset) tokens.Token {
 
 	return tkn
 }
+
+// TokenByChain returns the USDT0 token (or canonical USDT, for mainnet) of the chain, if supported.
+func TokenByChain(chainID uint64) (tokens.Token, bool) {
+	tkn, ok := tokenByChain[chainID]
+	return tkn, ok
+}
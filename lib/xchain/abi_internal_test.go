@@ -81,6 +81,9 @@ func TestSubmissionToFromBinding(t *testing.T) {
 	// Zero BlockHeight as we only submit AttestOffset
 	sub.BlockHeader.BlockHeight = 0
 
+	// Nil MsgTree as it is for internal use only
+	sub.MsgTree = nil
+
 	require.Equal(t, sub, reversedSub)
 }
 
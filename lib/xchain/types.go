@@ -296,6 +296,7 @@ type Submission struct {
 	ProofFlags      []bool       // Flags indicating whether the proof is a left or right proof
 	Signatures      []SigTuple   // Validator signatures and public keys
 	DestChainID     uint64       // Destination chain ID, for internal use only
+	MsgTree         *MsgTree     // Merkle tree of all the Block's messages, for internal use only (aggregation)
 }
 
 // SubmitCursor is a cursor that tracks the progress of a cross-chain stream on destination portal contracts.
@@ -0,0 +1,120 @@
+package relayer
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+// aggregatorMaxHeld is the max number of submissions held while the mempool is full.
+const aggregatorMaxHeld = 8
+
+// aggregator holds submissions (in order) while the mempool is full, merging subsequent
+// submissions into the held submission of the same attestation. Holding submissions of
+// consecutive attestations allows merging submissions of other streams (e.g. other shards)
+// of earlier attestations that arrive later. This reduces the number of xsubmit transactions
+// (and base submission gas) under load.
+//
+// Note that submissions of different attestations cannot be merged, since
+// the portal verifies all messages of a submission against a single attestation root.
+//
+// It is not thread safe.
+type aggregator struct {
+	chainName string
+	held      []xchain.Submission
+}
+
+func newAggregator(chainName string) *aggregator {
+	return &aggregator{chainName: chainName}
+}
+
+// Len returns the number of held submissions.
+func (a *aggregator) Len() int {
+	return len(a.held)
+}
+
+// Add merges the submission into the latest held submission of the same attestation,
+// or holds it if it cannot be merged.
+//
+// Merging into the latest held submission of the attestation preserves stream order, since
+// a stream's submissions are created in order and subsequent attestations are held after it.
+func (a *aggregator) Add(sub xchain.Submission) error {
+	for i := len(a.held) - 1; i >= 0; i-- {
+		if !aggregatable(a.held[i], sub) {
+			continue
+		}
+
+		merged, ok, err := mergeSubmissions(a.held[i], sub)
+		if err != nil {
+			return err
+		} else if ok {
+			a.held[i] = merged
+			aggregatedTotal.WithLabelValues(a.chainName).Inc()
+
+			return nil
+		}
+
+		break
+	}
+
+	a.held = append(a.held, sub)
+
+	return nil
+}
+
+// Next returns the next held (possibly merged) submission and stops holding it.
+func (a *aggregator) Next() (xchain.Submission, bool) {
+	if len(a.held) == 0 {
+		return xchain.Submission{}, false
+	}
+
+	resp := a.held[0]
+	a.held = a.held[1:]
+
+	return resp, true
+}
+
+// mergeSubmissions returns a single submission of the messages of both submissions, or false if
+// they are not of the same attestation, or if all the messages do not fit in a single submission
+// (as per groupMsgsByCost).
+func mergeSubmissions(a, b xchain.Submission) (xchain.Submission, bool, error) {
+	if !aggregatable(a, b) {
+		return xchain.Submission{}, false, nil
+	}
+
+	// Order messages by log index (as the msg tree).
+	msgs := slices.Concat(a.Msgs, b.Msgs)
+	slices.SortFunc(msgs, func(x, y xchain.Msg) int {
+		return cmp.Compare(x.LogIndex, y.LogIndex)
+	})
+
+	if len(groupMsgsByCost(msgs)) > 1 {
+		return xchain.Submission{}, false, nil
+	}
+
+	multi, err := a.MsgTree.Proof(msgs)
+	if err != nil {
+		return xchain.Submission{}, false, errors.Wrap(err, "aggregate proof")
+	}
+
+	resp := a
+	resp.Msgs = msgs
+	resp.Proof = multi.Proof
+	resp.ProofFlags = multi.ProofFlags
+
+	return resp, true, nil
+}
+
+// aggregatable returns true if the submissions are of the same attestation
+// and can therefore be submitted in a single xsubmit transaction.
+func aggregatable(a, b xchain.Submission) bool {
+	return a.MsgTree != nil && b.MsgTree != nil &&
+		a.DestChainID == b.DestChainID &&
+		a.AttHeader == b.AttHeader &&
+		a.AttestationRoot == b.AttestationRoot &&
+		a.ValidatorSetID == b.ValidatorSetID &&
+		a.BlockHeader == b.BlockHeader &&
+		len(a.Msgs) > 0 && len(b.Msgs) > 0
+}
@@ -0,0 +1,174 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSubmissions(t *testing.T) {
+	t.Parallel()
+
+	const srcChain, destChain = 1, 2
+	newMsg := func(shard xchain.ShardID, offset, logIndex uint64) xchain.Msg {
+		return xchain.Msg{
+			MsgID: xchain.MsgID{
+				StreamID:     xchain.StreamID{SourceChainID: srcChain, DestChainID: destChain, ShardID: shard},
+				StreamOffset: offset,
+			},
+			DestGasLimit: 100_000,
+			LogIndex:     logIndex,
+		}
+	}
+
+	// Interleaved messages of two streams in a single block.
+	msgs := []xchain.Msg{
+		newMsg(xchain.ShardFinalized0, 1, 0),
+		newMsg(xchain.ShardLatest0, 1, 1),
+		newMsg(xchain.ShardFinalized0, 2, 2),
+		newMsg(xchain.ShardLatest0, 2, 3),
+	}
+	tree, err := xchain.NewMsgTree(msgs)
+	require.NoError(t, err)
+
+	att := xchain.AttestHeader{ChainVersion: xchain.ChainVersion{ID: srcChain, ConfLevel: xchain.ConfFinalized}, AttestOffset: 1}
+	newSub := func(msgs ...xchain.Msg) xchain.Submission {
+		multi, err := tree.Proof(msgs)
+		require.NoError(t, err)
+
+		return xchain.Submission{
+			AttHeader:   att,
+			Msgs:        msgs,
+			Proof:       multi.Proof,
+			ProofFlags:  multi.ProofFlags,
+			DestChainID: destChain,
+			MsgTree:     &tree,
+		}
+	}
+
+	finalized := newSub(msgs[0], msgs[2])
+	latest := newSub(msgs[1], msgs[3])
+
+	merged, ok, err := mergeSubmissions(finalized, latest)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, newSub(msgs...), merged)
+
+	// Different attestations cannot be merged
+	other := latest
+	other.AttHeader.AttestOffset++
+	_, ok, err = mergeSubmissions(finalized, other)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Submissions without msg trees cannot be merged
+	other = latest
+	other.MsgTree = nil
+	_, ok, err = mergeSubmissions(finalized, other)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Merged submissions must fit the submission gas budget
+	expensive := latest
+	expensive.Msgs = []xchain.Msg{latest.Msgs[0], latest.Msgs[1]}
+	expensive.Msgs[0].DestGasLimit = subGasMax
+	_, ok, err = mergeSubmissions(finalized, expensive)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Aggregator merges into held submissions of the same attestation
+	agg := newAggregator("test")
+	require.NoError(t, agg.Add(finalized))
+	require.NoError(t, agg.Add(other))
+	require.NoError(t, agg.Add(latest))
+	require.Equal(t, 2, agg.Len())
+
+	next, ok := agg.Next()
+	require.True(t, ok)
+	require.Equal(t, merged, next)
+	next, ok = agg.Next()
+	require.True(t, ok)
+	require.Equal(t, other, next)
+	_, ok = agg.Next()
+	require.False(t, ok)
+}
+
+// TestAggregateUnderLoad tests that the buffer sends fewer xsubmit txs while the mempool is full,
+// by merging submissions of interleaved streams across consecutive attestations.
+func TestAggregateUnderLoad(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	const srcChain, destChain = 1, 2
+	newSubs := func(attOffset uint64) (xchain.Submission, xchain.Submission, xchain.Submission) {
+		t.Helper()
+
+		var msgs []xchain.Msg
+		for i, shard := range []xchain.ShardID{xchain.ShardFinalized0, xchain.ShardLatest0} {
+			msgs = append(msgs, xchain.Msg{
+				MsgID: xchain.MsgID{
+					StreamID:     xchain.StreamID{SourceChainID: srcChain, DestChainID: destChain, ShardID: shard},
+					StreamOffset: attOffset,
+				},
+				DestGasLimit: 100_000,
+				LogIndex:     uint64(i),
+			})
+		}
+		tree, err := xchain.NewMsgTree(msgs)
+		require.NoError(t, err)
+
+		newSub := func(msgs ...xchain.Msg) xchain.Submission {
+			multi, err := tree.Proof(msgs)
+			require.NoError(t, err)
+
+			return xchain.Submission{
+				AttHeader: xchain.AttestHeader{
+					ChainVersion: xchain.ChainVersion{ID: srcChain, ConfLevel: xchain.ConfFinalized},
+					AttestOffset: attOffset,
+				},
+				Msgs:        msgs,
+				Proof:       multi.Proof,
+				ProofFlags:  multi.ProofFlags,
+				DestChainID: destChain,
+				MsgTree:     &tree,
+			}
+		}
+
+		return newSub(msgs[0]), newSub(msgs[1]), newSub(msgs...)
+	}
+
+	filler, _, _ := newSubs(1)
+	final2, latest2, merged2 := newSubs(2)
+	final3, latest3, merged3 := newSubs(3)
+
+	sender := newMockSender()
+	buffer := newActiveBuffer("test", 1, sender.Send)
+	go func() {
+		err := buffer.Run(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	}()
+
+	// Fill the mempool, then add interleaved streams of consecutive attestations.
+	for _, sub := range []xchain.Submission{filler, final2, final3, latest2, latest3} {
+		require.NoError(t, buffer.AddInput(ctx, sub))
+	}
+
+	// Five submissions are sent in three xsubmit txs.
+	require.Equal(t, filler, sender.Next())
+	require.Equal(t, merged2, sender.Next())
+	require.Equal(t, merged3, sender.Next())
+	require.Never(t, func() bool {
+		select {
+		case <-sender.sendChan:
+			return true
+		default:
+			return false
+		}
+	}, 50*time.Millisecond, time.Millisecond)
+}
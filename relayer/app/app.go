@@ -34,7 +34,8 @@ func Run(ctx context.Context, cfg Config) error {
 	ctx = chaos.WithErrProbability(ctx, cfg.Network)
 
 	// Start metrics first, so app is "up"
-	monitorChan := serveMonitoring(cfg.MonitoringAddr)
+	status := new(statusReporter)
+	monitorChan := serveMonitoring(cfg.MonitoringAddr, status)
 
 	portalReg, err := makePortalRegistry(ctx, cfg.Network, cfg.RPCEndpoints)
 	if err != nil {
@@ -66,6 +67,12 @@ func Run(ctx context.Context, cfg Config) error {
 	pricer := newTokenPricer(ctx, cfg.CoinGeckoAPIKey)
 	pnl := newPnlLogger(network.ID, pricer)
 
+	profitPolicies, err := LoadProfitPolicies(cfg.ProfitFile)
+	if err != nil {
+		return err
+	}
+	profitPolicyByChain := profitPolicies.ByChain()
+
 	db, err := initializeDB(ctx, cfg)
 	if err != nil {
 		return err
@@ -76,7 +83,23 @@ func Run(ctx context.Context, cfg Config) error {
 	}
 	cursors.StartLoops(ctx)
 
+	var leaseBackend LeaseBackend // Nil if not coordinating with other instances
+	if cfg.LeaseDir != "" {
+		leaseBackend, err = NewFileLeaseBackend(cfg.LeaseDir)
+		if err != nil {
+			return err
+		}
+	}
+	leaseHolder := newLeaseHolder()
+
 	for _, destChain := range network.EVMChains() {
+		// Setup pending tx recovery, replacing stuck txs when the worker (re)starts
+		recoverTxMgr, err := newTxMgr(destChain, rpcClientPerChain[destChain.ID], *privateKey)
+		if err != nil {
+			return err
+		}
+		recovery := newNonceRecovery(destChain.Name, rpcClientPerChain[destChain.ID], recoverTxMgr)
+
 		// Setup send provider
 		sendProvider := func() (SendAsync, error) {
 			sender, err := NewSender(
@@ -86,12 +109,27 @@ func Run(ctx context.Context, cfg Config) error {
 				*privateKey,
 				network.ChainVersionNames(),
 				pnl.log,
+				recovery.Reserved,
 			)
 			if err != nil {
 				return nil, err
 			}
 
-			return sender.SendAsync, nil
+			policy, ok := profitPolicyByChain[destChain.ID]
+			if !ok {
+				return sender.SendAsync, nil
+			}
+
+			gate := newProfitGate(
+				network.ID,
+				destChain,
+				policy,
+				network.ChainVersionNames(),
+				rpcClientPerChain[destChain.ID].SuggestGasPrice,
+				pricer,
+			)
+
+			return gate.Wrap(sender.SendAsync), nil
 		}
 
 		// Setup validator set awaiter
@@ -101,6 +139,11 @@ func Run(ctx context.Context, cfg Config) error {
 		}
 		awaitValSet := newValSetAwaiter(portal, destChain.BlockPeriod)
 
+		var lease *workerLease
+		if leaseBackend != nil {
+			lease = newWorkerLease(leaseBackend, destChain.Name, leaseHolder)
+		}
+
 		// Start worker
 		worker := NewWorker(
 			destChain,
@@ -110,9 +153,13 @@ func Run(ctx context.Context, cfg Config) error {
 			CreateSubmissions,
 			sendProvider,
 			awaitValSet,
+			recovery.Recover,
 			cursors,
+			lease,
 		)
 
+		status.Add(worker)
+
 		go worker.Run(ctx)
 	}
 
@@ -2,12 +2,11 @@ package relayer
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/omni-network/omni/lib/chaos"
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/xchain"
-
-	"golang.org/x/sync/semaphore"
 )
 
 // activeBuffer links the output of each worker's cprovider/creators (one per chain version)
@@ -16,7 +15,8 @@ import (
 // It limits the number of concurrent transactions it forwards to the async sender
 // to limit the mempool size.
 //
-// While the mempool limit is reached, calls to AddInput block.
+// While the mempool limit is reached, submissions are held and aggregated (see aggregator),
+// and calls to AddInput block once aggregatorMaxHeld submissions are held.
 //
 // If stops processing on any error.
 type activeBuffer struct {
@@ -25,6 +25,8 @@ type activeBuffer struct {
 	mempoolLimit int64
 	errChan      chan error
 	sendAsync    SendAsync
+	aggregator   *aggregator
+	inflight     atomic.Int64
 }
 
 func newActiveBuffer(chainName string, mempoolLimit int64, sendAsync SendAsync) *activeBuffer {
@@ -34,10 +36,12 @@ func newActiveBuffer(chainName string, mempoolLimit int64, sendAsync SendAsync)
 		mempoolLimit: mempoolLimit,
 		errChan:      make(chan error, 1),
 		sendAsync:    sendAsync,
+		aggregator:   newAggregator(chainName),
 	}
 }
 
-// AddInput adds a new submission to the buffer. It blocks while mempoolLimit is reached.
+// AddInput adds a new submission to the buffer. It blocks while mempoolLimit is reached
+// and aggregatorMaxHeld submissions are held.
 func (b *activeBuffer) AddInput(ctx context.Context, submission xchain.Submission) error {
 	select {
 	case <-ctx.Done():
@@ -52,18 +56,34 @@ func (b *activeBuffer) AddInput(ctx context.Context, submission xchain.Submissio
 
 // Run processes the buffer, sending submissions to the async sender.
 func (b *activeBuffer) Run(ctx context.Context) error {
-	sema := semaphore.NewWeighted(b.mempoolLimit)
+	slots := make(chan struct{}, b.mempoolLimit) // Mempool semaphore
 	for {
+		// Only read inputs while holding less than max submissions.
+		input := b.buffer
+		if b.aggregator.Len() >= aggregatorMaxHeld {
+			input = nil
+		}
+
+		// Only acquire a mempool slot if a submission is held.
+		var slot chan struct{}
+		if b.aggregator.Len() > 0 {
+			slot = slots
+		}
+
 		select {
 		case <-ctx.Done():
 			return errors.Wrap(ctx.Err(), "context canceled")
 		case err := <-b.errChan:
 			return err
-		case submission := <-b.buffer:
-			if err := sema.Acquire(ctx, 1); err != nil {
-				return errors.Wrap(err, "acquire semaphore")
+		case submission := <-input:
+			// Hold the submission until a mempool slot is available, aggregating subsequent inputs into it.
+			if err := b.aggregator.Add(submission); err != nil {
+				return errors.Wrap(err, "aggregate submission")
 			}
+		case slot <- struct{}{}:
+			submission, _ := b.aggregator.Next()
 			mempoolLen.WithLabelValues(b.chainName).Inc()
+			b.inflight.Add(1)
 
 			// Trigger async send synchronously (for ordered nonces), but wait for response async.
 			response := b.sendAsync(ctx, submission)
@@ -74,7 +94,8 @@ func (b *activeBuffer) Run(ctx context.Context) error {
 				}
 
 				mempoolLen.WithLabelValues(b.chainName).Dec()
-				sema.Release(1)
+				b.inflight.Add(-1)
+				<-slots
 			}()
 
 			// Chaos test this worker with random errors.
@@ -85,6 +106,11 @@ func (b *activeBuffer) Run(ctx context.Context) error {
 	}
 }
 
+// Inflight returns the number of submissions sent to the mempool, but not yet included.
+func (b *activeBuffer) Inflight() int64 {
+	return b.inflight.Load()
+}
+
 func (b *activeBuffer) submitErr(err error) {
 	select {
 	case b.errChan <- err:
@@ -72,7 +72,7 @@ func Test_activeBuffer_Run(t *testing.T) {
 	//
 	const (
 		memLimit = int64(5) // mempoolLimit
-		size     = 10
+		size     = 20
 	)
 
 	sender := newMockSender()
@@ -95,15 +95,17 @@ func Test_activeBuffer_Run(t *testing.T) {
 		}
 	}()
 
+	// Submissions are held while the mempool is full
+	const blocked = memLimit + aggregatorMaxHeld
 	require.Eventuallyf(t,
 		func() bool {
-			return counter.Load() == memLimit+1
+			return counter.Load() == blocked
 		},
-		time.Second, time.Millisecond, "expected %d", memLimit+1,
+		time.Second, time.Millisecond, "expected %d", blocked,
 	)
 
 	// assert again that buf is blocking
-	require.Equal(t, memLimit+1, counter.Load())
+	require.Equal(t, int64(blocked), counter.Load())
 
 	// Retrieve output submissions
 	var output []xchain.Submission
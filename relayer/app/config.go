@@ -24,6 +24,8 @@ type Config struct {
 	MonitoringAddr  string
 	DBDir           string
 	CoinGeckoAPIKey string
+	ProfitFile      string
+	LeaseDir        string
 }
 
 func DefaultConfig() Config {
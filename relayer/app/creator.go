@@ -53,6 +53,7 @@ func CreateSubmissions(up StreamUpdate) ([]xchain.Submission, error) {
 			ProofFlags:      multi.ProofFlags,
 			Signatures:      sigs,
 			DestChainID:     up.DestChainID,
+			MsgTree:         &up.MsgTree,
 		})
 	}
 
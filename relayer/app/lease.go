@@ -0,0 +1,277 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+
+	"github.com/gofrs/flock"
+)
+
+const (
+	// leaseTTL is the duration a lease is valid for unless renewed.
+	leaseTTL = 30 * time.Second
+	// leaseRenewInterval is the interval at which primaries renew, and standbys try to acquire leases.
+	leaseRenewInterval = 10 * time.Second
+	// leaseStallTimeout is the duration without worker progress after which a primary stops renewing its lease.
+	leaseStallTimeout = 5 * time.Minute
+)
+
+var errLeaseStalled = errors.New("worker stalled, lease released")
+
+// LeaseBackend is a shared lock coordinating multiple relayer instances.
+type LeaseBackend interface {
+	// TryAcquire acquires or renews the lease for the holder. It returns false if the lease
+	// is held by another holder and not yet expired.
+	TryAcquire(ctx context.Context, key string, holder string, ttl time.Duration) (bool, error)
+	// Release releases the lease if held by the holder.
+	Release(ctx context.Context, key string, holder string) error
+}
+
+// NewFileLeaseBackend returns a lease backend storing leases as files in the directory.
+// It coordinates relayer instances sharing the directory, i.e., on the same host or a shared volume.
+func NewFileLeaseBackend(dir string) (LeaseBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create lease dir")
+	}
+
+	return fileLeaseBackend{dir: dir, now: time.Now}, nil
+}
+
+type fileLease struct {
+	Holder  string    `json:"holder"`
+	Expires time.Time `json:"expires"`
+}
+
+type fileLeaseBackend struct {
+	dir string
+	now func() time.Time
+}
+
+func (b fileLeaseBackend) TryAcquire(ctx context.Context, key string, holder string, ttl time.Duration) (bool, error) {
+	var ok bool
+	err := b.locked(ctx, key, func(path string) error {
+		lease, exists, err := readFileLease(path)
+		if err != nil {
+			return err
+		}
+
+		now := b.now()
+		if exists && lease.Holder != holder && now.Before(lease.Expires) {
+			return nil
+		}
+
+		bz, err := json.Marshal(fileLease{Holder: holder, Expires: now.Add(ttl)})
+		if err != nil {
+			return errors.Wrap(err, "marshal lease")
+		}
+
+		// Write atomically, so readers never see partial leases.
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, bz, 0o644); err != nil {
+			return errors.Wrap(err, "write lease")
+		} else if err := os.Rename(tmp, path); err != nil {
+			return errors.Wrap(err, "rename lease")
+		}
+
+		ok = true
+
+		return nil
+	})
+
+	return ok, err
+}
+
+func (b fileLeaseBackend) Release(ctx context.Context, key string, holder string) error {
+	return b.locked(ctx, key, func(path string) error {
+		lease, exists, err := readFileLease(path)
+		if err != nil {
+			return err
+		} else if !exists || lease.Holder != holder {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			return errors.Wrap(err, "remove lease")
+		}
+
+		return nil
+	})
+}
+
+// locked calls fn with the lease file path while holding the key's file lock.
+func (b fileLeaseBackend) locked(ctx context.Context, key string, fn func(path string) error) error {
+	path := filepath.Join(b.dir, key+".lease")
+
+	lock := flock.New(path + ".lock")
+	if ok, err := lock.TryLockContext(ctx, 10*time.Millisecond); err != nil {
+		return errors.Wrap(err, "lock lease")
+	} else if !ok {
+		return errors.New("lock lease failed")
+	}
+	defer func() {
+		_ = lock.Unlock()
+	}()
+
+	return fn(path)
+}
+
+func readFileLease(path string) (fileLease, bool, error) {
+	bz, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fileLease{}, false, nil
+	} else if err != nil {
+		return fileLease{}, false, errors.Wrap(err, "read lease")
+	}
+
+	var resp fileLease
+	if err := json.Unmarshal(bz, &resp); err != nil {
+		return fileLease{}, false, errors.Wrap(err, "unmarshal lease")
+	}
+
+	return resp, true, nil
+}
+
+// newLeaseHolder returns a unique lease holder ID of this relayer instance.
+func newLeaseHolder() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), randomHex7())
+}
+
+// workerLease coordinates a destination chain worker across relayer instances.
+// Only the primary instance holding the lease runs the worker; others are hot standbys.
+// The primary renews its lease only while its worker makes progress, so standbys take over
+// once it stalls or stops.
+type workerLease struct {
+	backend   LeaseBackend
+	chainName string // Lease key
+	holder    string
+	ttl       time.Duration
+	renew     time.Duration
+	stall     time.Duration
+	now       func() time.Time
+
+	progress atomic.Int64 // Unix nano timestamp of latest worker progress
+	primary  atomic.Bool
+
+	mu       sync.Mutex
+	cooldown time.Time // Don't acquire before this, giving standbys priority after stalling
+}
+
+func newWorkerLease(backend LeaseBackend, chainName string, holder string) *workerLease {
+	return &workerLease{
+		backend:   backend,
+		chainName: chainName,
+		holder:    holder,
+		ttl:       leaseTTL,
+		renew:     leaseRenewInterval,
+		stall:     leaseStallTimeout,
+		now:       time.Now,
+	}
+}
+
+// Progress marks the worker as making progress.
+func (l *workerLease) Progress() {
+	l.progress.Store(l.now().UnixNano())
+}
+
+// Primary returns true if this instance holds the lease.
+func (l *workerLease) Primary() bool {
+	return l.primary.Load()
+}
+
+// Await blocks as a standby until the lease is acquired, i.e., until this instance is primary.
+func (l *workerLease) Await(ctx context.Context) error {
+	var logged bool
+	for {
+		l.mu.Lock()
+		cooldown := l.cooldown
+		l.mu.Unlock()
+
+		if l.now().After(cooldown) {
+			ok, err := l.backend.TryAcquire(ctx, l.chainName, l.holder, l.ttl)
+			if err != nil {
+				return errors.Wrap(err, "acquire lease")
+			} else if ok {
+				log.Info(ctx, "Acquired worker lease, running as primary", "holder", l.holder)
+				leasePrimary.WithLabelValues(l.chainName).Set(1)
+				l.primary.Store(true)
+				l.Progress()
+
+				return nil
+			}
+		}
+
+		if !logged {
+			log.Info(ctx, "Worker lease held by another instance, running as standby", "holder", l.holder)
+			leasePrimary.WithLabelValues(l.chainName).Set(0)
+			logged = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "context canceled")
+		case <-time.After(l.renew):
+		}
+	}
+}
+
+// Hold renews the lease while the worker makes progress. It returns an error when the lease is lost,
+// or errLeaseStalled after releasing the lease if the worker stalls. It returns nil when the context is done.
+func (l *workerLease) Hold(ctx context.Context) error {
+	defer func() {
+		leasePrimary.WithLabelValues(l.chainName).Set(0)
+		l.primary.Store(false)
+	}()
+
+	ticker := time.NewTicker(l.renew)
+	defer ticker.Stop()
+
+	renewed := l.now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if l.now().Sub(time.Unix(0, l.progress.Load())) > l.stall {
+			leaseLost.WithLabelValues(l.chainName, "stalled").Inc()
+
+			l.mu.Lock()
+			l.cooldown = l.now().Add(l.ttl)
+			l.mu.Unlock()
+
+			if err := l.backend.Release(context.WithoutCancel(ctx), l.chainName, l.holder); err != nil {
+				log.Warn(ctx, "Failed releasing stalled worker lease", err)
+			}
+
+			return errLeaseStalled
+		}
+
+		ok, err := l.backend.TryAcquire(ctx, l.chainName, l.holder, l.ttl)
+		if ctx.Err() != nil {
+			return nil
+		} else if err != nil && l.now().Sub(renewed) < l.ttl {
+			// Keep trying until the lease expires, since the backend may be temporarily unavailable.
+			log.Warn(ctx, "Failed renewing worker lease (will retry)", err)
+			continue
+		} else if err != nil {
+			leaseLost.WithLabelValues(l.chainName, "expired").Inc()
+			return errors.Wrap(err, "renew worker lease")
+		} else if !ok {
+			leaseLost.WithLabelValues(l.chainName, "taken").Inc()
+			return errors.New("worker lease taken by another instance")
+		}
+
+		renewed = l.now()
+	}
+}
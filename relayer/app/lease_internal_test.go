@@ -0,0 +1,170 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLeaseBackend(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	backend, err := NewFileLeaseBackend(t.TempDir())
+	require.NoError(t, err)
+
+	now := time.Now()
+	fb := backend.(fileLeaseBackend) //nolint:forcetypeassert // Type known
+	fb.now = func() time.Time { return now }
+	backend = fb
+
+	const key = "mock_l1"
+
+	ok, err := backend.TryAcquire(ctx, key, "a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Held by a until expired
+	ok, err = backend.TryAcquire(ctx, key, "b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Renewed by a
+	now = now.Add(30 * time.Second)
+	ok, err = backend.TryAcquire(ctx, key, "a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	now = now.Add(59 * time.Second)
+	ok, err = backend.TryAcquire(ctx, key, "b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Other keys are independent
+	ok, err = backend.TryAcquire(ctx, "mock_l2", "b", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Expired leases are taken over
+	now = now.Add(2 * time.Second)
+	ok, err = backend.TryAcquire(ctx, key, "b", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Only the holder can release
+	require.NoError(t, backend.Release(ctx, key, "a"))
+	ok, err = backend.TryAcquire(ctx, key, "a", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, backend.Release(ctx, key, "b"))
+	ok, err = backend.TryAcquire(ctx, key, "a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestWorkerLease(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	backend, err := NewFileLeaseBackend(t.TempDir())
+	require.NoError(t, err)
+
+	newLease := func(holder string) *workerLease {
+		l := newWorkerLease(backend, "mock_l1", holder)
+		l.renew = time.Millisecond
+		l.stall = 50 * time.Millisecond
+
+		return l
+	}
+
+	primary := newLease("primary")
+	standby := newLease("standby")
+
+	require.NoError(t, primary.Await(ctx))
+
+	holdCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	held := make(chan error, 1)
+	go func() { held <- primary.Hold(holdCtx) }()
+
+	// Standby waits while the primary makes progress
+	acquired := make(chan error, 1)
+	go func() { acquired <- standby.Await(ctx) }()
+
+	for range 10 {
+		primary.Progress()
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Empty(t, acquired)
+
+	// Standby takes over once the primary stalls
+	require.ErrorIs(t, <-held, errLeaseStalled)
+	require.NoError(t, <-acquired)
+
+	// Stalled primary doesn't reacquire, since lease is held by the standby
+	ok, err := backend.TryAcquire(ctx, "mock_l1", "primary", leaseTTL)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Primary detects its lease taken
+	require.NoError(t, backend.Release(ctx, "mock_l1", "standby"))
+	ok, err = backend.TryAcquire(ctx, "mock_l1", "primary", leaseTTL)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	standby.Progress()
+	require.ErrorContains(t, standby.Hold(ctx), "worker lease taken by another instance")
+}
+
+func TestProgressOnSend(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	now := time.Unix(1, 0)
+	lease := newWorkerLease(nil, "mock_l1", "primary")
+	lease.now = func() time.Time { return now }
+	w := &Worker{lease: lease}
+
+	var sendErr error
+	sendAsync := w.progressOnSend(func(context.Context, xchain.Submission) <-chan error {
+		resp := make(chan error, 1)
+		resp <- sendErr
+
+		return resp
+	})
+
+	// Failed sends are not progress
+	sendErr = errors.New("failed")
+	require.Error(t, <-sendAsync(ctx, xchain.Submission{}))
+	require.Zero(t, lease.progress.Load())
+
+	// Successful sends are progress
+	sendErr = nil
+	require.NoError(t, <-sendAsync(ctx, xchain.Submission{}))
+	require.Equal(t, now.UnixNano(), lease.progress.Load())
+
+	// Nonces are reserved synchronously, preserving submission order
+	var nonces []uint64
+	release := make(chan error)
+	sendAsync = w.progressOnSend(func(_ context.Context, sub xchain.Submission) <-chan error {
+		nonces = append(nonces, sub.AttHeader.AttestOffset) // Reserve nonce
+		resp := make(chan error, 1)
+		go func() { resp <- <-release }()
+
+		return resp
+	})
+
+	first := sendAsync(ctx, xchain.Submission{AttHeader: xchain.AttestHeader{AttestOffset: 1}})
+	second := sendAsync(ctx, xchain.Submission{AttHeader: xchain.AttestHeader{AttestOffset: 2}})
+	require.Equal(t, []uint64{1, 2}, nonces)
+
+	close(release)
+	require.NoError(t, <-first)
+	require.NoError(t, <-second)
+}
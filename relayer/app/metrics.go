@@ -20,6 +20,34 @@ var (
 		Help:      "The length of the mempool per destination chain. Alert if too high",
 	}, []string{"dst_chain"})
 
+	aggregatedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "relayer",
+		Subsystem: "worker",
+		Name:      "aggregated_submission_total",
+		Help:      "The total number of submissions aggregated into other submissions (saving xsubmit txs) per destination chain",
+	}, []string{"dst_chain"})
+
+	profitDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "relayer",
+		Subsystem: "worker",
+		Name:      "profit_decision_total",
+		Help:      "The total number of pre-submission profitability decisions by source and destination chain and decision",
+	}, []string{"src_chain", "dst_chain", "decision"})
+
+	leasePrimary = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "relayer",
+		Subsystem: "worker",
+		Name:      "lease_primary",
+		Help:      "Whether this instance holds the worker lease (1) or is a standby (0) per destination chain",
+	}, []string{"dst_chain"})
+
+	leaseLost = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "relayer",
+		Subsystem: "worker",
+		Name:      "lease_lost_total",
+		Help:      "The total number of worker leases lost by destination chain and reason. Alert if too high",
+	}, []string{"dst_chain", "reason"})
+
 	workerResets = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "relayer",
 		Subsystem: "worker",
@@ -27,6 +55,13 @@ var (
 		Help:      "The total number of times the worker has reset by destination chain. Alert if too high",
 	}, []string{"dst_chain"})
 
+	recoveredNonces = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "relayer",
+		Subsystem: "worker",
+		Name:      "recovered_nonce_total",
+		Help:      "The total number of pending transactions replaced with no-op self-transfers on worker (re)start by destination chain",
+	}, []string{"dst_chain"})
+
 	submissionTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "relayer",
 		Subsystem: "worker",
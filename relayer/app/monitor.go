@@ -10,13 +10,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// serveMonitoring starts a goroutine that serves the monitoring API. It
+// serveMonitoring starts a goroutine that serves the monitoring API, including the status API. It
 // returns a channel that will receive an error if the server fails to start.
-func serveMonitoring(address string) <-chan error {
+func serveMonitoring(address string, status http.Handler) <-chan error {
 	errChan := make(chan error)
 	go func() {
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.Handler())
+		mux.Handle(EndpointStatus, status)
 
 		// Copied from net/http/pprof/pprof.go
 		mux.HandleFunc("/debug/pprof/", pprof.Index)
@@ -20,15 +20,15 @@ import (
 )
 
 const (
-	priceCacheEvictInterval = time.Minute
+	priceCacheTTL        = time.Minute
+	priceRefreshInterval = 30 * time.Second
 )
 
-// newTokenPricer creates a new cached pricer with priceCacheEvictInterval.
+// newTokenPricer creates a new cached pricer with priceCacheTTL, refreshing hot prices every priceRefreshInterval.
 func newTokenPricer(ctx context.Context, cgAPIKey string) tokenpricer.Pricer {
-	pricer := tokenpricer.NewCached(coingecko.New(coingecko.WithAPIKey(cgAPIKey)))
-
 	// use cached pricer avoid spamming coingecko public api
-	go pricer.ClearCacheForever(ctx, priceCacheEvictInterval)
+	pricer := tokenpricer.NewCached(coingecko.New(coingecko.WithAPIKey(cgAPIKey)), tokenpricer.WithTTL(priceCacheTTL))
+	go pricer.RefreshForever(ctx, priceRefreshInterval)
 
 	return pricer
 }
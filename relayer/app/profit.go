@@ -0,0 +1,269 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/BurntSushi/toml"
+)
+
+// profitRecheckInterval is the interval at which delayed submissions are re-evaluated.
+const profitRecheckInterval = 15 * time.Second
+
+// Profitability decisions.
+const (
+	profitSubmit   = "profitable" // Fees cover the estimated cost, submit
+	profitDelay    = "delay"      // Fees don't cover the estimated cost, delay until gas drops
+	profitMaxDelay = "max_delay"  // Fees still don't cover the estimated cost after max delay, submit anyway
+	profitNoFees   = "no_fees"    // Consensus chain submissions pay no fees, submit
+	profitError    = "error"      // Estimating economics failed, submit
+)
+
+// ProfitPolicy defines the pre-submission profitability check of a destination chain.
+// Submissions whose xmsg fees don't cover the estimated tx cost are delayed until gas drops,
+// or submitted anyway once delayed for max-delay, which must be less than the worker lease stall timeout.
+type ProfitPolicy struct {
+	ChainID  uint64        `toml:"chain-id"`  // Destination chain ID
+	MinRatio float64       `toml:"min-ratio"` // Min ratio of fees to estimated cost to submit, e.g. 1.0 to break even
+	MaxDelay time.Duration `toml:"max-delay"` // Submit anyway once delayed this long
+}
+
+func (p ProfitPolicy) Validate() error {
+	if p.ChainID == 0 {
+		return errors.New("missing chain id")
+	}
+
+	if p.MinRatio <= 0 {
+		return errors.New("min-ratio must be positive", "chain", p.ChainID)
+	}
+
+	if p.MaxDelay <= 0 {
+		return errors.New("max-delay must be positive", "chain", p.ChainID)
+	}
+
+	// Delayed submissions block worker progress, so a longer delay would release the worker lease.
+	if p.MaxDelay >= leaseStallTimeout {
+		return errors.New("max-delay must be less than the worker lease stall timeout", "chain", p.ChainID, "max", leaseStallTimeout)
+	}
+
+	return nil
+}
+
+// ProfitPolicies defines the profitability policies per destination chain.
+// Submissions to chains without a policy are sent immediately.
+type ProfitPolicies struct {
+	Policies []ProfitPolicy `toml:"policies"`
+}
+
+func (p ProfitPolicies) Validate() error {
+	dups := make(map[uint64]bool)
+	for _, policy := range p.Policies {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+
+		if dups[policy.ChainID] {
+			return errors.New("duplicate chain policy", "chain", policy.ChainID)
+		}
+		dups[policy.ChainID] = true
+	}
+
+	return nil
+}
+
+// ByChain returns the policies by destination chain ID.
+func (p ProfitPolicies) ByChain() map[uint64]ProfitPolicy {
+	resp := make(map[uint64]ProfitPolicy)
+	for _, policy := range p.Policies {
+		resp[policy.ChainID] = policy
+	}
+
+	return resp
+}
+
+// LoadProfitPolicies loads and validates a TOML profitability policy file.
+// No policies are returned if path is empty.
+func LoadProfitPolicies(path string) (ProfitPolicies, error) {
+	if path == "" {
+		return ProfitPolicies{}, nil
+	}
+
+	var resp ProfitPolicies
+	if _, err := toml.DecodeFile(path, &resp); err != nil {
+		return ProfitPolicies{}, errors.Wrap(err, "decode profit policies", "path", path)
+	}
+
+	if err := resp.Validate(); err != nil {
+		return ProfitPolicies{}, errors.Wrap(err, "validate profit policies", "path", path)
+	}
+
+	return resp, nil
+}
+
+// gasPriceFunc returns the current gas price of the destination chain.
+type gasPriceFunc func(ctx context.Context) (*big.Int, error)
+
+// profitGate delays submissions to a destination chain as per its profitability policy.
+type profitGate struct {
+	network      netconf.ID
+	dest         netconf.Chain
+	policy       ProfitPolicy
+	chainNames   map[xchain.ChainVersion]string
+	gasPrice     gasPriceFunc
+	gasEstimator gasEstimator
+	pricer       tokenpricer.Pricer
+	now          func() time.Time
+	recheck      time.Duration
+}
+
+func newProfitGate(
+	network netconf.ID,
+	dest netconf.Chain,
+	policy ProfitPolicy,
+	chainNames map[xchain.ChainVersion]string,
+	gasPrice gasPriceFunc,
+	pricer tokenpricer.Pricer,
+) profitGate {
+	return profitGate{
+		network:      network,
+		dest:         dest,
+		policy:       policy,
+		chainNames:   chainNames,
+		gasPrice:     gasPrice,
+		gasEstimator: newGasEstimator(network),
+		pricer:       pricer,
+		now:          time.Now,
+		recheck:      profitRecheckInterval,
+	}
+}
+
+// Wrap returns a SendAsync that awaits a profitable submission before sending it.
+// Note that awaiting blocks (subsequent submissions) synchronously, preserving submission ordering.
+func (g profitGate) Wrap(sendAsync SendAsync) SendAsync {
+	return func(ctx context.Context, sub xchain.Submission) <-chan error {
+		if err := g.Await(ctx, sub); err != nil {
+			resp := make(chan error, 1)
+			resp <- err
+
+			return resp
+		}
+
+		return sendAsync(ctx, sub)
+	}
+}
+
+// Await blocks until the submission should be sent as per the policy, logging and counting each decision.
+func (g profitGate) Await(ctx context.Context, sub xchain.Submission) error {
+	srcChain := g.chainNames[sub.AttHeader.ChainVersion]
+	ctx = log.WithCtx(ctx, "src_chain", srcChain, "attest_offset", sub.AttHeader.AttestOffset)
+
+	start := g.now()
+	for {
+		delayed := g.now().Sub(start)
+		decision, feesUSD, costUSD, err := g.decide(ctx, sub, delayed)
+		profitDecisions.WithLabelValues(srcChain, g.dest.Name, decision).Inc()
+
+		attrs := []any{
+			"decision", decision,
+			"fees_usd", feesUSD,
+			"cost_usd", costUSD,
+			"delayed", delayed.Truncate(time.Second),
+			"msgs", len(sub.Msgs),
+		}
+
+		switch decision {
+		case profitError:
+			log.Warn(ctx, "Failed estimating submission profitability, submitting", err, attrs...)
+			return nil
+		case profitMaxDelay:
+			log.Warn(ctx, "Submitting unprofitable submission after max delay", nil, attrs...)
+			return nil
+		case profitDelay:
+			log.Info(ctx, "Delaying unprofitable submission", attrs...)
+		default:
+			log.Debug(ctx, "Submission profitability checked", attrs...)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "context canceled")
+		case <-time.After(min(g.recheck, g.policy.MaxDelay-delayed)):
+		}
+	}
+}
+
+// decide returns the profitability decision of the submission, and its estimated fees and cost in USD.
+func (g profitGate) decide(ctx context.Context, sub xchain.Submission, delayed time.Duration) (string, float64, float64, error) {
+	if netconf.IsOmniConsensus(g.network, sub.BlockHeader.ChainID) {
+		return profitNoFees, 0, 0, nil
+	}
+
+	feesUSD, costUSD, err := g.estimate(ctx, sub)
+	if err != nil {
+		return profitError, 0, 0, err
+	}
+
+	return profitDecision(g.policy, feesUSD, costUSD, delayed), feesUSD, costUSD, nil
+}
+
+// estimate returns the xmsg fees paid on the source chain and the estimated submission tx cost in USD.
+func (g profitGate) estimate(ctx context.Context, sub xchain.Submission) (float64, float64, error) {
+	src, ok := evmchain.MetadataByID(sub.BlockHeader.ChainID)
+	if !ok {
+		return 0, 0, errors.New("unknown source chain", "chain", sub.BlockHeader.ChainID)
+	}
+
+	dest, ok := evmchain.MetadataByID(g.dest.ID)
+	if !ok {
+		return 0, 0, errors.New("unknown destination chain", "chain", g.dest.ID)
+	}
+
+	gasPrice, err := g.gasPrice(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "gas price")
+	}
+
+	gas := g.gasEstimator(g.dest.ID, sub.Msgs)
+	if gas == properGasEstimation {
+		gas = naiveSubmissionGas(sub.Msgs) // Good enough upper bound for economics
+	}
+
+	prices, err := g.pricer.USDPrices(ctx, tokens.OMNI, tokens.ETH)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "get prices")
+	}
+
+	fees, err := feeByDenom(src, sub, prices)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "get fees")
+	}
+
+	cost, err := spendByDenom(dest, bi.ToGweiF64(bi.MulRaw(gasPrice, gas)), prices)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "get cost")
+	}
+
+	return fees.nUSD / 1e9, cost.nUSD / 1e9, nil
+}
+
+// profitDecision returns the decision given the estimated fees and cost, and how long the submission has been delayed.
+func profitDecision(policy ProfitPolicy, feesUSD, costUSD float64, delayed time.Duration) string {
+	if feesUSD >= costUSD*policy.MinRatio {
+		return profitSubmit
+	} else if delayed >= policy.MaxDelay {
+		return profitMaxDelay
+	}
+
+	return profitDelay
+}
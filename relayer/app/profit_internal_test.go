@@ -0,0 +1,129 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/lib/xchain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProfitPolicies(t *testing.T) {
+	t.Parallel()
+
+	write := func(content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "profit.toml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		return path
+	}
+
+	policies, err := LoadProfitPolicies(write(`
+[[policies]]
+chain-id = 84532
+min-ratio = 1.2
+max-delay = "2m"
+`))
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]ProfitPolicy{
+		evmchain.IDBaseSepolia: {ChainID: evmchain.IDBaseSepolia, MinRatio: 1.2, MaxDelay: 2 * time.Minute},
+	}, policies.ByChain())
+
+	policies, err = LoadProfitPolicies("")
+	require.NoError(t, err)
+	require.Empty(t, policies.Policies)
+
+	_, err = LoadProfitPolicies(write("[[policies]]\nchain-id = 1\nmin-ratio = 1\n"))
+	require.ErrorContains(t, err, "max-delay must be positive")
+
+	_, err = LoadProfitPolicies(write("[[policies]]\nchain-id = 1\nmin-ratio = 1\nmax-delay = \"5m\"\n"))
+	require.ErrorContains(t, err, "max-delay must be less than the worker lease stall timeout")
+
+	_, err = LoadProfitPolicies(write("[[policies]]\nchain-id = 1\nmin-ratio = 1\nmax-delay = \"1m\"\n[[policies]]\nchain-id = 1\nmin-ratio = 1\nmax-delay = \"1m\"\n"))
+	require.ErrorContains(t, err, "duplicate chain policy")
+}
+
+func TestProfitDecision(t *testing.T) {
+	t.Parallel()
+
+	policy := ProfitPolicy{MinRatio: 1.5, MaxDelay: time.Minute}
+
+	require.Equal(t, profitSubmit, profitDecision(policy, 1.5, 1, 0))
+	require.Equal(t, profitDelay, profitDecision(policy, 1.4, 1, time.Second))
+	require.Equal(t, profitMaxDelay, profitDecision(policy, 1.4, 1, time.Minute))
+	require.Equal(t, profitSubmit, profitDecision(policy, 0, 0, 0))
+}
+
+func TestProfitGate(t *testing.T) {
+	t.Parallel()
+
+	dest := netconf.Chain{ID: evmchain.IDBaseSepolia, Name: "base_sepolia"}
+	pricer := tokenpricer.NewUSDMock(map[tokens.Asset]float64{tokens.ETH: 2000, tokens.OMNI: 1})
+
+	// Single xmsg paying 0.001 ETH in fees, costing 600k gas (naive estimate), so profitable below ~1.67 gwei.
+	sub := xchain.Submission{
+		BlockHeader: xchain.BlockHeader{ChainID: evmchain.IDHolesky},
+		Msgs: []xchain.Msg{{
+			MsgID:        xchain.MsgID{StreamID: xchain.StreamID{SourceChainID: evmchain.IDHolesky, DestChainID: dest.ID}},
+			DestGasLimit: 100_000,
+			Fees:         bi.Ether(0.001),
+		}},
+		DestChainID: dest.ID,
+	}
+
+	newGate := func(policy ProfitPolicy, gasPrice gasPriceFunc) profitGate {
+		gate := newProfitGate(netconf.Omega, dest, policy, nil, gasPrice, pricer)
+		gate.recheck = time.Millisecond
+
+		return gate
+	}
+
+	// Delayed until gas drops
+	var calls atomic.Int64
+	gate := newGate(ProfitPolicy{ChainID: dest.ID, MinRatio: 1, MaxDelay: time.Hour}, func(context.Context) (*big.Int, error) {
+		if calls.Add(1) < 3 {
+			return bi.Gwei(10), nil
+		}
+
+		return bi.Gwei(1), nil
+	})
+	require.NoError(t, gate.Await(t.Context(), sub))
+	require.EqualValues(t, 3, calls.Load())
+
+	// Submitted anyway after max delay
+	gate = newGate(ProfitPolicy{ChainID: dest.ID, MinRatio: 1, MaxDelay: 10 * time.Millisecond}, func(context.Context) (*big.Int, error) {
+		return bi.Gwei(10), nil
+	})
+	require.NoError(t, gate.Await(t.Context(), sub))
+
+	// Submitted if estimation fails
+	gate = newGate(ProfitPolicy{ChainID: dest.ID, MinRatio: 1, MaxDelay: time.Hour}, func(context.Context) (*big.Int, error) {
+		return nil, errors.New("rpc down")
+	})
+	require.NoError(t, gate.Await(t.Context(), sub))
+
+	// Sending is blocked while delayed
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	gate = newGate(ProfitPolicy{ChainID: dest.ID, MinRatio: 1, MaxDelay: time.Hour}, func(context.Context) (*big.Int, error) {
+		return bi.Gwei(10), nil
+	})
+	send := gate.Wrap(func(context.Context, xchain.Submission) <-chan error {
+		require.Fail(t, "unexpected send")
+		return nil
+	})
+	require.ErrorIs(t, <-send(ctx, sub), context.Canceled)
+}
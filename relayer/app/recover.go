@@ -0,0 +1,115 @@
+package relayer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/txmgr"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// recoverFunc replaces stuck transactions of the relayer account before the worker (re)starts.
+type recoverFunc func(ctx context.Context) error
+
+// nonceRecovery replaces pending (possibly stuck) relayer transactions on a destination chain
+// with no-op self-transfers, so a restarting worker resumes from a clean nonce.
+//
+// Cancelling rather than speeding up pending submissions is sufficient, since the worker
+// resubmits everything after the on-chain submitted cursors. It also prevents stale submissions
+// from being included after the restarted worker resubmits them, i.e., double submissions.
+type nonceRecovery struct {
+	chainName string
+	ethCl     ethclient.Client
+	txMgr     txmgr.TxManager
+
+	// next is the nonce after the highest nonce reserved by senders. Transactions
+	// after a nonce gap are queued by nodes, so aren't reflected by the pending nonce.
+	next atomic.Uint64
+}
+
+func newNonceRecovery(chainName string, ethCl ethclient.Client, txMgr txmgr.TxManager) *nonceRecovery {
+	return &nonceRecovery{
+		chainName: chainName,
+		ethCl:     ethCl,
+		txMgr:     txMgr,
+	}
+}
+
+// Reserved records a nonce reserved by a sender.
+func (r *nonceRecovery) Reserved(nonce uint64) {
+	for {
+		next := r.next.Load()
+		if nonce < next || r.next.CompareAndSwap(next, nonce+1) {
+			return
+		}
+	}
+}
+
+// Recover replaces all pending transactions of the relayer account with no-op self-transfers.
+// It blocks until all are included, returning an error if any nonce remains pending.
+func (r *nonceRecovery) Recover(ctx context.Context) error {
+	from := r.txMgr.From()
+
+	mined, err := r.ethCl.NonceAt(ctx, from, nil)
+	if err != nil {
+		return errors.Wrap(err, "mined nonce")
+	}
+
+	pending, err := r.ethCl.PendingNonceAt(ctx, from)
+	if err != nil {
+		return errors.Wrap(err, "pending nonce")
+	}
+
+	target := max(pending, r.next.Load())
+	if target <= mined {
+		return nil
+	}
+
+	log.Warn(ctx, "Replacing pending relayer transactions", nil,
+		"mined_nonce", mined,
+		"pending_nonce", pending,
+		"target_nonce", target,
+	)
+
+	// Replace concurrently, since Send blocks until included.
+	var wg sync.WaitGroup
+	for nonce := mined; nonce < target; nonce++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			tx, _, err := r.txMgr.Send(ctx, txmgr.TxCandidate{
+				To:       &from,
+				GasLimit: params.TxGas,
+				Value:    bi.Zero(),
+				Nonce:    &nonce,
+			})
+			if err != nil {
+				// The pending tx may have been included before being replaced, checked below.
+				log.DebugErr(ctx, "Failed replacing pending relayer transaction", err, "nonce", nonce)
+				return
+			}
+
+			recoveredNonces.WithLabelValues(r.chainName).Inc()
+			log.Debug(ctx, "Replaced pending relayer transaction", "nonce", nonce, "tx", tx.Hash())
+		}()
+	}
+	wg.Wait()
+
+	mined, err = r.ethCl.NonceAt(ctx, from, nil)
+	if err != nil {
+		return errors.Wrap(err, "mined nonce")
+	} else if mined < target {
+		return errors.New("pending relayer transactions remain", "mined_nonce", mined, "target_nonce", target)
+	}
+
+	log.Info(ctx, "Replaced pending relayer transactions", "nonce", mined)
+
+	return nil
+}
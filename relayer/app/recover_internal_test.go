@@ -0,0 +1,133 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/txmgr"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRecoverChain mocks a chain including relayer transactions by nonce.
+type mockRecoverChain struct {
+	ethclient.Client
+	txmgr.TxManager
+
+	mu       sync.Mutex
+	mined    uint64 // Next nonce to include
+	pending  uint64 // Next nonce after contiguous pending txs
+	included map[uint64]bool
+	replaced []uint64
+	failSend bool // Fail replacements
+	mineFail bool // Include the original pending tx when failing replacements
+}
+
+func (c *mockRecoverChain) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.included[c.mined] {
+		c.mined++
+	}
+
+	return c.mined, nil
+}
+
+func (c *mockRecoverChain) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.pending, nil
+}
+
+func (*mockRecoverChain) From() common.Address {
+	return common.HexToAddress("0x1234")
+}
+
+func (c *mockRecoverChain) Send(_ context.Context, candidate txmgr.TxCandidate) (*ethtypes.Transaction, *ethclient.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nonce := *candidate.Nonce
+	c.included[nonce] = c.included[nonce] || !c.failSend || c.mineFail
+	if c.failSend {
+		return nil, nil, errors.New("nonce too low")
+	}
+
+	c.replaced = append(c.replaced, nonce)
+	tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{Nonce: nonce, To: candidate.To, Value: candidate.Value, Gas: candidate.GasLimit})
+
+	return tx, &ethclient.Receipt{}, nil
+}
+
+func (c *mockRecoverChain) Replaced() []uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := slices.Clone(c.replaced)
+	slices.Sort(resp)
+
+	return resp
+}
+
+func TestNonceRecovery(t *testing.T) {
+	t.Parallel()
+
+	newRecovery := func(mined, pending uint64) (*nonceRecovery, *mockRecoverChain) {
+		chain := &mockRecoverChain{mined: mined, pending: pending, included: make(map[uint64]bool)}
+		return newNonceRecovery("mock_l1", chain, chain), chain
+	}
+
+	t.Run("none pending", func(t *testing.T) {
+		t.Parallel()
+		recovery, chain := newRecovery(5, 5)
+		recovery.Reserved(4) // Already included
+
+		require.NoError(t, recovery.Recover(t.Context()))
+		require.Empty(t, chain.Replaced())
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		t.Parallel()
+		recovery, chain := newRecovery(5, 8)
+
+		require.NoError(t, recovery.Recover(t.Context()))
+		require.Equal(t, []uint64{5, 6, 7}, chain.Replaced())
+	})
+
+	t.Run("nonce gap", func(t *testing.T) {
+		t.Parallel()
+		recovery, chain := newRecovery(5, 5)
+		recovery.Reserved(7)
+		recovery.Reserved(6)
+
+		require.NoError(t, recovery.Recover(t.Context()))
+		require.Equal(t, []uint64{5, 6, 7}, chain.Replaced())
+	})
+
+	t.Run("included before replaced", func(t *testing.T) {
+		t.Parallel()
+		recovery, chain := newRecovery(5, 7)
+		chain.failSend = true
+		chain.mineFail = true
+
+		require.NoError(t, recovery.Recover(t.Context()))
+		require.Empty(t, chain.Replaced())
+	})
+
+	t.Run("stuck", func(t *testing.T) {
+		t.Parallel()
+		recovery, chain := newRecovery(5, 7)
+		chain.failSend = true
+
+		require.ErrorContains(t, recovery.Recover(t.Context()), "pending relayer transactions remain")
+	})
+}
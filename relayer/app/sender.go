@@ -38,6 +38,7 @@ type Sender struct {
 	chainNames   map[xchain.ChainVersion]string
 	ethCl        ethclient.Client
 	onSubmit     onSubmitFunc
+	onReserve    func(nonce uint64)
 }
 
 // NewSender returns a new sender.
@@ -48,26 +49,13 @@ func NewSender(
 	privateKey ecdsa.PrivateKey,
 	chainNames map[xchain.ChainVersion]string,
 	onSubmit onSubmitFunc,
+	onReserve func(nonce uint64),
 ) (Sender, error) {
-	const receiptPollFreq = 3 // Query receipts every 1/3 of the block time
-	cfg, err := txmgr.NewConfig(
-		txmgr.NewCLIConfig(
-			chain.ID,
-			chain.BlockPeriod/receiptPollFreq,
-			txmgr.DefaultSenderFlagValues,
-		),
-		&privateKey,
-		rpcClient,
-	)
+	txMgr, err := newTxMgr(chain, rpcClient, privateKey)
 	if err != nil {
 		return Sender{}, err
 	}
 
-	txMgr, err := txmgr.NewSimple(chain.Name, cfg)
-	if err != nil {
-		return Sender{}, errors.Wrap(err, "create tx mgr")
-	}
-
 	// Create ABI
 	parsedAbi, err := abi.JSON(strings.NewReader(bindings.OmniPortalMetaData.ABI))
 	if err != nil {
@@ -89,9 +77,34 @@ func NewSender(
 		chainNames:   chainNames,
 		ethCl:        rpcClient,
 		onSubmit:     onSubmit,
+		onReserve:    onReserve,
 	}, nil
 }
 
+// newTxMgr returns a new tx manager sending transactions to the chain.
+func newTxMgr(chain netconf.Chain, rpcClient ethclient.Client, privateKey ecdsa.PrivateKey) (txmgr.TxManager, error) {
+	const receiptPollFreq = 3 // Query receipts every 1/3 of the block time
+	cfg, err := txmgr.NewConfig(
+		txmgr.NewCLIConfig(
+			chain.ID,
+			chain.BlockPeriod/receiptPollFreq,
+			txmgr.DefaultSenderFlagValues,
+		),
+		&privateKey,
+		rpcClient,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	txMgr, err := txmgr.NewSimple(chain.Name, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "create tx mgr")
+	}
+
+	return txMgr, nil
+}
+
 // SendAsync sends the submission to the destination chain asynchronously.
 // It returns a channel that will receive an error if the submission fails or nil when it succeeds.
 // Nonces are however reserved synchronously, so ordering of submissions
@@ -144,6 +157,9 @@ func (s Sender) SendAsync(ctx context.Context, sub xchain.Submission) <-chan err
 	if err != nil {
 		return returnErr(err)
 	}
+	if s.onReserve != nil {
+		s.onReserve(nonce)
+	}
 
 	estimatedGas := s.gasEstimator(s.chain.ID, sub.Msgs)
 
@@ -0,0 +1,199 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/xchain"
+)
+
+const (
+	// EndpointStatus is the monitoring server endpoint serving the relayer status.
+	EndpointStatus = "/api/v1/status"
+
+	// statusTimeout bounds the time spent querying chains for the status (within the monitoring server write timeout).
+	statusTimeout = 4 * time.Second
+
+	// maxRecentErrors is the number of recent errors retained per worker.
+	maxRecentErrors = 10
+)
+
+// StatusResponse is the response of the status API.
+type StatusResponse struct {
+	Chains []ChainStatus `json:"chains"`
+}
+
+// ChainStatus is the status of a destination chain worker.
+type ChainStatus struct {
+	DestChain    string         `json:"destChain"`
+	DestChainID  uint64         `json:"destChainId"`
+	Primary      bool           `json:"primary"`         // False if a standby, see lease-dir
+	Inflight     int64          `json:"inflight"`        // Submissions sent to the mempool, but not yet included
+	Streams      []StreamStatus `json:"streams"`         // Streams to the destination chain
+	RecentErrors []RecentError  `json:"recentErrors"`    // Latest worker errors (e.g. failed submissions), most recent last
+	Error        string         `json:"error,omitempty"` // Error fetching the status
+}
+
+// StreamStatus is the status of a stream to a destination chain.
+type StreamStatus struct {
+	Stream                string `json:"stream"`
+	ChainVersion          string `json:"chainVersion"`          // Attested source chain version of the stream
+	SubmittedMsgOffset    uint64 `json:"submittedMsgOffset"`    // Latest msg offset submitted to the destination portal
+	SubmittedAttestOffset uint64 `json:"submittedAttestOffset"` // Latest attest offset submitted to the destination portal
+	StoredAttestOffset    uint64 `json:"storedAttestOffset"`    // Latest confirmed attest offset in the cursor store
+	LatestAttestOffset    uint64 `json:"latestAttestOffset"`    // Latest attest offset of the chain version on the consensus chain
+	Lag                   uint64 `json:"lag"`                   // Attestations not yet submitted or confirmed (latest minus max of submitted and stored)
+}
+
+// RecentError is a recent worker error.
+type RecentError struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error"`
+}
+
+// recentErrors retains the latest worker errors.
+type recentErrors struct {
+	mu   sync.Mutex
+	errs []RecentError
+}
+
+func (r *recentErrors) Add(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errs = append(r.errs, RecentError{Time: time.Now(), Error: errors.Format(err)})
+	if len(r.errs) > maxRecentErrors {
+		r.errs = r.errs[len(r.errs)-maxRecentErrors:]
+	}
+}
+
+func (r *recentErrors) List() []RecentError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.errs)
+}
+
+// statusReporter reports the status of all registered workers.
+type statusReporter struct {
+	mu      sync.Mutex
+	workers []*Worker
+}
+
+// Add registers the worker.
+func (r *statusReporter) Add(w *Worker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.workers = append(r.workers, w)
+}
+
+// Status returns the status of all registered workers, querying them concurrently.
+func (r *statusReporter) Status(ctx context.Context) StatusResponse {
+	r.mu.Lock()
+	workers := slices.Clone(r.workers)
+	r.mu.Unlock()
+
+	resp := StatusResponse{Chains: make([]ChainStatus, len(workers))}
+
+	var wg sync.WaitGroup
+	for i, w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp.Chains[i] = w.Status(ctx)
+		}()
+	}
+	wg.Wait()
+
+	return resp
+}
+
+// ServeHTTP serves the status as JSON.
+func (r *statusReporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), statusTimeout)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(r.Status(ctx)); err != nil {
+		log.DebugErr(ctx, "Failed writing status response", err)
+	}
+}
+
+// Status returns the status of the worker.
+// Fetching errors are included in the response, since other fields may still be useful.
+func (w *Worker) Status(ctx context.Context) ChainStatus {
+	resp := ChainStatus{
+		DestChain:    w.destChain.Name,
+		DestChainID:  w.destChain.ID,
+		Primary:      w.lease == nil || w.lease.Primary(),
+		RecentErrors: w.errs.List(),
+	}
+
+	if buf := w.buf.Load(); buf != nil {
+		resp.Inflight = buf.Inflight()
+	}
+
+	streams, err := w.streamStatus(ctx)
+	if err != nil {
+		resp.Error = errors.Format(err)
+	}
+	resp.Streams = streams
+
+	return resp
+}
+
+// streamStatus returns the status of all streams to the destination chain.
+func (w *Worker) streamStatus(ctx context.Context) ([]StreamStatus, error) {
+	cursors, err := getSubmittedCursors(ctx, w.network, w.destChain.ID, w.xProvider)
+	if err != nil {
+		return nil, err
+	}
+	submitted := make(map[xchain.StreamID]xchain.SubmitCursor)
+	for _, c := range cursors {
+		submitted[c.StreamID] = c
+	}
+
+	stored, err := w.cursors.WorkerOffsets(ctx, w.destChain.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[xchain.ChainVersion]uint64)
+	var resp []StreamStatus //nolint:prealloc // Not worth it
+	for _, stream := range w.network.StreamsTo(w.destChain.ID) {
+		chainVer := xchain.ChainVersion{ID: stream.SourceChainID, ConfLevel: stream.ShardID.ConfLevel()}
+
+		if _, ok := latest[chainVer]; !ok {
+			att, ok, err := w.cProvider.LatestAttestation(ctx, chainVer)
+			if err != nil {
+				return resp, errors.Wrap(err, "latest attestation", "chain_version", w.network.ChainVersionName(chainVer))
+			} else if ok {
+				latest[chainVer] = att.AttestOffset
+			}
+		}
+
+		cursor := submitted[stream]
+		status := StreamStatus{
+			Stream:                w.network.StreamName(stream),
+			ChainVersion:          w.network.ChainVersionName(chainVer),
+			SubmittedMsgOffset:    cursor.MsgOffset,
+			SubmittedAttestOffset: cursor.AttestOffset,
+			StoredAttestOffset:    stored[chainVer],
+			LatestAttestOffset:    latest[chainVer],
+		}
+		if processed := max(status.SubmittedAttestOffset, status.StoredAttestOffset); status.LatestAttestOffset > processed {
+			status.Lag = status.LatestAttestOffset - processed
+		}
+
+		resp = append(resp, status)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,103 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/omni-network/omni/lib/cchain"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/xchain"
+	"github.com/omni-network/omni/relayer/app/cursor"
+
+	db "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+)
+
+type statusProvider struct {
+	cchain.Provider
+	latest map[xchain.ChainVersion]uint64
+}
+
+func (p statusProvider) LatestAttestation(_ context.Context, chainVer xchain.ChainVersion) (xchain.Attestation, bool, error) {
+	offset, ok := p.latest[chainVer]
+	return xchain.Attestation{AttestHeader: xchain.AttestHeader{ChainVersion: chainVer, AttestOffset: offset}}, ok, nil
+}
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	const srcChain, destChain = 1, 2
+	network := netconf.Network{Chains: []netconf.Chain{
+		{ID: srcChain, Name: "source", Shards: []xchain.ShardID{xchain.ShardFinalized0, xchain.ShardLatest0}, HasEmitPortal: true},
+		{ID: destChain, Name: "mock_l1", HasSubmitPortal: true},
+	}}
+
+	finalized := xchain.StreamID{SourceChainID: srcChain, DestChainID: destChain, ShardID: xchain.ShardFinalized0}
+	xClient := &mockXChainClient{
+		GetSubmittedCursorFn: func(_ context.Context, _ xchain.Ref, stream xchain.StreamID) (xchain.SubmitCursor, bool, error) {
+			if stream != finalized {
+				return xchain.SubmitCursor{}, false, nil
+			}
+
+			return xchain.SubmitCursor{StreamID: stream, MsgOffset: 5, AttestOffset: 8}, true, nil
+		},
+	}
+
+	cProvider := statusProvider{latest: map[xchain.ChainVersion]uint64{
+		{ID: srcChain, ConfLevel: xchain.ConfFinalized}: 10,
+		{ID: srcChain, ConfLevel: xchain.ConfLatest}:    20,
+	}}
+
+	cursors, err := cursor.New(db.NewMemDB(), xClient.GetSubmittedCursor, network)
+	require.NoError(t, err)
+
+	w := NewWorker(network.Chains[1], network, cProvider, xClient, nil, nil, nil, nil, cursors, nil)
+	w.errs.Add(errors.New("submission reverted"))
+
+	buf := newActiveBuffer("mock_l1", mempoolLimit, nil)
+	buf.inflight.Add(2)
+	w.buf.Store(buf)
+
+	status := new(statusReporter)
+	status.Add(w)
+
+	srv := httptest.NewServer(status)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var res StatusResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	require.Len(t, res.Chains, 1)
+
+	chain := res.Chains[0]
+	require.Equal(t, "mock_l1", chain.DestChain)
+	require.True(t, chain.Primary)
+	require.EqualValues(t, 2, chain.Inflight)
+	require.Empty(t, chain.Error)
+	require.Len(t, chain.RecentErrors, 1)
+	require.Equal(t, "submission reverted", chain.RecentErrors[0].Error)
+
+	require.Equal(t, []StreamStatus{
+		{
+			Stream:                network.StreamName(finalized),
+			ChainVersion:          network.ChainVersionName(xchain.ChainVersion{ID: srcChain, ConfLevel: xchain.ConfFinalized}),
+			SubmittedMsgOffset:    5,
+			SubmittedAttestOffset: 8,
+			LatestAttestOffset:    10,
+			Lag:                   2,
+		},
+		{
+			Stream:             network.StreamName(xchain.StreamID{SourceChainID: srcChain, DestChainID: destChain, ShardID: xchain.ShardLatest0}),
+			ChainVersion:       network.ChainVersionName(xchain.ChainVersion{ID: srcChain, ConfLevel: xchain.ConfLatest}),
+			LatestAttestOffset: 20,
+			Lag:                20,
+		},
+	}, chain.Streams)
+}
@@ -31,7 +31,11 @@ type Worker struct {
 	creator      CreateFunc
 	sendProvider func() (SendAsync, error)
 	awaitValSet  awaitValSet
+	recoverTxs   recoverFunc // Nil if not recovering pending transactions
 	cursors      *cursor.Store
+	lease        *workerLease                 // Nil if not coordinating with other instances
+	buf          atomic.Pointer[activeBuffer] // Active buffer of the current run, for status reporting
+	errs         recentErrors
 }
 
 // NewWorker creates a new worker for a single destination chain.
@@ -43,7 +47,9 @@ func NewWorker(
 	creator CreateFunc,
 	sendProvider func() (SendAsync, error),
 	awaitValSet awaitValSet,
+	recoverTxs recoverFunc,
 	cursors *cursor.Store,
+	lease *workerLease,
 ) *Worker {
 	return &Worker{
 		destChain:    destChain,
@@ -53,7 +59,9 @@ func NewWorker(
 		creator:      creator,
 		sendProvider: sendProvider,
 		awaitValSet:  awaitValSet,
+		recoverTxs:   recoverTxs,
 		cursors:      cursors,
+		lease:        lease,
 	}
 }
 
@@ -61,7 +69,7 @@ func (w *Worker) Run(ctx context.Context) {
 	ctx = log.WithCtx(ctx, "dst_chain", w.destChain.Name)
 	backoff := expbackoff.NewWithAutoReset(ctx)
 	for ctx.Err() == nil {
-		err := w.runOnce(ctx)
+		err := w.runLeased(ctx)
 		if ctx.Err() != nil {
 			return
 		} else if errors.Is(err, chaos.ErrChaos) {
@@ -70,17 +78,64 @@ func (w *Worker) Run(ctx context.Context) {
 			log.Warn(ctx, "Worker failed, resetting", err)
 		}
 
+		w.errs.Add(err)
+
 		workerResets.WithLabelValues(w.destChain.Name).Inc()
 		backoff()
 	}
 }
 
+// runLeased runs the worker while holding the lease as primary, or just runs it if not coordinating.
+// It blocks as a standby until the lease is acquired, and stops the worker if the lease is lost.
+func (w *Worker) runLeased(ctx context.Context) error {
+	if w.lease == nil {
+		return w.runOnce(ctx)
+	}
+
+	if err := w.lease.Await(ctx); err != nil {
+		return err
+	}
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	leaseErr := make(chan error, 1)
+	go func() {
+		leaseErr <- w.lease.Hold(workerCtx)
+		cancel() // Stop the worker when the lease is lost
+	}()
+
+	err := w.runOnce(workerCtx)
+	cancel()
+
+	if lErr := <-leaseErr; lErr != nil {
+		return lErr
+	}
+
+	// Release the lease when shutting down, so standbys take over immediately.
+	if ctx.Err() != nil {
+		if err := w.lease.backend.Release(context.WithoutCancel(ctx), w.lease.chainName, w.lease.holder); err != nil {
+			log.Warn(ctx, "Failed releasing worker lease", err)
+		}
+	}
+
+	return err
+}
+
 func (w *Worker) runOnce(ctx context.Context) error {
 	log.Info(ctx, "Worker starting")
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// Replace pending transactions of previous runs before fetching cursors, so they
+	// neither block new nonces nor get included after being resubmitted.
+	if w.recoverTxs != nil {
+		if err := w.recoverTxs(ctx); err != nil {
+			return errors.Wrap(err, "recover pending txs")
+		}
+	}
+
 	cursors, err := getSubmittedCursors(ctx, w.network, w.destChain.ID, w.xProvider)
 	if err != nil {
 		return err
@@ -99,7 +154,8 @@ func (w *Worker) runOnce(ctx context.Context) error {
 		return err
 	}
 
-	buf := newActiveBuffer(w.destChain.Name, mempoolLimit, sender)
+	buf := newActiveBuffer(w.destChain.Name, mempoolLimit, w.progressOnSend(sender))
+	w.buf.Store(buf)
 
 	attestOffsets, err := fromChainVersionOffsets(cursors, w.network.ChainVersionsTo(w.destChain.ID))
 	if err != nil {
@@ -167,6 +223,32 @@ func (w *Worker) runOnce(ctx context.Context) error {
 	return buf.Run(ctx)
 }
 
+// progress marks the worker lease as making progress, if coordinating.
+func (w *Worker) progress() {
+	if w.lease != nil {
+		w.lease.Progress()
+	}
+}
+
+// progressOnSend returns a SendAsync that marks progress when submissions are sent successfully.
+// It calls sendAsync synchronously, preserving nonce ordering, and only waits for the response async.
+func (w *Worker) progressOnSend(sendAsync SendAsync) SendAsync {
+	return func(ctx context.Context, sub xchain.Submission) <-chan error {
+		sent := sendAsync(ctx, sub)
+
+		resp := make(chan error, 1)
+		go func() {
+			err := <-sent
+			if err == nil {
+				w.progress()
+			}
+			resp <- err
+		}()
+
+		return resp
+	}
+}
+
 // awaitValSet blocks until the portal is aware of this validator set ID.
 type awaitValSet func(ctx context.Context, valsetID uint64) error
 
@@ -246,7 +328,13 @@ func (w *Worker) newCallback(
 
 	return func(ctx context.Context, att xchain.Attestation) error {
 		saveCursors := func(streamMsgs map[xchain.StreamID][]xchain.Msg) error {
-			return w.cursors.Insert(ctx, streamerChainVer, w.destChain.ID, att.AttestOffset, streamMsgs)
+			if err := w.cursors.Insert(ctx, streamerChainVer, w.destChain.ID, att.AttestOffset, streamMsgs); err != nil {
+				return err
+			}
+
+			w.progress() // Cursor advanced
+
+			return nil
 		}
 
 		block, ok, err := fetchXBlock(ctx, w.xProvider, att)
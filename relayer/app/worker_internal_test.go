@@ -151,6 +151,7 @@ func TestWorker_Run(t *testing.T) {
 	}}
 
 	noAwait := func(context.Context, uint64) error { return nil }
+	noRecover := func(context.Context) error { return nil }
 
 	cursors, err := cursor.New(db.NewMemDB(), mockXClient.GetSubmittedCursor, network)
 	require.NoError(t, err)
@@ -164,7 +165,9 @@ func TestWorker_Run(t *testing.T) {
 			mockCreateFunc,
 			func() (SendAsync, error) { return mockSender.SendTransaction, nil },
 			noAwait,
-			cursors)
+			noRecover,
+			cursors,
+			nil)
 		go w.Run(ctx)
 	}
 
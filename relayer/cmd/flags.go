@@ -17,4 +17,10 @@ func bindRunFlags(flags *pflag.FlagSet, cfg *relayer.Config) {
 	flags.StringVar(&cfg.MonitoringAddr, "monitoring-addr", cfg.MonitoringAddr, "The address to bind the monitoring server")
 	flags.StringVar(&cfg.DBDir, "db-dir", cfg.DBDir, "The path to the database directory")
 	flags.StringVar(&cfg.CoinGeckoAPIKey, "coingecko-apikey", cfg.CoinGeckoAPIKey, "The CoinGecko API key to use for fetching token prices")
+	flags.StringVar(&cfg.LeaseDir, "lease-dir", cfg.LeaseDir, "The path to a lease directory shared by redundant relayer instances, only the lease holder submits to each destination chain")
+	flags.StringVar(&cfg.ProfitFile, "profit-file", cfg.ProfitFile, "The path to the optional TOML profitability policy file, delaying unprofitable submissions per destination chain")
+}
+
+func bindStatusFlags(flags *pflag.FlagSet, cfg *statusConfig) {
+	flags.StringVarP(&cfg.MonitoringURL, "monitoring-url", "u", cfg.MonitoringURL, "The URL of the relayer monitoring server")
 }
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	relayer "github.com/omni-network/omni/relayer/app"
+
+	"github.com/spf13/cobra"
+)
+
+type statusConfig struct {
+	MonitoringURL string
+}
+
+func defaultStatusConfig() statusConfig {
+	return statusConfig{
+		MonitoringURL: "http://localhost:26660",
+	}
+}
+
+func newStatusCmd() *cobra.Command {
+	cfg := defaultStatusConfig()
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Query a running relayer for per-stream cursors and lag",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			status, err := queryStatus(cmd.Context(), cfg)
+			if err != nil {
+				return errors.Wrap(err, "status")
+			}
+
+			return printStatus(os.Stdout, status)
+		},
+	}
+
+	bindStatusFlags(cmd.Flags(), &cfg)
+
+	return cmd
+}
+
+// queryStatus calls the relayer's status endpoint.
+func queryStatus(ctx context.Context, cfg statusConfig) (relayer.StatusResponse, error) {
+	url := cfg.MonitoringURL + relayer.EndpointStatus
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return relayer.StatusResponse{}, errors.Wrap(err, "http request creation")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return relayer.StatusResponse{}, errors.Wrap(err, "http request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return relayer.StatusResponse{}, errors.New("unexpected status code", "code", resp.StatusCode)
+	}
+
+	var status relayer.StatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return relayer.StatusResponse{}, errors.Wrap(err, "decode status")
+	}
+
+	return status, nil
+}
+
+// printStatus pretty-prints the status, one section per destination chain.
+func printStatus(out io.Writer, status relayer.StatusResponse) error {
+	for i, chain := range status.Chains {
+		if i > 0 {
+			_, _ = fmt.Fprintln(out)
+		}
+
+		role := "primary"
+		if !chain.Primary {
+			role = "standby"
+		}
+		_, _ = fmt.Fprintf(out, "%s (%d): %s, %d inflight\n", chain.DestChain, chain.DestChainID, role, chain.Inflight)
+		if chain.Error != "" {
+			_, _ = fmt.Fprintf(out, "  status error: %s\n", chain.Error)
+		}
+
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "  STREAM\tCHAIN VERSION\tSUBMITTED MSG\tSUBMITTED ATTEST\tSTORED ATTEST\tLATEST ATTEST\tLAG")
+		for _, s := range chain.Streams {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%d\t%d\t%d\t%d\t%d\n",
+				s.Stream, s.ChainVersion, s.SubmittedMsgOffset, s.SubmittedAttestOffset,
+				s.StoredAttestOffset, s.LatestAttestOffset, s.Lag)
+		}
+		if err := tw.Flush(); err != nil {
+			return errors.Wrap(err, "flush")
+		}
+
+		if len(chain.RecentErrors) > 0 {
+			_, _ = fmt.Fprintln(out, "  Recent errors:")
+		}
+		for _, e := range chain.RecentErrors {
+			_, _ = fmt.Fprintf(out, "    %s  %s\n", e.Time.Format(time.RFC3339), e.Error)
+		}
+	}
+
+	return nil
+}
@@ -65,6 +65,19 @@ func (a *ageCache) blockMeta(ctx context.Context, chainID uint64, height uint64)
 	return name, time.Unix(timeI64, 0), nil
 }
 
+// openedAtFunc returns the time an order was opened, if known.
+type openedAtFunc func(orderID OrderID) (time.Time, bool)
+
+// OpenedAt returns the block time of the order's pending event, if cached.
+func (a *ageCache) OpenedAt(orderID OrderID) (time.Time, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	v, ok := a.createdAts[orderID]
+
+	return v.CreatedAt, ok
+}
+
 // InstrumentAge instruments the age of an order event.
 func (a *ageCache) InstrumentAge(ctx context.Context, srcChainID uint64, srcHeight uint64, order Order) slog.Attr {
 	a.mu.Lock()
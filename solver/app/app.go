@@ -16,7 +16,9 @@ import (
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/ethclient"
 	"github.com/omni-network/omni/lib/ethclient/ethbackend"
+	"github.com/omni-network/omni/lib/evmchain"
 	"github.com/omni-network/omni/lib/expbackoff"
+	"github.com/omni-network/omni/lib/layerzero"
 	"github.com/omni-network/omni/lib/log"
 	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tokenpricer"
@@ -25,6 +27,7 @@ import (
 	"github.com/omni-network/omni/lib/unibackend"
 	"github.com/omni-network/omni/lib/xchain"
 	xprovider "github.com/omni-network/omni/lib/xchain/provider"
+	"github.com/omni-network/omni/solver/fundthresh"
 	"github.com/omni-network/omni/solver/job"
 	"github.com/omni-network/omni/solver/rebalance"
 	"github.com/omni-network/omni/solver/targets"
@@ -132,6 +135,27 @@ func Run(ctx context.Context, cfg Config) error {
 		return errors.Wrap(err, "create cursor store")
 	}
 
+	firmQuotes, err := newFirmQuotes(db)
+	if err != nil {
+		return errors.Wrap(err, "create firm quote store")
+	}
+	go pruneFirmQuotesForever(ctx, firmQuotes)
+	isFirm := newIsFirm(firmQuotes)
+
+	inv := newInventory()
+
+	history, err := newOrderHistory(db)
+	if err != nil {
+		return errors.Wrap(err, "create order history store")
+	}
+	go pruneHistoryForever(ctx, history)
+	stream := newOrderStream()
+
+	pauses, err := newPauser(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "create pause store")
+	}
+
 	addrs, err := contracts.GetAddresses(ctx, network.ID)
 	if err != nil {
 		return errors.Wrap(err, "get contract addresses")
@@ -171,17 +195,56 @@ func Run(ctx context.Context, cfg Config) error {
 		return errors.Wrap(err, "approve outboxes")
 	}
 
-	pricer := newPricer(ctx, network.ID, cfg.CoinGeckoAPIKey)
+	pricer, err := newPricer(ctx, network.ID, cfg, backends)
+	if err != nil {
+		return errors.Wrap(err, "new pricer")
+	}
 	priceFunc := newPriceFunc(pricer)
 
+	fees, err := newFeeStoreFromFile(cfg.FeesFile)
+	if err != nil {
+		return errors.Wrap(err, "load fee schedule")
+	}
+	feeFunc := newFeeFunc(fees, pricer)
+
+	var reloads []func(context.Context)
+	if cfg.FeesFile != "" {
+		reloads = append(reloads, func(ctx context.Context) { reloadFees(ctx, cfg.FeesFile, fees) })
+	}
+
+	if cfg.FundThresholdsFile != "" {
+		if err := fundthresh.Reload(cfg.FundThresholdsFile); err != nil {
+			return errors.Wrap(err, "load fund thresholds")
+		}
+		rebalance.UpdateThresholdMetrics(network)
+		reloads = append(reloads, func(ctx context.Context) { reloadThresholds(ctx, cfg.FundThresholdsFile, network) })
+	}
+
+	go reloadOnSignal(ctx, reloads...)
+
 	go monitorPricesForever(ctx, priceFunc)
 
-	err = startProcessingEvents(ctx, network, xprov, jobDB, uniBackends, privKey, addrs, cursors, pricer, priceFunc, inboxContracts)
+	claimPolicies, err := LoadClaimPolicies(cfg.ClaimsFile)
+	if err != nil {
+		return errors.Wrap(err, "load claim policies")
+	}
+
+	claims, err := newPendingClaims(db)
+	if err != nil {
+		return err
+	}
+
+	fillStore, err := newFillStore(db)
+	if err != nil {
+		return err
+	}
+
+	err = startProcessingEvents(ctx, network, xprov, jobDB, uniBackends, privKey, addrs, cursors, pricer, priceFunc, feeFunc, firmQuotes, inv, history, stream, claims, claimPolicies.ByChain(), fillStore, pauses, inboxContracts)
 	if err != nil {
 		return errors.Wrap(err, "start event streams")
 	}
 
-	if err := rebalance.Start(ctx, network, newCCTPClient(network.ID), pricer, backends, solverAddr, cfg.DBDir); err != nil {
+	if err := startRebalancing(ctx, network, xprov, pricer, backends, solverAddr, cfg.DBDir, rebalanceOpts(cfg)...); err != nil {
 		log.Warn(ctx, "Failed to start rebalancing [BUG]", err)
 	}
 
@@ -196,26 +259,59 @@ func Run(ctx context.Context, cfg Config) error {
 	log.Info(ctx, "Serving API", "address", cfg.APIAddr)
 
 	// Build base handlers that are always available
-	checkFunc := newChecker(uniBackends, callAllower, priceFunc, solverAddr, addrs.SolverNetOutbox)
+	checkFunc := newChecker(uniBackends, callAllower, priceFunc, feeFunc, isFirm, inv, pauses.Paused, solverAddr, addrs.SolverNetOutbox)
+	rateLimits, err := LoadRateLimits(cfg.RateLimitsFile)
+	if err != nil {
+		return errors.Wrap(err, "load rate limits")
+	}
+	limiter := newRateLimiter(rateLimits)
+
+	var simulate simulateFunc // Nil disables simulation
+	if cfg.SimulationDir != "" {
+		simulator, err := startSimulator(ctx, cfg.SimulationDir, network, cfg.RPCEndpoints, solverAddr, addrs.SolverNetOutbox)
+		if err != nil {
+			return errors.Wrap(err, "start simulator")
+		}
+		simulate = limiter.WrapSimulate(simulator.Simulate)
+	}
+
 	handlers := []Handler{
 		newCheckHandler(
 			checkFunc,
 			newTracer(backends, solverAddr, addrs.SolverNetOutbox),
+			simulate,
 		),
 		newContractsHandler(addrs),
-		newQuoteHandler(newQuoter(priceFunc)),
-		newPriceHandler(wrapPriceHandlerFunc(priceFunc)),
+		newQuoteHandler(newQuoter(priceFunc, feeFunc, newLiquidityChecker(uniBackends, solverAddr, inv), newFirmQuoter(firmQuotes, privKey, cfg.FirmQuoteTTL), pauses.Paused)),
+		newPriceHandler(wrapPriceHandlerFunc(priceFunc, feeFunc)),
 		newTokensHandler(network.ChainIDs()),
+		newOrderHandler(history),
+		newOrderStreamHandler(stream, history),
+	}
+
+	// Only add admin handlers if an admin API token is configured
+	if cfg.AdminAPIToken != "" {
+		handlers = append(handlers, newAdminHandlers(cfg.AdminAPIToken, pauses)...)
 	}
 
 	// Only add relay handler for ephemeral networks
 	if network.ID.IsEphemeral() {
 		log.Debug(ctx, "Adding relay handler for ephemeral network", "network", network.ID)
-		handlers = append(handlers, newRelayHandler(newRelayer(inboxContracts, uniBackends, solverAddr, addrs.SolverNetInbox, checkFunc)))
+		relayFee := func(ctx context.Context, chainID uint64, rec *ethclient.Receipt) (float64, error) {
+			return txFeeUSD(ctx, pricer, chainID, rec)
+		}
+		relays, err := newRelayLedger(db, cfg.RelayMaxUserDailyUSD, newRelayReceiptFunc(uniBackends), relayFee)
+		if err != nil {
+			return errors.Wrap(err, "create relay ledger")
+		}
+		handlers = append(handlers,
+			newRelayHandler(newRelayer(inboxContracts, uniBackends, solverAddr, addrs.SolverNetInbox, checkFunc, relays, pricer)),
+			newRelayStatusHandler(relays),
+		)
 	}
 
 	//nolint:contextcheck // False positive, inner context is used for shutdown
-	apiChan, apiCancel := serveAPI(cfg.APIAddr, handlers...)
+	apiChan, apiCancel := serveAPI(cfg.APIAddr, limiter, handlers...)
 	defer apiCancel()
 
 	select {
@@ -292,6 +388,15 @@ func startProcessingEvents(
 	cursors *cursors,
 	pricer tokenpricer.Pricer,
 	priceFunc priceFunc,
+	feeFunc feeFunc,
+	firmQuotes *firmQuotes,
+	inv *inventory,
+	history *orderHistory,
+	stream *orderStream,
+	claims *pendingClaims,
+	claimPolicies map[uint64]ClaimPolicy,
+	fillStore *fillStore,
+	pauses *pauser,
 	inboxContracts map[uint64]*bindings.SolverNetInbox,
 ) error {
 	solverAddr := ethcrypto.PubkeyToAddress(solverKey.PublicKey)
@@ -351,7 +456,7 @@ func startProcessingEvents(
 
 	debugFunc := func(ctx context.Context, order Order, event Event) {
 		debugPendingData(ctx, targetName, order, event)
-		debugOrderPrice(ctx, priceFunc, order)
+		debugOrderPrice(ctx, priceFunc, feeFunc, order)
 	}
 
 	callAllower := newCallAllower(network.ID, addrs.SolverNetExecutor)
@@ -359,16 +464,37 @@ func startProcessingEvents(
 	ageCache := newAgeCache(ethBackends)
 	go monitorAgeCacheForever(ctx, network, ageCache)
 
-	filledPnL := newFilledPnlFunc(pricer, targetName, network.ChainName, ageCache.InstrumentDestFilled)
-	updatePnL := newUpdatePnLFunc(pricer, network.ChainName)
+	recordHistory := withStreamUpdates(newHistoryRecorder(history), stream)
+	filledPnL := withFilledHistory(newFilledPnlFunc(pricer, targetName, network.ChainName, ageCache.InstrumentDestFilled), recordHistory, pricer)
+	updatePnL := withUpdateHistory(newUpdatePnLFunc(pricer, network.ChainName), recordHistory, pricer)
+
+	getOrder := newOrderGetter(inboxContracts)
+	claimer := newClaimScheduler(
+		claims,
+		claimPolicies,
+		newClaimer(inboxContracts, backends, solverAddr, updatePnL),
+		recordHistory,
+		getOrder,
+		newGasPricer(backends),
+		pricer,
+		pauses.Paused,
+	)
+	go claimer.ClaimForever(ctx, claimCheckInterval)
+
+	fills := newFillTracker(fillStore, newDidFillerAt(outboxContracts), newFinalizer(backends))
+	if err := fills.Load(ctx, getOrder); err != nil {
+		return errors.Wrap(err, "load tracked fills")
+	}
 
 	deps := procDeps{
-		GetOrder:          newOrderGetter(inboxContracts),
-		ShouldReject:      newShouldRejector(backends, callAllower, priceFunc, solverAddr, addrs.SolverNetOutbox),
+		GetOrder:          getOrder,
+		ShouldReject:      newShouldRejector(backends, callAllower, priceFunc, feeFunc, newIsFirm(firmQuotes), ageCache.OpenedAt, inv, pauses.Paused, solverAddr, addrs.SolverNetOutbox),
 		DidFill:           newDidFiller(outboxContracts),
-		Reject:            newRejector(inboxContracts, backends, solverAddr, updatePnL),
-		Fill:              newFiller(outboxContracts, backends, solverAddr, addrs.SolverNetOutbox, filledPnL),
-		Claim:             newClaimer(inboxContracts, backends, solverAddr, updatePnL),
+		Reject:            withFirmQuoteReject(firmQuotes, newRejector(inboxContracts, backends, solverAddr, updatePnL)),
+		Fill:              withFirmQuoteFill(firmQuotes, withInventoryRelease(inv, newFiller(outboxContracts, backends, solverAddr, addrs.SolverNetOutbox, filledPnL))),
+		Claim:             claimer.Claim,
+		RecordHistory:     recordHistory,
+		TrackFill:         fills.Track,
 		ChainName:         network.ChainName,
 		ProcessorName:     procName,
 		TargetName:        targetName,
@@ -394,6 +520,7 @@ func startProcessingEvents(
 
 	// Create the async worker function
 	asyncWork := newAsyncWorkerFunc(jobDB, procs, network.ChainName)
+	go fills.TrackForever(ctx, fillTrackInterval, newJobReprocessor(jobDB, asyncWork))
 
 	// Start all processing all existing jobs
 	jobs, err := jobDB.All(ctx)
@@ -483,6 +610,75 @@ func streamEventsForever(
 	}
 }
 
+// startRebalancing starts rebalancing the solver's balance.
+// Devnets use local CCTP and LayerZero stand-ins, since devnet CCTP chains are anvil forks of mainnet chains.
+func startRebalancing(
+	ctx context.Context,
+	network netconf.Network,
+	xprov xchain.Provider,
+	pricer tokenpricer.Pricer,
+	backends ethbackend.Backends,
+	solverAddr common.Address,
+	dbDir string,
+	opts ...rebalance.Options,
+) error {
+	if network.ID != netconf.Devnet {
+		return rebalance.Start(ctx, network, newCCTPClient(network.ID), pricer, backends, solverAddr, dbDir, opts...)
+	}
+
+	clients := backends.Clients()
+	cctpClients := make(map[uint64]ethclient.Client)
+	var cctpChains []evmchain.Metadata
+	for _, chain := range network.EVMChains() {
+		meta, ok := evmchain.MetadataByID(chain.ID)
+		if !ok || !cctp.IsSupportedChain(chain.ID) {
+			continue
+		}
+
+		client, ok := clients[chain.ID]
+		if !ok {
+			return errors.New("missing cctp chain client", "chain", chain.Name)
+		}
+
+		cctpClients[chain.ID] = client
+		cctpChains = append(cctpChains, meta)
+	}
+
+	// Random attester, enabled on forked MessageTransmitters on startup.
+	attesterPk, err := ethcrypto.GenerateKey()
+	if err != nil {
+		return errors.Wrap(err, "generate attester key")
+	}
+
+	cctpClient, err := cctp.StartDevClient(ctx, attesterPk, xprov, cctpChains, cctpClients)
+	if err != nil {
+		return errors.Wrap(err, "start cctp dev client")
+	}
+
+	opts = append(opts, rebalance.WithLayerZeroClient(layerzero.NewDevClient(clients)))
+
+	return rebalance.Start(ctx, network, cctpClient, pricer, backends, solverAddr, dbDir, opts...)
+}
+
+// rebalanceOpts returns the rebalance options configured in cfg.
+func rebalanceOpts(cfg Config) []rebalance.Options {
+	opts := []rebalance.Options{
+		rebalance.WithMaxSlippage(cfg.RebalanceMaxSlippageBips),
+		rebalance.WithMaxPriceDeviation(cfg.RebalanceMaxPriceDeviationBips),
+	}
+	if cfg.RebalancePlanner {
+		opts = append(opts, rebalance.WithPlanner())
+	}
+	if cfg.RebalanceDryRun {
+		opts = append(opts, rebalance.WithDryRun())
+	}
+	if cfg.RebalancePlanFile != "" {
+		opts = append(opts, rebalance.WithPlanFile(cfg.RebalancePlanFile))
+	}
+
+	return opts
+}
+
 // newCCTPClient creates a new CCTP client based on the network ID.
 func newCCTPClient(networkID netconf.ID) cctp.Client {
 	api := cctp.TestnetAPI
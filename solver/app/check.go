@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"math/big"
+	"time"
 
 	"github.com/omni-network/omni/contracts/bindings"
 	"github.com/omni-network/omni/lib/bi"
@@ -25,7 +26,16 @@ type checkFunc func(context.Context, types.CheckRequest) error
 
 // newChecker returns a checkFunc that can be used to see if an order would be accepted or rejected.
 // It is the logic behind the /check endpoint.
-func newChecker(backends unibackend.Backends, isAllowedCall callAllowFunc, priceFunc priceFunc, solverAddr, outboxAddr common.Address) checkFunc {
+func newChecker(
+	backends unibackend.Backends,
+	isAllowedCall callAllowFunc,
+	priceFunc priceFunc,
+	feeFunc feeFunc,
+	isFirm isFirmFunc,
+	inv *inventory,
+	isPaused pausedFunc,
+	solverAddr, outboxAddr common.Address,
+) checkFunc {
 	return func(ctx context.Context, req types.CheckRequest) error {
 		if _, err := backends.Backend(req.SourceChainID); err != nil {
 			return newRejection(types.RejectUnsupportedSrcChain, errors.New("unsupported source chain", "chain_id", req.SourceChainID))
@@ -51,11 +61,24 @@ func newChecker(backends unibackend.Backends, isAllowedCall callAllowFunc, price
 			return err
 		}
 
-		if err := checkQuote(ctx, priceFunc, []TokenAmt{deposit}, expenses); err != nil {
+		// Orders are rejected if either quoting or filling is paused.
+		for _, scope := range []types.PauseScope{types.PauseQuote, types.PauseFill} {
+			if err := checkPaused(isPaused, scope, req.SourceChainID, []TokenAmt{deposit}, req.DestinationChainID, expenses); err != nil {
+				return err
+			}
+		}
+
+		var expectedID OrderID
+		if req.OrderID != nil {
+			expectedID = OrderID(*req.OrderID)
+		}
+
+		// Orders are not yet opened, so firm quotes are checked as if opened now.
+		if err := checkFirmOrQuote(ctx, priceFunc, feeFunc, isFirm, expectedID, time.Now(), req.SourceChainID, req.DestinationChainID, []TokenAmt{deposit}, expenses); err != nil {
 			return err
 		}
 
-		if err := checkLiquidity(ctx, expenses, dstBackend, solverAddr); err != nil {
+		if err := checkLiquidity(ctx, expenses, dstBackend, solverAddr, inv); err != nil {
 			return err
 		}
 
@@ -58,8 +58,9 @@ func TestCheckSameChainIntegration(t *testing.T) {
 
 	// Create check handler
 	handler := newCheckHandler(
-		newChecker(unibackend.EVMBackends(backends), func(_ uint64, _ common.Address, _ []byte) bool { return true }, unaryPrice, solver, outbox),
+		newChecker(unibackend.EVMBackends(backends), func(_ uint64, _ common.Address, _ []byte) bool { return true }, unaryPrice, testFeeFunc(), notFirm, newInventory(), notPaused, solver, outbox),
 		newTracer(backends, solver, outbox),
+		nil,
 	)
 
 	// Create test server
@@ -45,7 +45,7 @@ func TestCheck(t *testing.T) {
 
 			callAllower := func(_ uint64, _ common.Address, _ []byte) bool { return !tt.disallowCall }
 			handler := handlerAdapter(newCheckHandler(
-				newChecker(uniBackends, callAllower, priceFunc, solver, outbox),
+				newChecker(uniBackends, callAllower, priceFunc, testFeeFunc(), notFirm, newInventory(), notPaused, solver, outbox),
 				func(ctx context.Context, req types.CheckRequest) (types.CallTrace, error) {
 					require.True(t, tt.req.Debug)
 					require.True(t, tt.trace == nil || tt.traceErr == nil)
@@ -56,6 +56,7 @@ func TestCheck(t *testing.T) {
 
 					return *tt.trace, tt.traceErr
 				},
+				nil,
 			))
 
 			if tt.mock != nil {
@@ -126,7 +127,7 @@ func TestCheckError(t *testing.T) {
 			Err:        errors.New(msg),
 			StatusCode: http.StatusServiceUnavailable,
 		}
-	}, noopTracer))
+	}, noopTracer, nil))
 
 	srv := httptest.NewServer(handler)
 	defer srv.Close()
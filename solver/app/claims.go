@@ -0,0 +1,444 @@
+package app
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/unibackend"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/BurntSushi/toml"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cosmossdk.io/orm/types/ormerrors"
+	db "github.com/cosmos/cosmos-db"
+)
+
+// claimCheckInterval is the interval at which deferred claims are checked against their chain's policy.
+const claimCheckInterval = time.Minute
+
+const (
+	claimTriggerMaxDelay = "max_delay"
+	claimTriggerGasPrice = "gas_price"
+	claimTriggerUSDValue = "usd_value"
+)
+
+// ClaimPolicy defines when filled orders from an origin chain are claimed.
+// Claims of filled orders are deferred until any trigger is met, then each accrued order is claimed in its own tx,
+// since the inbox only supports claiming a single order. Deferring therefore only saves gas by claiming when the gas
+// price is low, not by combining claims. The USD trigger doesn't save gas, it bounds the deposits left unclaimed.
+// Zero gas price and USD triggers are disabled, the max delay is required.
+type ClaimPolicy struct {
+	ChainID         uint64        `toml:"chain-id"`           // Origin chain ID
+	MaxDelay        time.Duration `toml:"max-delay"`          // Claim once the oldest accrued order was filled this long ago
+	MaxGasPriceGwei float64       `toml:"max-gas-price-gwei"` // Claim once the origin chain gas price is at or below this
+	MinUSD          float64       `toml:"min-usd"`            // Claim once the accrued order deposits are worth at least this in USD, bounding unclaimed deposits
+}
+
+func (p ClaimPolicy) Validate() error {
+	if p.ChainID == 0 {
+		return errors.New("missing chain id")
+	}
+
+	if p.MaxDelay <= 0 {
+		return errors.New("max-delay must be positive", "chain", p.ChainID)
+	}
+
+	if p.MaxGasPriceGwei < 0 || p.MinUSD < 0 {
+		return errors.New("negative trigger", "chain", p.ChainID)
+	}
+
+	return nil
+}
+
+// ClaimPolicies defines the claim policies per origin chain.
+// Orders from chains without a policy are claimed immediately.
+type ClaimPolicies struct {
+	Policies []ClaimPolicy `toml:"policies"`
+}
+
+func (p ClaimPolicies) Validate() error {
+	dups := make(map[uint64]bool)
+	for _, policy := range p.Policies {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+
+		if dups[policy.ChainID] {
+			return errors.New("duplicate chain policy", "chain", policy.ChainID)
+		}
+		dups[policy.ChainID] = true
+	}
+
+	return nil
+}
+
+// ByChain returns the policies by origin chain ID.
+func (p ClaimPolicies) ByChain() map[uint64]ClaimPolicy {
+	resp := make(map[uint64]ClaimPolicy)
+	for _, policy := range p.Policies {
+		resp[policy.ChainID] = policy
+	}
+
+	return resp
+}
+
+// LoadClaimPolicies loads and validates a TOML claim policy file.
+// No policies are returned if path is empty.
+func LoadClaimPolicies(path string) (ClaimPolicies, error) {
+	if path == "" {
+		return ClaimPolicies{}, nil
+	}
+
+	var resp ClaimPolicies
+	if _, err := toml.DecodeFile(path, &resp); err != nil {
+		return ClaimPolicies{}, errors.Wrap(err, "decode claim policies", "path", path)
+	}
+
+	if err := resp.Validate(); err != nil {
+		return ClaimPolicies{}, errors.Wrap(err, "validate claim policies", "path", path)
+	}
+
+	return resp, nil
+}
+
+func newPendingClaims(db db.DB) (*pendingClaims, error) {
+	dbStore, err := newSolverStore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pendingClaims{
+		table: dbStore.PendingClaimTable(),
+	}, nil
+}
+
+// pendingClaims provides a thread-safe persisted store of filled orders awaiting a deferred claim.
+type pendingClaims struct {
+	mu    sync.Mutex
+	table PendingClaimTable
+}
+
+// Add adds the order to the store if not already present.
+func (c *pendingClaims) Add(ctx context.Context, order Order, usdValue float64, now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ok, err := c.table.Has(ctx, order.ID[:]); err != nil {
+		return errors.Wrap(err, "has pending claim")
+	} else if ok {
+		return nil
+	}
+
+	err := c.table.Insert(ctx, &PendingClaim{
+		OrderId:    order.ID[:],
+		SrcChainId: order.SourceChainID,
+		UsdValue:   usdValue,
+		FilledAt:   timestamppb.New(now),
+	})
+	if err != nil {
+		return errors.Wrap(err, "insert pending claim")
+	}
+
+	return nil
+}
+
+// List returns all pending claims of orders from the source chain.
+func (c *pendingClaims) List(ctx context.Context, srcChainID uint64) ([]*PendingClaim, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	iter, err := c.table.List(ctx, PendingClaimSrcChainIdIndexKey{}.WithSrcChainId(srcChainID))
+	if err != nil {
+		return nil, errors.Wrap(err, "list pending claims")
+	}
+	defer iter.Close()
+
+	var resp []*PendingClaim
+	for iter.Next() {
+		claim, err := iter.Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "get value")
+		}
+
+		resp = append(resp, proto.Clone(claim).(*PendingClaim)) //nolint:forcetypeassert // Type known
+	}
+
+	return resp, nil
+}
+
+// Delete deletes the order's pending claim, if any.
+func (c *pendingClaims) Delete(ctx context.Context, id OrderID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err := c.table.Delete(ctx, &PendingClaim{OrderId: id[:]})
+	if err != nil && !ormerrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete pending claim")
+	}
+
+	return nil
+}
+
+// gasPriceFunc returns the current gas price of the chain.
+type gasPriceFunc func(ctx context.Context, chainID uint64) (*big.Int, error)
+
+// newGasPricer returns a gasPriceFunc suggesting EVM gas prices from the backends.
+func newGasPricer(backends unibackend.Backends) gasPriceFunc {
+	return func(ctx context.Context, chainID uint64) (*big.Int, error) {
+		backend, err := backends.Backend(chainID)
+		if err != nil {
+			return nil, err
+		} else if !backend.IsEVM() {
+			return nil, errors.New("gas price only supports eth backend")
+		}
+
+		return backend.EVMBackend().SuggestGasPrice(ctx)
+	}
+}
+
+// claimScheduler defers claims of filled orders from origin chains with a claim policy,
+// claiming all accrued orders (one tx per order) once a policy trigger is met.
+type claimScheduler struct {
+	store    *pendingClaims
+	policies map[uint64]ClaimPolicy
+	claim    func(ctx context.Context, order Order) error
+	record   recordHistoryFunc
+	getOrder func(ctx context.Context, chainID uint64, id OrderID) (Order, bool, error)
+	gasPrice gasPriceFunc
+	pricer   tokenpricer.Pricer
+	isPaused pausedFunc
+	now      func() time.Time
+}
+
+func newClaimScheduler(
+	store *pendingClaims,
+	policies map[uint64]ClaimPolicy,
+	claim func(ctx context.Context, order Order) error,
+	record recordHistoryFunc,
+	getOrder func(ctx context.Context, chainID uint64, id OrderID) (Order, bool, error),
+	gasPrice gasPriceFunc,
+	pricer tokenpricer.Pricer,
+	isPaused pausedFunc,
+) *claimScheduler {
+	return &claimScheduler{
+		store:    store,
+		policies: policies,
+		claim:    claim,
+		record:   record,
+		getOrder: getOrder,
+		gasPrice: gasPrice,
+		pricer:   pricer,
+		isPaused: isPaused,
+		now:      time.Now,
+	}
+}
+
+// Claim claims the order immediately if its origin chain has no claim policy,
+// otherwise it persists the order to be claimed later, returning true.
+func (s *claimScheduler) Claim(ctx context.Context, order Order) (bool, error) {
+	if _, ok := s.policies[order.SourceChainID]; !ok {
+		return false, s.claimUnpaused(ctx, order)
+	}
+
+	usd, err := depositsUSD(ctx, s.pricer, order)
+	if err != nil {
+		log.Warn(ctx, "Failed to value deferred claim (ignoring)", err)
+	}
+
+	if err := s.store.Add(ctx, order, usd, s.now()); err != nil {
+		return false, errors.Wrap(err, "defer claim")
+	}
+
+	log.Debug(ctx, "Deferred order claim", "deposits_usd", usd)
+
+	return true, nil
+}
+
+// ClaimForever blocks and periodically claims accrued orders of chains whose policy trigger is met.
+func (s *claimScheduler) ClaimForever(ctx context.Context, interval time.Duration) {
+	if len(s.policies) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for chainID, policy := range s.policies {
+				ctx := log.WithCtx(ctx, "src_chain", evmchain.Name(chainID))
+				if err := s.claimOnce(ctx, policy); err != nil {
+					log.Warn(ctx, "Failed claiming deferred orders (will retry)", err)
+				}
+			}
+		}
+	}
+}
+
+// claimOnce claims all accrued orders of the policy's chain if any trigger is met.
+// Claims are isolated; failed claims remain pending and are retried once a trigger is met again.
+func (s *claimScheduler) claimOnce(ctx context.Context, policy ClaimPolicy) error {
+	pending, err := s.store.List(ctx, policy.ChainID)
+	if err != nil {
+		return err
+	}
+
+	chain := evmchain.Name(policy.ChainID)
+	instrumentDeferredClaims(chain, pending)
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	trigger, ok, err := s.trigger(ctx, policy, pending)
+	if err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	log.Info(ctx, "Claiming deferred orders", "count", len(pending), "trigger", trigger)
+	deferredClaimReleases.WithLabelValues(chain, trigger).Inc()
+
+	var claimed int
+	for _, p := range pending {
+		id := OrderID(p.GetOrderId())
+		ctx := log.WithCtx(ctx, "order_id", id.String())
+
+		if err := s.claimPending(ctx, policy.ChainID, id); err != nil {
+			log.Warn(ctx, "Failed claiming deferred order (will retry)", err)
+			continue
+		}
+
+		if err := s.store.Delete(ctx, id); err != nil {
+			return err
+		}
+		claimed++
+	}
+
+	deferredClaimReleaseSize.WithLabelValues(chain).Observe(float64(claimed))
+
+	remaining, err := s.store.List(ctx, policy.ChainID)
+	if err != nil {
+		return err
+	}
+	instrumentDeferredClaims(chain, remaining)
+
+	return nil
+}
+
+// claimPending claims the order if it is still filled, recording the claim in the order history.
+// Orders no longer filled (e.g. already claimed) are skipped.
+func (s *claimScheduler) claimPending(ctx context.Context, chainID uint64, id OrderID) error {
+	order, found, err := s.getOrder(ctx, chainID, id)
+	if err != nil {
+		return errors.Wrap(err, "get order")
+	} else if !found || order.Status != solvernet.StatusFilled {
+		log.Info(ctx, "Dropping deferred claim of order no longer filled", "found", found, "status", order.Status)
+		return nil
+	}
+
+	if err := s.claimUnpaused(ctx, order); err != nil {
+		return err
+	}
+
+	s.record(ctx, order, historyEntry{Action: historyClaim})
+
+	return nil
+}
+
+// claimUnpaused claims the order, unless claims are paused for its source chain or deposits.
+// Paused claims return an error, so they are retried until resumed.
+func (s *claimScheduler) claimUnpaused(ctx context.Context, order Order) error {
+	deposits, err := parseMinReceived(order)
+	if err != nil {
+		return err
+	}
+
+	if pause, ok := s.isPaused(types.PauseClaim, order.SourceChainID, amtTokens(deposits)...); ok {
+		return errors.New("claims paused", "chain_id", pause.ChainID, "token", pause.Token, "reason", pause.Reason)
+	}
+
+	return s.claim(ctx, order)
+}
+
+// trigger returns the first policy trigger met by the pending claims, if any.
+func (s *claimScheduler) trigger(ctx context.Context, policy ClaimPolicy, pending []*PendingClaim) (string, bool, error) {
+	var usd float64
+	oldest := s.now()
+	for _, p := range pending {
+		usd += p.GetUsdValue()
+		if filledAt := p.GetFilledAt().AsTime(); filledAt.Before(oldest) {
+			oldest = filledAt
+		}
+	}
+
+	if s.now().Sub(oldest) >= policy.MaxDelay {
+		return claimTriggerMaxDelay, true, nil
+	}
+
+	if policy.MinUSD > 0 && usd >= policy.MinUSD {
+		return claimTriggerUSDValue, true, nil
+	}
+
+	if policy.MaxGasPriceGwei > 0 {
+		gasPrice, err := s.gasPrice(ctx, policy.ChainID)
+		if err != nil {
+			return "", false, errors.Wrap(err, "gas price")
+		}
+
+		if bi.ToGweiF64(gasPrice) <= policy.MaxGasPriceGwei {
+			return claimTriggerGasPrice, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// depositsUSD returns the USD value of the filled order's deposits.
+func depositsUSD(ctx context.Context, pricer tokenpricer.Pricer, order Order) (float64, error) {
+	deposits, err := order.MinReceived()
+	if err != nil {
+		return 0, err
+	}
+
+	var resp float64
+	for _, deposit := range deposits {
+		tkn, ok := tokenByAddr32(order.SourceChainID, deposit.Token)
+		if !ok {
+			return 0, errors.New("unknown deposit token")
+		}
+
+		usd, err := usdValue(ctx, pricer, TokenAmt{Token: tkn, Amount: deposit.Amount})
+		if err != nil {
+			return 0, err
+		}
+
+		resp += usd
+	}
+
+	return resp, nil
+}
+
+func instrumentDeferredClaims(chain string, pending []*PendingClaim) {
+	var usd float64
+	for _, p := range pending {
+		usd += p.GetUsdValue()
+	}
+
+	deferredClaims.WithLabelValues(chain).Set(float64(len(pending)))
+	deferredClaimsUSD.WithLabelValues(chain).Set(usd)
+}
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tutil"
+
+	db "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadClaimPolicies(t *testing.T) {
+	t.Parallel()
+
+	write := func(content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "claims.toml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		return path
+	}
+
+	policies, err := LoadClaimPolicies(write(`
+[[policies]]
+chain-id = 1
+max-delay = "6h"
+max-gas-price-gwei = 2.5
+min-usd = 1000
+`))
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]ClaimPolicy{
+		1: {ChainID: 1, MaxDelay: 6 * time.Hour, MaxGasPriceGwei: 2.5, MinUSD: 1000},
+	}, policies.ByChain())
+
+	policies, err = LoadClaimPolicies("")
+	require.NoError(t, err)
+	require.Empty(t, policies.ByChain())
+
+	_, err = LoadClaimPolicies(write("[[policies]]\nchain-id = 1\n"))
+	require.ErrorContains(t, err, "max-delay must be positive")
+
+	_, err = LoadClaimPolicies(write("[[policies]]\nchain-id = 1\nmax-delay = \"1h\"\n[[policies]]\nchain-id = 1\nmax-delay = \"2h\"\n"))
+	require.ErrorContains(t, err, "duplicate chain policy")
+}
+
+func TestClaimScheduler(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	memDB := db.NewMemDB()
+	const (
+		deferChain = evmchain.IDEthereum
+		otherChain = evmchain.IDBase
+	)
+
+	policy := ClaimPolicy{ChainID: deferChain, MaxDelay: time.Hour, MaxGasPriceGwei: 1, MinUSD: 10_000}
+
+	orders := make(map[OrderID]Order)
+	claimed := make(map[OrderID]int)
+	failing := make(map[OrderID]bool)
+	recorded := make(map[OrderID][]string)
+	gasPrice := bi.Gwei(10)
+
+	newScheduler := func(now time.Time) *claimScheduler {
+		t.Helper()
+		store, err := newPendingClaims(memDB)
+		require.NoError(t, err)
+
+		s := newClaimScheduler(
+			store,
+			map[uint64]ClaimPolicy{deferChain: policy},
+			func(_ context.Context, order Order) error {
+				if failing[order.ID] {
+					return errors.New("claim failed")
+				}
+				claimed[order.ID]++
+
+				return nil
+			},
+			func(_ context.Context, order Order, entry historyEntry) {
+				recorded[order.ID] = append(recorded[order.ID], entry.Action)
+			},
+			func(_ context.Context, _ uint64, id OrderID) (Order, bool, error) {
+				order, ok := orders[id]
+				return order, ok, nil
+			},
+			func(context.Context, uint64) (*big.Int, error) { return gasPrice, nil },
+			tokenpricer.NewDevnetMock(),
+			notPaused,
+		)
+		s.now = func() time.Time { return now }
+
+		return s
+	}
+
+	filledOrder := func(chainID uint64) Order {
+		order := Order{ID: OrderID(tutil.RandomHash()), SourceChainID: chainID, Status: solvernet.StatusFilled}
+		orders[order.ID] = order
+
+		return order
+	}
+
+	t0 := time.Unix(1700000000, 0)
+	s := newScheduler(t0)
+
+	// Chains without a policy are claimed immediately
+	other := filledOrder(otherChain)
+	deferred, err := s.Claim(ctx, other)
+	require.NoError(t, err)
+	require.False(t, deferred)
+	require.Equal(t, 1, claimed[other.ID])
+
+	// Chains with a policy are deferred
+	order1 := filledOrder(deferChain)
+	order2 := filledOrder(deferChain)
+	order3 := filledOrder(deferChain)
+	for _, order := range []Order{order1, order2, order3, order1} {
+		deferred, err := s.Claim(ctx, order)
+		require.NoError(t, err)
+		require.True(t, deferred)
+	}
+
+	pending, err := s.store.List(ctx, deferChain)
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+
+	// No trigger met yet
+	require.NoError(t, s.claimOnce(ctx, policy))
+	require.Empty(t, claimed[order1.ID])
+
+	// Pending claims survive restarts, max delay triggers claiming all pending orders.
+	// Failed claims remain pending, orders no longer filled are dropped.
+	s = newScheduler(t0.Add(time.Hour))
+	failing[order2.ID] = true
+	orders[order3.ID] = Order{ID: order3.ID, SourceChainID: deferChain, Status: solvernet.StatusClaimed}
+
+	require.NoError(t, s.claimOnce(ctx, policy))
+	require.Equal(t, 1, claimed[order1.ID])
+	require.Empty(t, claimed[order3.ID])
+
+	// Only landed claims are recorded
+	require.Equal(t, []string{historyClaim}, recorded[order1.ID])
+	require.Empty(t, recorded[order2.ID])
+	require.Empty(t, recorded[order3.ID])
+
+	pending, err = s.store.List(ctx, deferChain)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, order2.ID[:], pending[0].GetOrderId())
+
+	// Low gas price triggers claiming
+	failing[order2.ID] = false
+	s = newScheduler(t0.Add(time.Hour))
+	_, err = s.Claim(ctx, filledOrder(deferChain))
+	require.NoError(t, err)
+	s.now = func() time.Time { return t0.Add(time.Hour + time.Minute) }
+	pending, err = s.store.List(ctx, deferChain)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	// Only consider the fresh claim, since order2 exceeds the max delay
+	fresh := pending[:1]
+	if pending[0].GetFilledAt().AsTime().Equal(t0) {
+		fresh = pending[1:]
+	}
+
+	trigger, ok, err := s.trigger(ctx, policy, fresh)
+	require.NoError(t, err)
+	require.False(t, ok, trigger)
+
+	gasPrice = bi.Gwei(0.5)
+	trigger, ok, err = s.trigger(ctx, policy, fresh)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, claimTriggerGasPrice, trigger)
+
+	// USD value triggers claiming
+	gasPrice = bi.Gwei(10)
+	fresh[0].UsdValue = policy.MinUSD
+	trigger, ok, err = s.trigger(ctx, policy, fresh)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, claimTriggerUSDValue, trigger)
+}
@@ -3,12 +3,14 @@ package app
 import (
 	"bytes"
 	"text/template"
+	"time"
 
 	"github.com/omni-network/omni/lib/buildinfo"
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/log"
 	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tracer"
+	"github.com/omni-network/omni/lib/uniswap"
 	"github.com/omni-network/omni/lib/xchain"
 
 	cmtos "github.com/cometbft/cometbft/libs/os"
@@ -17,22 +19,44 @@ import (
 )
 
 type Config struct {
-	RPCEndpoints    xchain.RPCEndpoints
-	Network         netconf.ID
-	MonitoringAddr  string
-	APIAddr         string
-	SolverPrivKey   string
-	DBDir           string
-	Tracer          tracer.Config
-	CoinGeckoAPIKey string
+	RPCEndpoints                   xchain.RPCEndpoints
+	Network                        netconf.ID
+	MonitoringAddr                 string
+	APIAddr                        string
+	AdminAPIToken                  string
+	RateLimitsFile                 string
+	SolverPrivKey                  string
+	DBDir                          string
+	Tracer                         tracer.Config
+	CoinGeckoAPIKey                string
+	PriceMaxAge                    time.Duration
+	PriceMaxDeviation              float64
+	StaticPrices                   map[string]string
+	FeesFile                       string
+	ClaimsFile                     string
+	FundThresholdsFile             string
+	FirmQuoteTTL                   time.Duration
+	RebalancePlanner               bool
+	RebalanceDryRun                bool
+	RebalancePlanFile              string
+	RebalanceMaxSlippageBips       uint64
+	RebalanceMaxPriceDeviationBips uint64
+	RelayMaxUserDailyUSD           float64
+	SimulationDir                  string
 }
 
 func DefaultConfig() Config {
 	return Config{
-		SolverPrivKey:  "solver.key",
-		MonitoringAddr: ":26660",
-		APIAddr:        ":26661",
-		DBDir:          "./db",
+		SolverPrivKey:                  "solver.key",
+		MonitoringAddr:                 ":26660",
+		APIAddr:                        ":26661",
+		DBDir:                          "./db",
+		PriceMaxAge:                    defaultPriceMaxAge,
+		PriceMaxDeviation:              defaultPriceMaxDeviation,
+		FirmQuoteTTL:                   defaultFirmQuoteTTL,
+		RebalanceMaxSlippageBips:       uniswap.DefaultMaxSlippageBips,
+		RebalanceMaxPriceDeviationBips: uniswap.DefaultMaxDeviationBips,
+		RelayMaxUserDailyUSD:           defaultRelayMaxUserDailyUSD,
 	}
 }
 
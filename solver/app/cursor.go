@@ -37,7 +37,8 @@ func newSolverDB(dbDir string) (db.DB, error) {
 	return resp, nil
 }
 
-func newCursors(db db.DB) (*cursors, error) {
+// newSolverStore returns the solver app ORM store backed by the given DB.
+func newSolverStore(db db.DB) (SolverStore, error) {
 	schema := &ormv1alpha1.ModuleSchemaDescriptor{SchemaFile: []*ormv1alpha1.ModuleSchemaDescriptor_FileEntry{
 		{Id: 1, ProtoFileName: File_solver_app_solver_proto.Path()},
 	}}
@@ -54,6 +55,15 @@ func newCursors(db db.DB) (*cursors, error) {
 		return nil, errors.Wrap(err, "create store")
 	}
 
+	return dbStore, nil
+}
+
+func newCursors(db db.DB) (*cursors, error) {
+	dbStore, err := newSolverStore(db)
+	if err != nil {
+		return nil, err
+	}
+
 	return &cursors{
 		table: dbStore.CursorTable(),
 	}, nil
@@ -0,0 +1,238 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+
+	"github.com/BurntSushi/toml"
+)
+
+// standardFeeBips is the standard fee charged by the solver (0.3%).
+const standardFeeBips = 30
+
+// defaultFeeRule is the name of the rule applied when no fee schedule rules match.
+const defaultFeeRule = "default"
+
+// FeeRule defines the solver fee for orders matching all of its filters.
+// Empty (zero) filters match any order.
+type FeeRule struct {
+	Name        string  `toml:"name"`
+	Deposit     string  `toml:"deposit"`       // Deposit asset symbol
+	Expense     string  `toml:"expense"`       // Expense asset symbol
+	SrcChainID  uint64  `toml:"src-chain-id"`  // Source chain ID
+	DestChainID uint64  `toml:"dest-chain-id"` // Destination chain ID
+	MinUSD      float64 `toml:"min-usd"`       // Inclusive lower bound of order size band in USD
+	MaxUSD      float64 `toml:"max-usd"`       // Exclusive upper bound of order size band in USD, zero is unbounded
+	Bips        int64   `toml:"bips"`
+}
+
+// hasBand returns true if the rule filters by order size.
+func (r FeeRule) hasBand() bool {
+	return r.MinUSD > 0 || r.MaxUSD > 0
+}
+
+// inBand returns true if the order size in USD is within the rule's size band.
+func (r FeeRule) inBand(usd float64) bool {
+	return usd >= r.MinUSD && (r.MaxUSD == 0 || usd < r.MaxUSD)
+}
+
+// matchesPair returns true if the rule's asset and chain filters match the deposit/expense pair.
+func (r FeeRule) matchesPair(deposit, expense tokens.Token) bool {
+	if r.Deposit != "" && !strings.EqualFold(r.Deposit, deposit.Symbol) {
+		return false
+	}
+
+	if r.Expense != "" && !strings.EqualFold(r.Expense, expense.Symbol) {
+		return false
+	}
+
+	if r.SrcChainID != 0 && r.SrcChainID != deposit.ChainID {
+		return false
+	}
+
+	if r.DestChainID != 0 && r.DestChainID != expense.ChainID {
+		return false
+	}
+
+	return true
+}
+
+func (r FeeRule) Validate() error {
+	if r.Bips < 0 || r.Bips >= 10_000 {
+		return errors.New("invalid fee bips", "rule", r.Name, "bips", r.Bips)
+	}
+
+	if r.MinUSD < 0 || r.MaxUSD < 0 {
+		return errors.New("negative size band", "rule", r.Name)
+	}
+
+	if r.MaxUSD != 0 && r.MaxUSD <= r.MinUSD {
+		return errors.New("max-usd must exceed min-usd", "rule", r.Name)
+	}
+
+	for _, symbol := range []string{r.Deposit, r.Expense} {
+		if symbol == "" {
+			continue
+		}
+
+		if _, err := tokens.AssetBySymbol(strings.ToUpper(symbol)); err != nil {
+			return errors.Wrap(err, "unknown asset", "rule", r.Name)
+		}
+	}
+
+	return nil
+}
+
+// FeeSchedule defines the fees charged by the solver.
+// Rules are evaluated in order, the first matching rule applies.
+// Orders not matching any rule are charged DefaultBips.
+type FeeSchedule struct {
+	DefaultBips int64     `toml:"default-bips"`
+	Rules       []FeeRule `toml:"rules"`
+}
+
+func (s FeeSchedule) Validate() error {
+	if s.DefaultBips < 0 || s.DefaultBips >= 10_000 {
+		return errors.New("invalid default fee bips", "bips", s.DefaultBips)
+	}
+
+	for _, rule := range s.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DefaultFeeSchedule returns the default fee schedule, used if no fee schedule file is configured.
+func DefaultFeeSchedule() FeeSchedule {
+	return FeeSchedule{
+		DefaultBips: standardFeeBips,
+		Rules: []FeeRule{
+			{
+				// OMNI<>OMNI is charged no fee
+				Name:    "omni",
+				Deposit: tokens.OMNI.Symbol,
+				Expense: tokens.OMNI.Symbol,
+				Bips:    0,
+			},
+		},
+	}
+}
+
+// LoadFeeSchedule loads and validates a TOML fee schedule file.
+func LoadFeeSchedule(path string) (FeeSchedule, error) {
+	var resp FeeSchedule
+	if _, err := toml.DecodeFile(path, &resp); err != nil {
+		return FeeSchedule{}, errors.Wrap(err, "decode fee schedule", "path", path)
+	}
+
+	if err := resp.Validate(); err != nil {
+		return FeeSchedule{}, errors.Wrap(err, "validate fee schedule", "path", path)
+	}
+
+	return resp, nil
+}
+
+// feeStore holds the active (hot-reloadable) fee schedule.
+type feeStore struct {
+	mu       sync.RWMutex
+	schedule FeeSchedule
+}
+
+func newFeeStore(schedule FeeSchedule) *feeStore {
+	return &feeStore{schedule: schedule}
+}
+
+func (s *feeStore) Get() FeeSchedule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.schedule
+}
+
+func (s *feeStore) Set(schedule FeeSchedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.schedule = schedule
+}
+
+// newFeeStoreFromFile returns a fee store loaded from the optional fee schedule file.
+// The default fee schedule is used if path is empty.
+func newFeeStoreFromFile(path string) (*feeStore, error) {
+	if path == "" {
+		return newFeeStore(DefaultFeeSchedule()), nil
+	}
+
+	schedule, err := LoadFeeSchedule(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFeeStore(schedule), nil
+}
+
+// feeFunc returns the fee schedule rule applied when paying for `expense` with `deposit` tokens.
+type feeFunc func(ctx context.Context, deposit tokens.Token, expense TokenAmt) (FeeRule, error)
+
+// newFeeFunc returns a feeFunc using the active fee schedule from `store`.
+// The pricer is used to value expenses in USD for order size bands.
+func newFeeFunc(store *feeStore, pricer tokenpricer.Pricer) feeFunc {
+	return func(ctx context.Context, deposit tokens.Token, expense TokenAmt) (FeeRule, error) {
+		schedule := store.Get()
+
+		var usd *float64 // Lazily populated, only if a matching rule has a size band.
+		for _, rule := range schedule.Rules {
+			if !rule.matchesPair(deposit, expense.Token) {
+				continue
+			}
+
+			if rule.hasBand() {
+				if usd == nil {
+					v, err := usdValue(ctx, pricer, expense)
+					if err != nil {
+						return FeeRule{}, errors.Wrap(err, "expense usd value")
+					}
+					usd = &v
+				}
+
+				if !rule.inBand(*usd) {
+					continue
+				}
+			}
+
+			return rule, nil
+		}
+
+		return FeeRule{Name: defaultFeeRule, Bips: schedule.DefaultBips}, nil
+	}
+}
+
+// usdValue returns the value of the token amount in USD.
+func usdValue(ctx context.Context, pricer tokenpricer.Pricer, amt TokenAmt) (float64, error) {
+	if amt.Amount == nil || bi.IsZero(amt.Amount) {
+		return 0, nil
+	}
+
+	units := bi.ToF64(amt.Amount, amt.Token.Decimals)
+	if amt.Token.Asset == tokens.USDC {
+		return units, nil
+	}
+
+	price, err := pricer.Price(ctx, amt.Token.Asset, tokens.USDC)
+	if err != nil {
+		return 0, err
+	}
+
+	priceF64, _ := price.Float64()
+
+	return units * priceF64, nil
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testFeeFunc returns a feeFunc using the default fee schedule.
+func testFeeFunc() feeFunc {
+	return newFeeFunc(newFeeStore(DefaultFeeSchedule()), tokenpricer.NewDevnetMock())
+}
+
+func TestFeeFunc(t *testing.T) {
+	t.Parallel()
+
+	schedule := FeeSchedule{
+		DefaultBips: 30,
+		Rules: []FeeRule{
+			{Name: "omni", Deposit: "OMNI", Expense: "OMNI", Bips: 0},
+			{Name: "to-arb", Expense: "ETH", DestChainID: evmchain.IDArbitrumOne, Bips: 10},
+			{Name: "large-usdc", Deposit: "USDC", Expense: "USDC", MinUSD: 10_000, Bips: 5},
+			{Name: "small-usdc", Deposit: "USDC", Expense: "USDC", MaxUSD: 100, Bips: 50},
+		},
+	}
+	require.NoError(t, schedule.Validate())
+
+	feeFunc := newFeeFunc(newFeeStore(schedule), tokenpricer.NewDevnetMock())
+
+	baseETH := mustNative(t, evmchain.IDBase)
+	arbETH := mustNative(t, evmchain.IDArbitrumOne)
+	opETH := mustNative(t, evmchain.IDOptimism)
+	baseUSDC := erc20(evmchain.IDBase, tokens.USDC)
+	arbUSDC := erc20(evmchain.IDArbitrumOne, tokens.USDC)
+	mainnetOMNI := erc20(evmchain.IDEthereum, tokens.OMNI)
+	omniOMNI := mustNative(t, evmchain.IDOmniMainnet)
+
+	tests := []struct {
+		name    string
+		deposit tokens.Token
+		expense TokenAmt
+		rule    string
+		bips    int64
+	}{
+		{"omni pair", mainnetOMNI, TokenAmt{Token: omniOMNI, Amount: bi.Ether(1)}, "omni", 0},
+		{"route", baseETH, TokenAmt{Token: arbETH, Amount: bi.Ether(1)}, "to-arb", 10},
+		{"other route", baseETH, TokenAmt{Token: opETH, Amount: bi.Ether(1)}, defaultFeeRule, 30},
+		{"large band", baseUSDC, TokenAmt{Token: arbUSDC, Amount: bi.Dec6(10_000)}, "large-usdc", 5},
+		{"small band", baseUSDC, TokenAmt{Token: arbUSDC, Amount: bi.Dec6(99)}, "small-usdc", 50},
+		{"middle band", baseUSDC, TokenAmt{Token: arbUSDC, Amount: bi.Dec6(1000)}, defaultFeeRule, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := feeFunc(t.Context(), tt.deposit, tt.expense)
+			require.NoError(t, err)
+			require.Equal(t, tt.rule, rule.Name)
+			require.Equal(t, tt.bips, rule.Bips)
+		})
+	}
+}
+
+func TestFeeScheduleValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, DefaultFeeSchedule().Validate())
+
+	invalid := []FeeSchedule{
+		{DefaultBips: -1},
+		{DefaultBips: 10_000},
+		{Rules: []FeeRule{{Bips: -1}}},
+		{Rules: []FeeRule{{MinUSD: 100, MaxUSD: 10}}},
+		{Rules: []FeeRule{{MinUSD: -1}}},
+		{Rules: []FeeRule{{Deposit: "UNKNOWN"}}},
+	}
+	for _, schedule := range invalid {
+		require.Error(t, schedule.Validate(), "%+v", schedule)
+	}
+}
+
+func TestReloadFees(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "fees.toml")
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	write(`
+default-bips = 20
+
+[[rules]]
+name = "eth"
+deposit = "ETH"
+expense = "ETH"
+bips = 15
+`)
+
+	store, err := newFeeStoreFromFile(path)
+	require.NoError(t, err)
+	require.EqualValues(t, 20, store.Get().DefaultBips)
+	require.Len(t, store.Get().Rules, 1)
+	require.EqualValues(t, 15, store.Get().Rules[0].Bips)
+
+	// Invalid schedules are ignored
+	write(`default-bips = 20000`)
+	reloadFees(t.Context(), path, store)
+	require.EqualValues(t, 20, store.Get().DefaultBips)
+	require.Len(t, store.Get().Rules, 1)
+
+	// Valid schedules are reloaded
+	write(`default-bips = 25`)
+	reloadFees(t.Context(), path, store)
+	require.EqualValues(t, 25, store.Get().DefaultBips)
+	require.Empty(t, store.Get().Rules)
+}
@@ -0,0 +1,359 @@
+package app
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/contracts/bindings"
+	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/tracer"
+	"github.com/omni-network/omni/lib/unibackend"
+	"github.com/omni-network/omni/solver/job"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"google.golang.org/protobuf/proto"
+
+	"cosmossdk.io/orm/types/ormerrors"
+	db "github.com/cosmos/cosmos-db"
+)
+
+// fillTrackInterval is the interval at which tracked fills are checked against the destination chain finalized head.
+const fillTrackInterval = 30 * time.Second
+
+// reprocessEventIndex is the event index of synthetic jobs re-processing pending orders whose fill was orphaned.
+const reprocessEventIndex = math.MaxUint64
+
+// didFillAtFunc returns true if the pending order was filled at the destination chain height (nil for latest).
+type didFillAtFunc func(ctx context.Context, order Order, height *big.Int) (bool, error)
+
+// finalizedFunc returns the finalized head height of the chain.
+type finalizedFunc func(ctx context.Context, chainID uint64) (uint64, error)
+
+// reprocessFunc re-processes the pending order event.
+type reprocessFunc func(ctx context.Context, order Order, e Event) error
+
+// newDidFillerAt returns a didFillAtFunc querying the destination chain outbox contracts.
+// Like DidFill, it returns false/nil for invalid orders.
+func newDidFillerAt(outboxContracts map[uint64]*bindings.SolverNetOutbox) didFillAtFunc {
+	return func(ctx context.Context, order Order, height *big.Int) (bool, error) {
+		ctx, span := tracer.Start(ctx, "proc/did_fill")
+		defer span.End()
+
+		pendingData, err := order.PendingData()
+		if err != nil {
+			return false, nil
+		}
+
+		outbox, ok := outboxContracts[pendingData.DestinationChainID]
+		if !ok {
+			return false, nil
+		}
+
+		filled, err := outbox.DidFill(&bind.CallOpts{Context: ctx, BlockNumber: height}, order.ID, pendingData.FillOriginData)
+		if err != nil {
+			return false, errors.Wrap(err, "did fill")
+		}
+
+		return filled, nil
+	}
+}
+
+// newFinalizer returns a finalizedFunc querying EVM backends.
+func newFinalizer(backends unibackend.Backends) finalizedFunc {
+	return func(ctx context.Context, chainID uint64) (uint64, error) {
+		backend, err := backends.Backend(chainID)
+		if err != nil {
+			return 0, err
+		} else if !backend.IsEVM() {
+			return 0, errors.New("finalized only supports eth backend")
+		}
+
+		header, err := backend.EVMBackend().HeaderByType(ctx, ethclient.HeadFinalized)
+		if err != nil {
+			return 0, errors.Wrap(err, "finalized header")
+		}
+
+		return header.Number.Uint64(), nil
+	}
+}
+
+// newJobReprocessor returns a reprocessFunc that inserts and starts a synthetic pending job for the order.
+// The job's tx includes the order ID, since a single tx can open multiple orders.
+func newJobReprocessor(jobDB *job.DB, asyncWork asyncWorkFunc) reprocessFunc {
+	return func(ctx context.Context, order Order, e Event) error {
+		j, err := jobDB.Insert(ctx, order.SourceChainID, e.Height, reprocessTx(e.Tx, order.ID), reprocessEventIndex, order.ID[:], uint64(solvernet.StatusPending))
+		if err != nil {
+			return errors.Wrap(err, "insert reprocess job")
+		}
+
+		return asyncWork(ctx, j)
+	}
+}
+
+// reprocessTx returns the tx of a synthetic re-process job of the order opened by the tx.
+func reprocessTx(tx string, id OrderID) string {
+	return tx + "/" + id.String()
+}
+
+type trackedFill struct {
+	Order Order
+	Event Event
+}
+
+func newFillStore(db db.DB) (*fillStore, error) {
+	dbStore, err := newSolverStore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fillStore{
+		table: dbStore.TrackedFillTable(),
+	}, nil
+}
+
+// fillStore provides a thread-safe persisted store of fills tracked until finalized.
+type fillStore struct {
+	mu    sync.Mutex
+	table TrackedFillTable
+}
+
+// Save adds or replaces the order's tracked fill.
+func (f *fillStore) Save(ctx context.Context, order Order, e Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := f.table.Save(ctx, &TrackedFill{
+		OrderId:     order.ID[:],
+		SrcChainId:  order.SourceChainID,
+		EventHeight: e.Height,
+		EventTx:     e.Tx,
+	})
+	if err != nil {
+		return errors.Wrap(err, "save tracked fill")
+	}
+
+	return nil
+}
+
+// List returns all tracked fills.
+func (f *fillStore) List(ctx context.Context) ([]*TrackedFill, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	iter, err := f.table.List(ctx, TrackedFillPrimaryKey{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list tracked fills")
+	}
+	defer iter.Close()
+
+	var resp []*TrackedFill
+	for iter.Next() {
+		fill, err := iter.Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "get value")
+		}
+
+		resp = append(resp, proto.Clone(fill).(*TrackedFill)) //nolint:forcetypeassert // Type known
+	}
+
+	return resp, nil
+}
+
+// Delete deletes the order's tracked fill, if any.
+func (f *fillStore) Delete(ctx context.Context, id OrderID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := f.table.Delete(ctx, &TrackedFill{OrderId: id[:]})
+	if err != nil && !ormerrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete tracked fill")
+	}
+
+	return nil
+}
+
+// fillTracker follows filled orders until the fill is finalized on the destination chain.
+// Fills that disappear (e.g. due to reorgs) before finalization are re-processed.
+// Tracked fills are persisted, so tracking resumes after restarts.
+type fillTracker struct {
+	store     *fillStore
+	didFillAt didFillAtFunc
+	finalized finalizedFunc
+
+	mu    sync.Mutex
+	fills map[OrderID]trackedFill
+}
+
+func newFillTracker(store *fillStore, didFillAt didFillAtFunc, finalized finalizedFunc) *fillTracker {
+	return &fillTracker{
+		store:     store,
+		didFillAt: didFillAt,
+		finalized: finalized,
+		fills:     make(map[OrderID]trackedFill),
+	}
+}
+
+// Load resumes tracking persisted fills, fetching their orders.
+// Persisted fills of orders no longer pending are dropped, since the source chain already marked them filled.
+func (t *fillTracker) Load(ctx context.Context, getOrder func(ctx context.Context, chainID uint64, id OrderID) (Order, bool, error)) error {
+	persisted, err := t.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, fill := range persisted {
+		id := OrderID(fill.GetOrderId())
+		order, found, err := getOrder(ctx, fill.GetSrcChainId(), id)
+		if err != nil {
+			return errors.Wrap(err, "get order", "order_id", id)
+		} else if !found || order.Status != solvernet.StatusPending {
+			log.Debug(ctx, "Dropping tracked fill of order no longer pending", "order_id", id, "found", found, "status", order.Status)
+			t.untrack(ctx, Order{ID: id})
+
+			continue
+		}
+
+		t.Track(ctx, order, Event{
+			OrderID: id,
+			Status:  solvernet.StatusPending,
+			Height:  fill.GetEventHeight(),
+			Tx:      fill.GetEventTx(),
+		})
+	}
+
+	return nil
+}
+
+// Track starts following the filled pending order until its fill is finalized.
+// Persisting is best-effort, since the fill is still tracked in memory.
+func (t *fillTracker) Track(ctx context.Context, order Order, e Event) {
+	pendingData, err := order.PendingData()
+	if err != nil {
+		return
+	}
+
+	if err := t.store.Save(ctx, order, e); err != nil {
+		log.Warn(ctx, "Failed to persist tracked fill", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.fills[order.ID] = trackedFill{Order: order, Event: e}
+	trackedFills.WithLabelValues(evmchain.Name(pendingData.DestinationChainID)).Set(float64(t.countUnsafe(pendingData.DestinationChainID)))
+}
+
+func (t *fillTracker) untrack(ctx context.Context, order Order) {
+	if err := t.store.Delete(ctx, order.ID); err != nil {
+		log.Warn(ctx, "Failed to delete tracked fill", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.fills, order.ID)
+
+	pendingData, err := order.PendingData()
+	if err != nil {
+		return
+	}
+	trackedFills.WithLabelValues(evmchain.Name(pendingData.DestinationChainID)).Set(float64(t.countUnsafe(pendingData.DestinationChainID)))
+}
+
+func (t *fillTracker) countUnsafe(destChainID uint64) int {
+	var resp int
+	for _, fill := range t.fills {
+		if fill.Order.pendingData.DestinationChainID == destChainID {
+			resp++
+		}
+	}
+
+	return resp
+}
+
+func (t *fillTracker) tracked() []trackedFill {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	resp := make([]trackedFill, 0, len(t.fills))
+	for _, fill := range t.fills {
+		resp = append(resp, fill)
+	}
+
+	return resp
+}
+
+// TrackForever blocks and periodically checks tracked fills, re-processing orphaned fills.
+func (t *fillTracker) TrackForever(ctx context.Context, interval time.Duration, reprocess reprocessFunc) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.checkOnce(ctx, reprocess)
+		}
+	}
+}
+
+// checkOnce checks all tracked fills once.
+// Fills included in the finalized head are confirmed and no longer tracked.
+// Fills no longer present in the latest head are orphaned and re-processed.
+func (t *fillTracker) checkOnce(ctx context.Context, reprocess reprocessFunc) {
+	finalized := make(map[uint64]*big.Int) // Finalized height by destination chain, per check.
+
+	for _, fill := range t.tracked() {
+		order := fill.Order
+		destChainID := order.pendingData.DestinationChainID
+		ctx := log.WithCtx(ctx, "order_id", order.ID.String(), "dst_chain", evmchain.Name(destChainID))
+
+		height, ok := finalized[destChainID]
+		if !ok {
+			h, err := t.finalized(ctx, destChainID)
+			if err != nil {
+				log.Warn(ctx, "Failed fetching finalized head (will retry)", err)
+				continue
+			}
+			height = new(big.Int).SetUint64(h)
+			finalized[destChainID] = height
+		}
+
+		if filled, err := t.didFillAt(ctx, order, height); err != nil {
+			log.Warn(ctx, "Failed checking finalized fill (will retry)", err)
+			continue
+		} else if filled {
+			log.Debug(ctx, "Fill finalized")
+			finalizedFills.WithLabelValues(evmchain.Name(order.SourceChainID), evmchain.Name(destChainID)).Inc()
+			t.untrack(ctx, order)
+
+			continue
+		}
+
+		if filled, err := t.didFillAt(ctx, order, nil); err != nil {
+			log.Warn(ctx, "Failed checking latest fill (will retry)", err)
+			continue
+		} else if filled {
+			continue // Not finalized yet
+		}
+
+		log.Warn(ctx, "Orphaned fill detected, re-processing order", nil)
+		orphanedFills.WithLabelValues(evmchain.Name(order.SourceChainID), evmchain.Name(destChainID)).Inc()
+
+		if err := reprocess(ctx, order, fill.Event); err != nil {
+			log.Warn(ctx, "Failed re-processing orphaned fill (will retry)", err)
+			continue
+		}
+
+		t.untrack(ctx, order)
+	}
+}
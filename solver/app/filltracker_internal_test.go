@@ -0,0 +1,157 @@
+package app
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tutil"
+	"github.com/omni-network/omni/solver/job"
+
+	db "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFillTracker(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	const finalizedHeight = 100
+
+	// filledAt is the destination height each order was filled at, or absent if not (or no longer) filled.
+	filledAt := make(map[OrderID]uint64)
+	latest := uint64(110)
+
+	didFillAt := func(_ context.Context, order Order, height *big.Int) (bool, error) {
+		at, ok := filledAt[order.ID]
+		if !ok {
+			return false, nil
+		}
+		if height == nil {
+			return at <= latest, nil
+		}
+
+		return at <= height.Uint64(), nil
+	}
+	finalized := func(_ context.Context, chainID uint64) (uint64, error) {
+		require.Equal(t, evmchain.IDBase, chainID)
+		return finalizedHeight, nil
+	}
+
+	var reprocessed []OrderID
+	reprocess := func(_ context.Context, order Order, e Event) error {
+		require.Equal(t, order.ID, e.OrderID)
+		reprocessed = append(reprocessed, order.ID)
+
+		return nil
+	}
+
+	newPending := func() (Order, Event) {
+		id := OrderID(tutil.RandomHash())
+		order := Order{
+			ID:            id,
+			SourceChainID: evmchain.IDArbitrumOne,
+			Status:        solvernet.StatusPending,
+			pendingData:   PendingData{DestinationChainID: evmchain.IDBase},
+		}
+
+		return order, Event{OrderID: id, Status: solvernet.StatusPending, Height: 1, Tx: "0x1"}
+	}
+
+	memDB := db.NewMemDB()
+	newTracker := func() *fillTracker {
+		t.Helper()
+		store, err := newFillStore(memDB)
+		require.NoError(t, err)
+
+		return newFillTracker(store, didFillAt, finalized)
+	}
+
+	tracker := newTracker()
+
+	finalOrder, finalEvent := newPending()
+	filledAt[finalOrder.ID] = 90
+	tracker.Track(ctx, finalOrder, finalEvent)
+
+	recentOrder, recentEvent := newPending()
+	filledAt[recentOrder.ID] = 105
+	tracker.Track(ctx, recentOrder, recentEvent)
+
+	orphanOrder, orphanEvent := newPending()
+	filledAt[orphanOrder.ID] = 108
+	tracker.Track(ctx, orphanOrder, orphanEvent)
+
+	// Non-pending orders are not tracked
+	tracker.Track(ctx, Order{ID: OrderID(tutil.RandomHash()), Status: solvernet.StatusFilled}, Event{})
+	require.Len(t, tracker.tracked(), 3)
+
+	// Orphan the fill (reorg)
+	delete(filledAt, orphanOrder.ID)
+
+	tracker.checkOnce(ctx, reprocess)
+	require.Equal(t, []OrderID{orphanOrder.ID}, reprocessed)
+
+	// Only the non-finalized fill remains tracked
+	tracked := tracker.tracked()
+	require.Len(t, tracked, 1)
+	require.Equal(t, recentOrder.ID, tracked[0].Order.ID)
+
+	// Tracked fills are reloaded after restarts, dropping orders no longer pending
+	droppedOrder, droppedEvent := newPending()
+	tracker.Track(ctx, droppedOrder, droppedEvent)
+
+	tracker = newTracker()
+	require.NoError(t, tracker.Load(ctx, func(_ context.Context, chainID uint64, id OrderID) (Order, bool, error) {
+		require.Equal(t, evmchain.IDArbitrumOne, chainID)
+		if id == recentOrder.ID {
+			return recentOrder, true, nil
+		}
+
+		return Order{ID: id, Status: solvernet.StatusFilled}, true, nil
+	}))
+
+	tracked = tracker.tracked()
+	require.Len(t, tracked, 1)
+	require.Equal(t, recentOrder.ID, tracked[0].Order.ID)
+	require.Equal(t, recentEvent, tracked[0].Event)
+
+	persisted, err := tracker.store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, persisted, 1)
+
+	// Recent fill is orphaned later
+	delete(filledAt, recentOrder.ID)
+	tracker.checkOnce(ctx, reprocess)
+	require.Equal(t, []OrderID{orphanOrder.ID, recentOrder.ID}, reprocessed)
+	require.Empty(t, tracker.tracked())
+
+	persisted, err = tracker.store.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, persisted)
+}
+
+func TestJobReprocessor(t *testing.T) {
+	t.Parallel()
+
+	jobDB, err := job.New(db.NewMemDB())
+	require.NoError(t, err)
+
+	var jobs []*job.Job
+	reprocess := newJobReprocessor(jobDB, func(_ context.Context, j *job.Job) error {
+		jobs = append(jobs, j)
+		return nil
+	})
+
+	// Orders opened by the same tx have distinct re-process jobs
+	for range 2 {
+		id := OrderID(tutil.RandomHash())
+		order := Order{ID: id, SourceChainID: evmchain.IDArbitrumOne}
+		require.NoError(t, reprocess(t.Context(), order, Event{OrderID: id, Status: solvernet.StatusPending, Height: 1, Tx: "0x1"}))
+	}
+
+	require.Len(t, jobs, 2)
+	require.NotEqual(t, jobs[0].GetId(), jobs[1].GetId())
+	require.NotEqual(t, jobs[0].GetOrderId(), jobs[1].GetOrderId())
+}
@@ -0,0 +1,278 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/umath"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"cosmossdk.io/orm/types/ormerrors"
+	db "github.com/cosmos/cosmos-db"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultFirmQuoteTTL is the default duration firm quotes are honoured for.
+const defaultFirmQuoteTTL = time.Minute
+
+// firmQuotePruneInterval is the interval at which expired firm quotes are deleted.
+const firmQuotePruneInterval = 5 * time.Minute
+
+// firmQuoteFunc issues a signed firm quote locking the given terms for the order.
+type firmQuoteFunc func(ctx context.Context, orderID OrderID, srcChainID, destChainID uint64, deposits, expenses []TokenAmt) (types.FirmQuote, error)
+
+// isFirmFunc returns true if the order references a valid firm quote for the given terms;
+// i.e., one bound to the order, not yet used, and not expired when the order was opened.
+type isFirmFunc func(ctx context.Context, orderID OrderID, openedAt time.Time, srcChainID, destChainID uint64, deposits, expenses []TokenAmt) (bool, error)
+
+// newFirmQuoter returns a firmQuoteFunc that signs firm quotes with the solver key
+// and persists them in the store, to be honoured until expiry.
+func newFirmQuoter(store *firmQuotes, solverKey *ecdsa.PrivateKey, ttl time.Duration) firmQuoteFunc {
+	return func(ctx context.Context, orderID OrderID, srcChainID, destChainID uint64, deposits, expenses []TokenAmt) (types.FirmQuote, error) {
+		expiry := time.Now().Add(ttl).Truncate(time.Second)
+		expiryUnix, err := umath.ToUint64(expiry.Unix())
+		if err != nil {
+			return types.FirmQuote{}, err
+		}
+
+		resp := types.FirmQuote{
+			ID:      firmQuoteID(orderID, srcChainID, destChainID, deposits, expenses),
+			OrderID: common.Hash(orderID),
+			Expiry:  expiryUnix,
+			Solver:  crypto.PubkeyToAddress(solverKey.PublicKey),
+		}
+
+		digest := resp.Digest()
+		resp.Signature, err = crypto.Sign(digest[:], solverKey)
+		if err != nil {
+			return types.FirmQuote{}, errors.Wrap(err, "sign firm quote")
+		}
+
+		if err := store.Save(ctx, resp.ID, orderID, expiry, resp.Signature); err != nil {
+			return types.FirmQuote{}, err
+		}
+
+		return resp, nil
+	}
+}
+
+// newIsFirm returns an isFirmFunc backed by the firm quote store.
+func newIsFirm(store *firmQuotes) isFirmFunc {
+	return func(ctx context.Context, orderID OrderID, openedAt time.Time, srcChainID, destChainID uint64, deposits, expenses []TokenAmt) (bool, error) {
+		if orderID == (OrderID{}) {
+			return false, nil
+		}
+
+		return store.IsValid(ctx, firmQuoteID(orderID, srcChainID, destChainID, deposits, expenses), openedAt)
+	}
+}
+
+// firmQuoteID returns the deterministic ID of a firm quote for the given order and terms.
+// Legs are sorted, so the ID is independent of leg order; e.g. the order in which
+// an order's deposits and expenses are parsed.
+func firmQuoteID(orderID OrderID, srcChainID, destChainID uint64, deposits, expenses []TokenAmt) common.Hash {
+	var bz []byte
+	bz = append(bz, orderID[:]...)
+	bz = binary.BigEndian.AppendUint64(bz, srcChainID)
+	bz = binary.BigEndian.AppendUint64(bz, destChainID)
+
+	for _, legs := range [][]TokenAmt{deposits, expenses} {
+		bz = binary.BigEndian.AppendUint64(bz, umath.Len(legs))
+		for _, leg := range sortLegs(legs) {
+			amount := leg.Amount
+			if amount == nil {
+				amount = bi.Zero()
+			}
+
+			bz = binary.BigEndian.AppendUint64(bz, leg.Token.ChainID)
+			bz = append(bz, leg.Token.Address.Bytes()...)
+			bz = append(bz, leg.Token.SVMAddress.Bytes()...)
+			bz = append(bz, common.BigToHash(amount).Bytes()...)
+		}
+	}
+
+	return crypto.Keccak256Hash(bz)
+}
+
+// sortLegs returns a copy of the legs sorted by token.
+func sortLegs(legs []TokenAmt) []TokenAmt {
+	resp := slices.Clone(legs)
+	slices.SortFunc(resp, func(a, b TokenAmt) int {
+		if a.Token.ChainID != b.Token.ChainID {
+			if a.Token.ChainID < b.Token.ChainID {
+				return -1
+			}
+
+			return 1
+		}
+
+		if c := bytes.Compare(a.Token.Address.Bytes(), b.Token.Address.Bytes()); c != 0 {
+			return c
+		}
+
+		return bytes.Compare(a.Token.SVMAddress.Bytes(), b.Token.SVMAddress.Bytes())
+	})
+
+	return resp
+}
+
+func newFirmQuotes(db db.DB) (*firmQuotes, error) {
+	dbStore, err := newSolverStore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &firmQuotes{
+		table: dbStore.FirmQuoteTable(),
+	}, nil
+}
+
+// firmQuotes provides a thread-safe persisted firm quote store.
+type firmQuotes struct {
+	mu    sync.Mutex
+	table FirmQuoteTable
+}
+
+// Save stores the firm quote, replacing any existing quote with the same ID (order and terms).
+func (q *firmQuotes) Save(ctx context.Context, id common.Hash, orderID OrderID, expiry time.Time, sig []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if existing, err := q.table.Get(ctx, id.Bytes()); err == nil && existing.GetUsed() {
+		return errors.New("firm quote already used")
+	}
+
+	err := q.table.Save(ctx, &FirmQuote{
+		Id:        id.Bytes(),
+		Expiry:    timestamppb.New(expiry),
+		Signature: sig,
+		OrderId:   orderID[:],
+	})
+	if err != nil {
+		return errors.Wrap(err, "save firm quote")
+	}
+
+	return nil
+}
+
+// IsValid returns true if a firm quote with the given ID exists, is not used and had not expired at `openedAt`.
+func (q *firmQuotes) IsValid(ctx context.Context, id common.Hash, openedAt time.Time) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	quote, err := q.table.Get(ctx, id.Bytes())
+	if ormerrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "get firm quote")
+	}
+
+	return !quote.GetUsed() && openedAt.Before(quote.GetExpiry().AsTime()), nil
+}
+
+// Use marks all firm quotes bound to the order as used, so they are not honoured again.
+func (q *firmQuotes) Use(ctx context.Context, orderID OrderID) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	iter, err := q.table.List(ctx, FirmQuoteOrderIdIndexKey{}.WithOrderId(orderID[:]))
+	if err != nil {
+		return errors.Wrap(err, "list firm quotes")
+	}
+	defer iter.Close()
+
+	var quotes []*FirmQuote
+	for iter.Next() {
+		quote, err := iter.Value()
+		if err != nil {
+			return errors.Wrap(err, "firm quote value")
+		}
+		quotes = append(quotes, quote)
+	}
+
+	for _, quote := range quotes {
+		quote.Used = true
+		if err := q.table.Update(ctx, quote); err != nil {
+			return errors.Wrap(err, "update firm quote")
+		}
+	}
+
+	return nil
+}
+
+// withFirmQuoteFill returns a fill function that uses firm quotes bound to successfully filled orders.
+func withFirmQuoteFill(store *firmQuotes, fill func(ctx context.Context, order Order) error) func(ctx context.Context, order Order) error {
+	return func(ctx context.Context, order Order) error {
+		if err := fill(ctx, order); err != nil {
+			return err
+		}
+
+		useFirmQuote(ctx, store, order.ID)
+
+		return nil
+	}
+}
+
+// withFirmQuoteReject returns a reject function that uses firm quotes bound to successfully rejected orders.
+func withFirmQuoteReject(store *firmQuotes, reject func(ctx context.Context, order Order, reason types.RejectReason) error) func(ctx context.Context, order Order, reason types.RejectReason) error {
+	return func(ctx context.Context, order Order, reason types.RejectReason) error {
+		if err := reject(ctx, order, reason); err != nil {
+			return err
+		}
+
+		useFirmQuote(ctx, store, order.ID)
+
+		return nil
+	}
+}
+
+// useFirmQuote marks firm quotes bound to the order as used (best-effort, since the order is already processed).
+func useFirmQuote(ctx context.Context, store *firmQuotes, orderID OrderID) {
+	if err := store.Use(ctx, orderID); err != nil {
+		log.Warn(ctx, "Failed marking firm quote used", err)
+	}
+}
+
+// Prune deletes all firm quotes that expired before `now`.
+func (q *firmQuotes) Prune(ctx context.Context, now time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	err := q.table.DeleteRange(ctx,
+		FirmQuoteExpiryIndexKey{},
+		FirmQuoteExpiryIndexKey{}.WithExpiry(timestamppb.New(now)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "delete expired firm quotes")
+	}
+
+	return nil
+}
+
+// pruneFirmQuotesForever blocks and periodically deletes expired firm quotes.
+func pruneFirmQuotesForever(ctx context.Context, store *firmQuotes) {
+	ticker := time.NewTicker(firmQuotePruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := store.Prune(ctx, time.Now()); err != nil {
+				log.Warn(ctx, "Failed to prune firm quotes (will retry)", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	db "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+)
+
+// notFirm is an isFirmFunc that never matches a firm quote.
+func notFirm(context.Context, OrderID, time.Time, uint64, uint64, []TokenAmt, []TokenAmt) (bool, error) {
+	return false, nil
+}
+
+// notOpened is an openedAtFunc that never knows when orders were opened.
+func notOpened(OrderID) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func TestFirmQuote(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store, err := newFirmQuotes(db.NewMemDB())
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	priceFunc := newPriceFunc(tokenpricer.NewDevnetMock())
+	quoter := newQuoter(priceFunc, testFeeFunc(), anyLiquidity, newFirmQuoter(store, key, time.Minute), notPaused)
+	isFirm := newIsFirm(store)
+
+	baseETH := mustNative(t, evmchain.IDBase)
+	arbETH := mustNative(t, evmchain.IDArbitrumOne)
+	arbUSDC := erc20(evmchain.IDArbitrumOne, tokens.USDC)
+	user := common.HexToAddress("0x1234")
+
+	// Indicative quotes are not firm
+	resp, err := quoter(ctx, types.QuoteRequest{
+		SourceChainID:      evmchain.IDBase,
+		DestinationChainID: evmchain.IDArbitrumOne,
+		Deposit:            types.AddrAmt{Token: baseETH.Address},
+		Expense:            types.AddrAmt{Token: arbETH.Address, Amount: bi.Ether(1)},
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Firm)
+
+	req := types.QuoteRequest{
+		SourceChainID:      evmchain.IDBase,
+		DestinationChainID: evmchain.IDArbitrumOne,
+		Deposit:            types.AddrAmt{Token: baseETH.Address},
+		Expenses: []types.AddrAmt{
+			{Token: arbETH.Address, Amount: bi.Ether(1)},
+			{Token: arbUSDC.Address, Amount: bi.Dec6(1000)},
+		},
+		Firm: true,
+	}
+
+	// Firm quotes are bound to a user's order
+	_, err = quoter(ctx, req)
+	require.ErrorContains(t, err, "firm quotes require user")
+
+	req.User = &user
+	req.Nonce = (*hexutil.Big)(bi.N(3))
+	resp, err = quoter(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Firm)
+
+	firm := *resp.Firm
+	orderID := solvernet.EVMOrderID(user, bi.N(3), false, evmchain.IDBase)
+	require.Equal(t, common.Hash(orderID), firm.OrderID)
+
+	digest := firm.Digest()
+	pubkey, err := crypto.SigToPub(digest[:], firm.Signature)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pubkey))
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), firm.Solver)
+	require.Greater(t, firm.Expiry, uint64(time.Now().Unix()))
+
+	deposits := []TokenAmt{{Token: baseETH, Amount: resp.Deposit.Amount}}
+	expenses := []TokenAmt{
+		{Token: arbUSDC, Amount: bi.Dec6(1000)},
+		{Token: arbETH, Amount: bi.Ether(1)},
+	}
+	isOrderFirm := func(id OrderID, openedAt time.Time, expenses []TokenAmt) bool {
+		t.Helper()
+		ok, err := isFirm(ctx, id, openedAt, evmchain.IDBase, evmchain.IDArbitrumOne, deposits, expenses)
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	// Order terms match the firm quote, irrespective of leg order
+	require.Equal(t, firm.ID, firmQuoteID(orderID, evmchain.IDBase, evmchain.IDArbitrumOne, deposits, expenses))
+	require.True(t, isOrderFirm(orderID, time.Now(), expenses))
+
+	// Different terms do not match
+	require.False(t, isOrderFirm(orderID, time.Now(), []TokenAmt{
+		{Token: arbUSDC, Amount: bi.Dec6(1001)},
+		{Token: arbETH, Amount: bi.Ether(1)},
+	}))
+
+	// Other orders (users or nonces) with the same terms do not match
+	require.False(t, isOrderFirm(solvernet.EVMOrderID(user, bi.N(4), false, evmchain.IDBase), time.Now(), expenses))
+	require.False(t, isOrderFirm(solvernet.EVMOrderID(common.HexToAddress("0x5678"), bi.N(3), false, evmchain.IDBase), time.Now(), expenses))
+	require.False(t, isOrderFirm(OrderID{}, time.Now(), expenses))
+
+	// Expiry is compared to the order's open time, not the processing time
+	require.True(t, isOrderFirm(orderID, time.Unix(int64(firm.Expiry)-1, 0), expenses))
+	require.False(t, isOrderFirm(orderID, time.Unix(int64(firm.Expiry), 0), expenses))
+
+	// Used quotes are not honoured again, nor re-issued
+	require.NoError(t, store.Use(ctx, orderID))
+	require.False(t, isOrderFirm(orderID, time.Now(), expenses))
+	_, err = quoter(ctx, req)
+	require.ErrorContains(t, err, "firm quote already used")
+
+	// Expired quotes are pruned
+	require.NoError(t, store.Prune(ctx, time.Now().Add(time.Hour)))
+	ok, err := store.table.Has(ctx, firm.ID.Bytes())
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestCheckFirmOrQuote(t *testing.T) {
+	t.Parallel()
+
+	priceFunc := newPriceFunc(tokenpricer.NewDevnetMock())
+	isFirm := func(context.Context, OrderID, time.Time, uint64, uint64, []TokenAmt, []TokenAmt) (bool, error) {
+		return true, nil
+	}
+
+	// Deposit no longer covers expense at the current price
+	deposits := []TokenAmt{{Token: mustNative(t, evmchain.IDBase), Amount: bi.Ether(1)}}
+	expenses := []TokenAmt{{Token: mustNative(t, evmchain.IDArbitrumOne), Amount: bi.Ether(1)}}
+
+	err := checkFirmOrQuote(t.Context(), priceFunc, testFeeFunc(), notFirm, OrderID{}, time.Now(), evmchain.IDBase, evmchain.IDArbitrumOne, deposits, expenses)
+	r := new(RejectionError)
+	require.ErrorAs(t, err, &r)
+	require.Equal(t, types.RejectInsufficientDeposit, r.Reason)
+
+	// Firm quotes honour the locked price
+	err = checkFirmOrQuote(t.Context(), priceFunc, testFeeFunc(), isFirm, OrderID{}, time.Now(), evmchain.IDBase, evmchain.IDArbitrumOne, deposits, expenses)
+	require.NoError(t, err)
+}
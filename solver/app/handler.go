@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -39,6 +40,17 @@ type Handler struct {
 	// The response must be a struct and optional error.
 	HandleFunc func(context.Context, any) (any, error)
 
+	// PathReq optionally returns the request parsed from the URL path (e.g. /orders/{id}), instead of
+	// unmarshalling the request body into ZeroReq.
+	PathReq func(*http.Request) (any, error)
+
+	// Authorize optionally authorizes the request before it is handled, see newTokenAuthorizer.
+	Authorize func(*http.Request) error
+
+	// Stream optionally serves long-lived responses (e.g. server-sent events) directly,
+	// instead of the JSON request and response handling above.
+	Stream http.Handler
+
 	// SkipInstrument skips the handler instrumentation.
 	SkipInstrument bool
 }
@@ -65,7 +77,7 @@ func newContractsHandler(addrs contracts.Addresses) Handler {
 // newCheckHandler returns a handler for the /check endpoint.
 // It is responsible for http request / response handling, and delegates
 // logic to a checkFunc.
-func newCheckHandler(checkFunc checkFunc, traceFunc traceFunc) Handler {
+func newCheckHandler(checkFunc checkFunc, traceFunc traceFunc, simulateFunc simulateFunc) Handler {
 	return Handler{
 		Endpoint: endpointCheck,
 		ZeroReq:  func() any { return &types.CheckRequest{} },
@@ -89,6 +101,22 @@ func newCheckHandler(checkFunc checkFunc, traceFunc traceFunc) Handler {
 				return trace.Map()
 			}
 
+			// Returns simulation result if simulate == true, else nil.
+			maybeSimulate := func() *types.Simulation {
+				if !req.Simulate {
+					return nil
+				} else if simulateFunc == nil {
+					return &types.Simulation{Error: "simulation disabled"}
+				}
+
+				sim, err := simulateFunc(ctx, *req)
+				if err != nil {
+					return &types.Simulation{Error: errors.Format(err)}
+				}
+
+				return &sim
+			}
+
 			err := checkFunc(ctx, *req)
 			if r := new(RejectionError); errors.As(err, &r) {
 				return types.CheckResponse{
@@ -97,14 +125,16 @@ func newCheckHandler(checkFunc checkFunc, traceFunc traceFunc) Handler {
 					RejectReason:      r.Reason.String(),
 					RejectDescription: errors.Format(r.Err),
 					Trace:             maybeTrace(),
+					Simulation:        maybeSimulate(),
 				}, nil
 			} else if err != nil {
 				return types.CheckResponse{}, err
 			}
 
 			return types.CheckResponse{
-				Accepted: true,
-				Trace:    maybeTrace(),
+				Accepted:   true,
+				Trace:      maybeTrace(),
+				Simulation: maybeSimulate(),
 			}, nil
 		},
 	}
@@ -129,6 +159,7 @@ func newQuoteHandler(quoteFunc quoteFunc) Handler {
 					// include quoted response, even if rejected (useful for min/max rejections)
 					Deposit:           res.Deposit,
 					Expense:           res.Expense,
+					Expenses:          res.Expenses,
 					Rejected:          true,
 					RejectCode:        r.Reason,
 					RejectReason:      r.Reason.String(),
@@ -205,6 +236,146 @@ func newRelayHandler(relayFunc relayFunc) Handler {
 	}
 }
 
+// newRelayStatusHandler returns a handler for the /relay/{id} endpoint.
+// It returns the status of a relayed gasless order.
+func newRelayStatusHandler(relays *relayLedger) Handler {
+	return Handler{
+		Endpoint: endpointRelayStatus,
+		PathReq: func(r *http.Request) (any, error) {
+			id, err := parseOrderID(r.PathValue("id"))
+			if err != nil {
+				return nil, newAPIError(errors.New("invalid relay id"), http.StatusBadRequest)
+			}
+
+			return &types.RelayStatusRequest{ID: id}, nil
+		},
+		HandleFunc: func(ctx context.Context, request any) (any, error) {
+			req, ok := request.(*types.RelayStatusRequest)
+			if !ok {
+				return nil, errors.New("invalid request type [BUG]", "type", fmt.Sprintf("%T", request))
+			}
+
+			relay, err := relays.Get(ctx, req.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			return relayStatusResponse(relay), nil
+		},
+	}
+}
+
+// newOrderHandler returns a handler for the /orders/{id} endpoint.
+// It returns the order's lifecycle history.
+func newOrderHandler(history *orderHistory) Handler {
+	return Handler{
+		Endpoint: endpointOrders,
+		PathReq: func(r *http.Request) (any, error) {
+			id, err := parseOrderID(r.PathValue("id"))
+			if err != nil {
+				return nil, err
+			}
+
+			return &types.OrderRequest{ID: id}, nil
+		},
+		HandleFunc: func(ctx context.Context, request any) (any, error) {
+			req, ok := request.(*types.OrderRequest)
+			if !ok {
+				return nil, errors.New("invalid request type [BUG]", "type", fmt.Sprintf("%T", request))
+			}
+
+			entries, err := history.Get(ctx, OrderID(req.ID))
+			if err != nil {
+				return nil, err
+			} else if len(entries) == 0 {
+				return nil, newAPIError(errors.New("order not found"), http.StatusNotFound)
+			}
+
+			return orderResponse(req.ID, entries), nil
+		},
+	}
+}
+
+// newTokenAuthorizer returns a request authorizer requiring the bearer token in the Authorization header.
+func newTokenAuthorizer(token string) func(*http.Request) error {
+	return func(r *http.Request) error {
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			return errors.New("unauthorized")
+		}
+
+		return nil
+	}
+}
+
+// newAdminHandlers returns the authenticated admin handlers, pausing and resuming
+// fills, claims or quoting per chain, per token or globally.
+func newAdminHandlers(token string, pauses *pauser) []Handler {
+	authorize := newTokenAuthorizer(token)
+
+	pausesResponse := func() types.PausesResponse {
+		return types.PausesResponse{Pauses: pauses.List()}
+	}
+
+	pauseReq := func(request any) (types.PauseRequest, error) {
+		req, ok := request.(*types.PauseRequest)
+		if !ok {
+			return types.PauseRequest{}, errors.New("invalid request type [BUG]", "type", fmt.Sprintf("%T", request))
+		}
+
+		return *req, nil
+	}
+
+	return []Handler{
+		{
+			Endpoint:  endpointAdminPause,
+			Authorize: authorize,
+			ZeroReq:   func() any { return &types.PauseRequest{} },
+			HandleFunc: func(ctx context.Context, request any) (any, error) {
+				req, err := pauseReq(request)
+				if err != nil {
+					return nil, err
+				}
+
+				if err := pauses.Pause(ctx, req); err != nil {
+					return nil, err
+				}
+
+				log.Warn(ctx, "Paused solver", nil, "scope", req.Scope, "chain_id", req.ChainID, "token", req.Token, "reason", req.Reason)
+
+				return pausesResponse(), nil
+			},
+		},
+		{
+			Endpoint:  endpointAdminResume,
+			Authorize: authorize,
+			ZeroReq:   func() any { return &types.PauseRequest{} },
+			HandleFunc: func(ctx context.Context, request any) (any, error) {
+				req, err := pauseReq(request)
+				if err != nil {
+					return nil, err
+				}
+
+				if err := pauses.Resume(ctx, req); err != nil {
+					return nil, err
+				}
+
+				log.Info(ctx, "Resumed solver", "scope", req.Scope, "chain_id", req.ChainID, "token", req.Token)
+
+				return pausesResponse(), nil
+			},
+		},
+		{
+			Endpoint:  endpointAdminPauses,
+			Authorize: authorize,
+			ZeroReq:   func() any { return nil },
+			HandleFunc: func(context.Context, any) (any, error) {
+				return pausesResponse(), nil
+			},
+		},
+	}
+}
+
 var gatewayTimeout = time.Second * 10
 
 func handlerAdapter(h Handler) http.Handler {
@@ -220,8 +391,21 @@ func handlerAdapter(h Handler) http.Handler {
 			return
 		}
 
-		req := h.ZeroReq()
-		if req == nil { //nolint:revive // noop if-block for readability
+		if h.Authorize != nil {
+			if err := h.Authorize(rr); err != nil {
+				writeErrResponse(ctx, w, newAPIError(err, http.StatusUnauthorized))
+				return
+			}
+		}
+
+		var req any
+		if h.PathReq != nil {
+			req, err = h.PathReq(rr)
+			if err != nil {
+				writeErrResponse(ctx, w, err)
+				return
+			}
+		} else if req = h.ZeroReq(); req == nil { //nolint:revive // noop if-block for readability
 			// Skip request unmarshalling if ZeroReq returns nil.
 		} else if err := json.Unmarshal(body, req); err != nil {
 			// TODO(corver): remove once issue identified
@@ -118,7 +118,7 @@ func TestCheckHandlerRequests(t *testing.T) {
 				}
 
 				return nil
-			}, noopTracer)
+			}, noopTracer, nil)
 
 			srv := httptest.NewServer(handlerAdapter(handler))
 
@@ -0,0 +1,381 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	db "github.com/cosmos/cosmos-db"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Order history actions recorded by the event processor.
+// Solver txs are recorded using their PnL subcategory as action, e.g. Inbox:Reject.
+const (
+	historyEvent  = "event"    // Inbox order event processed
+	historySkip   = "skip"     // Pending order skipped, since already filled
+	historyReject = "reject"   // Order rejected by the solver
+	historyFill   = "fill"     // Order filled by the solver
+	historyClaim  = "claim"    // Order claimed by the solver
+	historyDefer  = "deferred" // Order claim deferred by the solver's claim policy
+
+	historyFillTx = "Outbox:Fill"
+)
+
+const (
+	// historyRetention is the period order history entries are retained.
+	historyRetention = 30 * 24 * time.Hour
+	// historyPruneInterval is the interval at which expired order history entries are deleted.
+	historyPruneInterval = time.Hour
+)
+
+// historyEntry is an order lifecycle entry to record.
+type historyEntry struct {
+	Action string
+	Event  Event              // Inbox event being processed, if any
+	Reason types.RejectReason // Reject reason, if rejected
+	Tx     string             // Solver tx hash, if any
+	PnLUSD float64            // Solver tx PnL in USD, if any
+}
+
+// recordHistoryFunc records an order lifecycle entry.
+// It is best-effort, since order processing should not fail due to history.
+type recordHistoryFunc func(ctx context.Context, order Order, entry historyEntry)
+
+func newOrderHistory(db db.DB) (*orderHistory, error) {
+	dbStore, err := newSolverStore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderHistory{
+		table: dbStore.OrderHistoryTable(),
+	}, nil
+}
+
+// orderHistory provides a thread-safe persisted order lifecycle history store.
+type orderHistory struct {
+	mu    sync.RWMutex
+	table OrderHistoryTable
+}
+
+// Insert adds an entry to the order's history.
+// Duplicate entries (same order, action, tx and event tx), e.g. from retried or reprocessed jobs, are ignored.
+func (h *orderHistory) Insert(ctx context.Context, order Order, entry historyEntry, now time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	dup, err := h.exists(ctx, order.ID, entry)
+	if err != nil {
+		return err
+	} else if dup {
+		return nil
+	}
+
+	_, err = h.table.InsertReturningId(ctx, newHistoryProto(order, entry, now))
+	if err != nil {
+		return errors.Wrap(err, "insert order history")
+	}
+
+	return nil
+}
+
+// exists returns true if the order's history already contains the entry.
+func (h *orderHistory) exists(ctx context.Context, id OrderID, entry historyEntry) (bool, error) {
+	iter, err := h.table.List(ctx, OrderHistoryOrderIdIndexKey{}.WithOrderId(id[:]))
+	if err != nil {
+		return false, errors.Wrap(err, "list order history")
+	}
+	defer iter.Close()
+
+	for iter.Next() {
+		e, err := iter.Value()
+		if err != nil {
+			return false, errors.Wrap(err, "get value")
+		}
+
+		if e.GetAction() == entry.Action && e.GetTx() == entry.Tx && e.GetEventTx() == entry.Event.Tx {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Prune deletes all entries created before `cutoff`.
+func (h *orderHistory) Prune(ctx context.Context, cutoff time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	last, ok, err := h.lastBefore(ctx, cutoff)
+	if err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	err = h.table.DeleteRange(ctx, OrderHistoryIdIndexKey{}, OrderHistoryIdIndexKey{}.WithId(last))
+	if err != nil {
+		return errors.Wrap(err, "delete order history")
+	}
+
+	return nil
+}
+
+// lastBefore returns the ID of the last entry created before `cutoff`, if any.
+// Entries are iterated by auto-incremented ID, so in creation order.
+func (h *orderHistory) lastBefore(ctx context.Context, cutoff time.Time) (uint64, bool, error) {
+	iter, err := h.table.List(ctx, OrderHistoryIdIndexKey{})
+	if err != nil {
+		return 0, false, errors.Wrap(err, "list order history")
+	}
+	defer iter.Close()
+
+	var last uint64
+	var ok bool
+	for iter.Next() {
+		e, err := iter.Value()
+		if err != nil {
+			return 0, false, errors.Wrap(err, "get value")
+		}
+
+		if !e.GetCreatedAt().AsTime().Before(cutoff) {
+			break
+		}
+
+		last, ok = e.GetId(), true
+	}
+
+	return last, ok, nil
+}
+
+// pruneHistoryForever blocks and periodically deletes order history entries older than the retention period.
+func pruneHistoryForever(ctx context.Context, history *orderHistory) {
+	ticker := time.NewTicker(historyPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := history.Prune(ctx, time.Now().Add(-historyRetention)); err != nil {
+				log.Warn(ctx, "Failed to prune order history (will retry)", err)
+			}
+		}
+	}
+}
+
+// newHistoryProto returns the order history entry to persist.
+func newHistoryProto(order Order, entry historyEntry, now time.Time) *OrderHistory {
+	var destChainID uint64
+	if pendingData, err := order.PendingData(); err == nil {
+		destChainID = pendingData.DestinationChainID
+	}
+
+	return &OrderHistory{
+		OrderId:      order.ID[:],
+		SrcChainId:   order.SourceChainID,
+		DestChainId:  destChainID,
+		Status:       uint32(order.Status),
+		Action:       entry.Action,
+		EventTx:      entry.Event.Tx,
+		EventHeight:  entry.Event.Height,
+		Tx:           entry.Tx,
+		RejectReason: uint32(entry.Reason),
+		PnlUsd:       entry.PnLUSD,
+		CreatedAt:    timestamppb.New(now),
+	}
+}
+
+// Get returns the order's history entries in chronological order.
+func (h *orderHistory) Get(ctx context.Context, id OrderID) ([]*OrderHistory, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	iter, err := h.table.List(ctx, OrderHistoryOrderIdIndexKey{}.WithOrderId(id[:]))
+	if err != nil {
+		return nil, errors.Wrap(err, "list order history")
+	}
+	defer iter.Close()
+
+	var resp []*OrderHistory
+	for iter.Next() {
+		entry, err := iter.Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "get value")
+		}
+
+		resp = append(resp, proto.Clone(entry).(*OrderHistory)) //nolint:forcetypeassert // Type known
+	}
+
+	return resp, nil
+}
+
+// newHistoryRecorder returns a recordHistoryFunc that inserts entries into the order history store.
+func newHistoryRecorder(history *orderHistory) recordHistoryFunc {
+	return func(ctx context.Context, order Order, entry historyEntry) {
+		if err := history.Insert(ctx, order, entry, time.Now()); err != nil {
+			log.Warn(ctx, "Failed to record order history", err, "action", entry.Action)
+		}
+	}
+}
+
+// withFilledHistory returns a filledPnLFunc that also records the fill tx and its PnL in the order history.
+// Fill PnL is the value of deposits less expenses and tx fees.
+func withFilledHistory(fn filledPnLFunc, record recordHistoryFunc, pricer tokenpricer.Pricer) filledPnLFunc {
+	return func(ctx context.Context, order Order, rec *ethclient.Receipt) error {
+		if err := fn(ctx, order, rec); err != nil {
+			return err
+		}
+
+		pnlUSD, err := filledPnLUSD(ctx, pricer, order, rec)
+		if err != nil {
+			log.Warn(ctx, "Failed to calculate fill PnL for order history", err)
+		}
+
+		record(ctx, order, historyEntry{
+			Action: historyFillTx,
+			Tx:     rec.TxHash.Hex(),
+			PnLUSD: pnlUSD,
+		})
+
+		return nil
+	}
+}
+
+// withUpdateHistory returns an updatePnLFunc that also records the update tx and its PnL (tx fee) in the order history.
+func withUpdateHistory(fn updatePnLFunc, record recordHistoryFunc, pricer tokenpricer.Pricer) updatePnLFunc {
+	return func(ctx context.Context, order Order, rec *ethclient.Receipt, update string) error {
+		if err := fn(ctx, order, rec, update); err != nil {
+			return err
+		}
+
+		feeUSD, err := txFeeUSD(ctx, pricer, order.SourceChainID, rec)
+		if err != nil {
+			log.Warn(ctx, "Failed to calculate tx fee for order history", err)
+		}
+
+		record(ctx, order, historyEntry{
+			Action: update,
+			Tx:     rec.TxHash.Hex(),
+			PnLUSD: -feeUSD,
+		})
+
+		return nil
+	}
+}
+
+// filledPnLUSD returns the USD PnL of filling the order; deposits less expenses and tx fee.
+func filledPnLUSD(ctx context.Context, pricer tokenpricer.Pricer, order Order, rec *ethclient.Receipt) (float64, error) {
+	pendingData, err := order.PendingData()
+	if err != nil {
+		return 0, err
+	}
+
+	deposits, err := parseMinReceived(order)
+	if err != nil {
+		return 0, err
+	}
+
+	expenses, err := parseMaxSpent(pendingData)
+	if err != nil {
+		return 0, err
+	}
+
+	feeUSD, err := txFeeUSD(ctx, pricer, pendingData.DestinationChainID, rec)
+	if err != nil {
+		return 0, err
+	}
+
+	resp := -feeUSD
+	for _, deposit := range deposits {
+		usd, err := usdValue(ctx, pricer, deposit)
+		if err != nil {
+			return 0, err
+		}
+		resp += usd
+	}
+
+	for _, expense := range expenses {
+		usd, err := usdValue(ctx, pricer, expense)
+		if err != nil {
+			return 0, err
+		}
+		resp -= usd
+	}
+
+	return resp, nil
+}
+
+// txFeeUSD returns the USD value of the native fee paid by the tx.
+func txFeeUSD(ctx context.Context, pricer tokenpricer.Pricer, chainID uint64, rec *ethclient.Receipt) (float64, error) {
+	nativeToken, ok := tokens.Native(chainID)
+	if !ok {
+		return 0, errors.New("native token not found", "chain_id", chainID)
+	}
+
+	return usdValue(ctx, pricer, TokenAmt{Token: nativeToken, Amount: txFee(rec)})
+}
+
+// parseOrderID parses a hex order ID.
+func parseOrderID(s string) (common.Hash, error) {
+	bz, err := hexutil.Decode(s)
+	if err != nil || len(bz) != common.HashLength {
+		return common.Hash{}, newAPIError(errors.New("invalid order id"), http.StatusBadRequest)
+	}
+
+	return common.BytesToHash(bz), nil
+}
+
+// orderResponse converts the order's history entries to the API response type.
+func orderResponse(id common.Hash, entries []*OrderHistory) types.OrderResponse {
+	resp := types.OrderResponse{
+		OrderID: id,
+		History: make([]types.OrderHistoryEntry, 0, len(entries)),
+	}
+
+	for _, e := range entries {
+		entry := historyToType(e)
+		resp.History = append(resp.History, entry)
+		resp.Status = entry.Status
+	}
+
+	return resp
+}
+
+// historyToType converts an order history entry to the API type.
+func historyToType(e *OrderHistory) types.OrderHistoryEntry {
+	status := solvernet.OrderStatus(e.GetStatus()) //nolint:gosec // Status stored as uint8
+	entry := types.OrderHistoryEntry{
+		Action:      e.GetAction(),
+		Status:      status.String(),
+		SrcChainID:  e.GetSrcChainId(),
+		DestChainID: e.GetDestChainId(),
+		EventTx:     e.GetEventTx(),
+		EventHeight: e.GetEventHeight(),
+		Tx:          e.GetTx(),
+		PnLUSD:      e.GetPnlUsd(),
+		Timestamp:   e.GetCreatedAt().AsTime(),
+	}
+
+	if reason := types.RejectReason(e.GetRejectReason()); reason != types.RejectNone { //nolint:gosec // Reason stored as uint8
+		entry.RejectCode = reason
+		entry.RejectReason = reason.String()
+	}
+
+	return entry
+}
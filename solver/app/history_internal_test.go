@@ -0,0 +1,147 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/tutil"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	db "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	history, err := newOrderHistory(db.NewMemDB())
+	require.NoError(t, err)
+
+	orderID := OrderID(tutil.RandomHash())
+	otherID := OrderID(tutil.RandomHash())
+	t0 := time.Unix(1700000000, 0).UTC()
+
+	pending := Order{ID: orderID, SourceChainID: 1, Status: solvernet.StatusPending, pendingData: PendingData{DestinationChainID: 2}}
+	filled := Order{ID: orderID, SourceChainID: 1, Status: solvernet.StatusFilled}
+	event := Event{OrderID: orderID, Status: solvernet.StatusPending, Height: 100, Tx: "0xabc"}
+
+	insert := func(order Order, entry historyEntry, at time.Time) {
+		t.Helper()
+		require.NoError(t, history.Insert(ctx, order, entry, at))
+	}
+
+	insert(pending, historyEntry{Action: historyEvent, Event: event}, t0)
+	insert(Order{ID: otherID, SourceChainID: 1, Status: solvernet.StatusPending}, historyEntry{Action: historyEvent}, t0)
+	insert(pending, historyEntry{Action: historyFillTx, Tx: "0xdef", PnLUSD: 1.5}, t0.Add(time.Second))
+	insert(pending, historyEntry{Action: historyFill, Event: event}, t0.Add(time.Second))
+	insert(filled, historyEntry{Action: historyClaim, Event: Event{Height: 200, Tx: "0x123"}}, t0.Add(time.Minute))
+
+	entries, err := history.Get(ctx, orderID)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	mux := http.NewServeMux()
+	mux.Handle(endpointOrders, handlerAdapter(newOrderHandler(history)))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	get := func(id string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v1/orders/"+id, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	resp := get(common.Hash(orderID).Hex())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var orderResp types.OrderResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&orderResp))
+	require.Equal(t, common.Hash(orderID), orderResp.OrderID)
+	require.Equal(t, solvernet.StatusFilled.String(), orderResp.Status)
+	require.Equal(t, []types.OrderHistoryEntry{
+		{Action: historyEvent, Status: "pending", SrcChainID: 1, DestChainID: 2, EventTx: "0xabc", EventHeight: 100, Timestamp: t0},
+		{Action: historyFillTx, Status: "pending", SrcChainID: 1, DestChainID: 2, Tx: "0xdef", PnLUSD: 1.5, Timestamp: t0.Add(time.Second)},
+		{Action: historyFill, Status: "pending", SrcChainID: 1, DestChainID: 2, EventTx: "0xabc", EventHeight: 100, Timestamp: t0.Add(time.Second)},
+		{Action: historyClaim, Status: "filled", SrcChainID: 1, EventTx: "0x123", EventHeight: 200, Timestamp: t0.Add(time.Minute)},
+	}, orderResp.History)
+
+	// Rejections include the reason
+	insert(Order{ID: otherID, SourceChainID: 1, Status: solvernet.StatusPending}, historyEntry{Action: historyReject, Reason: types.RejectInsufficientInventory}, t0)
+	resp = get(common.Hash(otherID).Hex())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&orderResp))
+	require.Len(t, orderResp.History, 2)
+	require.Equal(t, types.RejectInsufficientInventory, orderResp.History[1].RejectCode)
+	require.Equal(t, types.RejectInsufficientInventory.String(), orderResp.History[1].RejectReason)
+
+	require.Equal(t, http.StatusNotFound, get(tutil.RandomHash().Hex()).StatusCode)
+	require.Equal(t, http.StatusBadRequest, get("0x1234").StatusCode)
+}
+
+func TestOrderHistoryDedupePrune(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	history, err := newOrderHistory(db.NewMemDB())
+	require.NoError(t, err)
+
+	orderID := OrderID(tutil.RandomHash())
+	otherID := OrderID(tutil.RandomHash())
+	order := Order{ID: orderID, SourceChainID: 1, Status: solvernet.StatusPending}
+	t0 := time.Unix(1700000000, 0).UTC()
+
+	insert := func(order Order, entry historyEntry, at time.Time) {
+		t.Helper()
+		require.NoError(t, history.Insert(ctx, order, entry, at))
+	}
+
+	count := func(id OrderID) int {
+		t.Helper()
+		entries, err := history.Get(ctx, id)
+		require.NoError(t, err)
+
+		return len(entries)
+	}
+
+	event := historyEntry{Action: historyEvent, Event: Event{Tx: "0xabc"}}
+	fillTx := historyEntry{Action: historyFillTx, Tx: "0xdef"}
+
+	// Retried or reprocessed entries are ignored
+	insert(order, event, t0)
+	insert(order, event, t0.Add(time.Second))
+	insert(order, fillTx, t0.Add(time.Second))
+	insert(order, fillTx, t0.Add(time.Minute))
+	require.Equal(t, 2, count(orderID))
+
+	// Distinct events, txs or orders are recorded
+	insert(order, historyEntry{Action: historyEvent, Event: Event{Tx: "0x123"}}, t0.Add(time.Hour))
+	insert(order, historyEntry{Action: historyFillTx, Tx: "0x456"}, t0.Add(time.Hour))
+	insert(Order{ID: otherID, SourceChainID: 1}, event, t0.Add(time.Hour))
+	require.Equal(t, 4, count(orderID))
+	require.Equal(t, 1, count(otherID))
+
+	// Nothing to prune
+	require.NoError(t, history.Prune(ctx, t0))
+	require.Equal(t, 4, count(orderID))
+
+	// Entries created before the cutoff are pruned
+	require.NoError(t, history.Prune(ctx, t0.Add(time.Hour)))
+	require.Equal(t, 2, count(orderID))
+	require.Equal(t, 1, count(otherID))
+
+	require.NoError(t, history.Prune(ctx, t0.Add(2*time.Hour)))
+	require.Zero(t, count(orderID))
+	require.Zero(t, count(otherID))
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/lib/tokens/tokenutil"
+	"github.com/omni-network/omni/lib/uni"
+	"github.com/omni-network/omni/lib/unibackend"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// liquidityFunc returns a rejection if the solver lacks free liquidity to pay for the expenses.
+type liquidityFunc func(ctx context.Context, expenses []TokenAmt) error
+
+// newLiquidityChecker returns a liquidityFunc checking the solver balances on the expense chain
+// less the inventory reserved by in-flight fills.
+func newLiquidityChecker(backends unibackend.Backends, solverAddr common.Address, inv *inventory) liquidityFunc {
+	return func(ctx context.Context, expenses []TokenAmt) error {
+		if len(expenses) == 0 {
+			return nil
+		}
+
+		backend, err := backends.Backend(expenses[0].Token.ChainID)
+		if err != nil {
+			return newRejection(types.RejectUnsupportedDestChain, err)
+		}
+
+		return checkLiquidity(ctx, expenses, backend, solverAddr, inv)
+	}
+}
+
+// inventory is an in-memory ledger of token amounts reserved by in-flight fills, per (chain, token).
+// It prevents concurrent fills from over-committing the solver's liquidity.
+type inventory struct {
+	mu       sync.Mutex
+	reserved map[tokens.Token]*big.Int
+	orders   map[OrderID][]TokenAmt
+}
+
+func newInventory() *inventory {
+	return &inventory{
+		reserved: make(map[tokens.Token]*big.Int),
+		orders:   make(map[OrderID][]TokenAmt),
+	}
+}
+
+// Reserved returns the amount of the token reserved by in-flight fills.
+func (inv *inventory) Reserved(tkn tokens.Token) *big.Int {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	return inv.reservedUnsafe(tkn)
+}
+
+// Check returns a rejection if the balances (per expense) less reserved inventory do not cover the expenses.
+func (inv *inventory) Check(expenses []TokenAmt, balances []*big.Int) error {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	return inv.checkUnsafe(expenses, balances)
+}
+
+// Reserve atomically checks and reserves the order's expenses.
+// It returns a rejection if the balances (per expense) less reserved inventory do not cover the expenses.
+// Existing reservations of the order are replaced.
+func (inv *inventory) Reserve(id OrderID, expenses []TokenAmt, balances []*big.Int) error {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	inv.releaseUnsafe(id)
+
+	if err := inv.checkUnsafe(expenses, balances); err != nil {
+		return err
+	}
+
+	for _, expense := range expenses {
+		inv.reserved[expense.Token] = bi.Add(inv.reservedUnsafe(expense.Token), expense.Amount)
+		inv.instrumentUnsafe(expense.Token)
+	}
+	inv.orders[id] = expenses
+
+	return nil
+}
+
+// Release releases the order's reserved expenses, if any.
+// It should be called when an order's fill is confirmed or failed.
+func (inv *inventory) Release(id OrderID) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	inv.releaseUnsafe(id)
+}
+
+func (inv *inventory) releaseUnsafe(id OrderID) {
+	for _, expense := range inv.orders[id] {
+		reserved := bi.Sub(inv.reservedUnsafe(expense.Token), expense.Amount)
+		if reserved.Sign() <= 0 {
+			delete(inv.reserved, expense.Token)
+		} else {
+			inv.reserved[expense.Token] = reserved
+		}
+		inv.instrumentUnsafe(expense.Token)
+	}
+
+	delete(inv.orders, id)
+}
+
+func (inv *inventory) checkUnsafe(expenses []TokenAmt, balances []*big.Int) error {
+	if len(expenses) != len(balances) {
+		return errors.New("expenses and balances length mismatch [BUG]")
+	}
+
+	for i, expense := range expenses {
+		reserved := inv.reservedUnsafe(expense.Token)
+		free := bi.Sub(balances[i], reserved)
+
+		inventoryFree.WithLabelValues(evmchain.Name(expense.Token.ChainID), expense.Token.Symbol).Set(expense.Token.AmtToF64(free))
+
+		// TODO: for native tokens, even if we have enough, we don't want to
+		// spend out whole balance. we'll need to keep some for gas
+		if bi.LT(free, bi.Add(expense.Amount, minSafe(expense.Token))) {
+			attrs := []any{"balance", expense.Token.FormatAmt(balances[i])}
+			if reserved.Sign() > 0 {
+				attrs = append(attrs, "reserved", expense.Token.FormatAmt(reserved))
+			}
+
+			return newRejection(types.RejectInsufficientInventory, errors.New("insufficient balance",
+				append(attrs, "expense", expense)...,
+			))
+		}
+	}
+
+	return nil
+}
+
+func (inv *inventory) reservedUnsafe(tkn tokens.Token) *big.Int {
+	if reserved, ok := inv.reserved[tkn]; ok {
+		return reserved
+	}
+
+	return bi.Zero()
+}
+
+func (inv *inventory) instrumentUnsafe(tkn tokens.Token) {
+	inventoryReserved.WithLabelValues(evmchain.Name(tkn.ChainID), tkn.Symbol).Set(tkn.AmtToF64(inv.reservedUnsafe(tkn)))
+}
+
+// minSafe returns the minimum balance of the token the solver should retain post-fill.
+func minSafe(tkn tokens.Token) *big.Int {
+	if tkn.Is(tokens.ETH) {
+		return minSafeETH
+	}
+
+	return bi.Zero()
+}
+
+// expenseBalances returns the solver's balance of each expense token.
+func expenseBalances(ctx context.Context, expenses []TokenAmt, backend unibackend.Backend, solverAddr common.Address) ([]*big.Int, error) {
+	var resp []*big.Int
+	for _, expense := range expenses {
+		bal, err := tokenutil.UniBalanceOf(ctx, backend, expense.Token, uni.EVMAddress(solverAddr))
+		if err != nil {
+			return nil, errors.Wrap(err, "get balance", "token", expense.Token.Symbol)
+		}
+
+		resp = append(resp, bal)
+	}
+
+	return resp, nil
+}
+
+// withInventoryRelease wraps a fill function, releasing the order's reserved inventory once the fill is confirmed or failed.
+func withInventoryRelease(inv *inventory, fill func(ctx context.Context, order Order) error) func(ctx context.Context, order Order) error {
+	return func(ctx context.Context, order Order) error {
+		defer inv.Release(order.ID)
+		return fill(ctx, order)
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/lib/tutil"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// anyLiquidity is a liquidityFunc that always has sufficient liquidity.
+func anyLiquidity(context.Context, []TokenAmt) error {
+	return nil
+}
+
+func TestInventory(t *testing.T) {
+	t.Parallel()
+
+	inv := newInventory()
+	usdc := erc20(evmchain.IDArbitrumOne, tokens.USDC)
+	eth := mustNative(t, evmchain.IDArbitrumOne)
+
+	requireInsufficient := func(err error) {
+		t.Helper()
+		r := new(RejectionError)
+		require.ErrorAs(t, err, &r)
+		require.Equal(t, types.RejectInsufficientInventory, r.Reason)
+	}
+
+	balances := []*big.Int{bi.Dec6(100)}
+	order1 := OrderID(tutil.RandomHash())
+	order2 := OrderID(tutil.RandomHash())
+	order3 := OrderID(tutil.RandomHash())
+
+	// Reserve 60 of 100 USDC
+	require.NoError(t, inv.Reserve(order1, []TokenAmt{{Token: usdc, Amount: bi.Dec6(60)}}, balances))
+	require.Equal(t, bi.Dec6(60), inv.Reserved(usdc))
+
+	// Re-reserving the same order replaces the reservation
+	require.NoError(t, inv.Reserve(order1, []TokenAmt{{Token: usdc, Amount: bi.Dec6(60)}}, balances))
+	require.Equal(t, bi.Dec6(60), inv.Reserved(usdc))
+
+	// Concurrent order cannot over-commit
+	requireInsufficient(inv.Reserve(order2, []TokenAmt{{Token: usdc, Amount: bi.Dec6(50)}}, balances))
+	requireInsufficient(inv.Check([]TokenAmt{{Token: usdc, Amount: bi.Dec6(50)}}, balances))
+	require.Equal(t, bi.Dec6(60), inv.Reserved(usdc))
+
+	// But can use the free balance
+	require.NoError(t, inv.Reserve(order2, []TokenAmt{{Token: usdc, Amount: bi.Dec6(40)}}, balances))
+	require.Equal(t, bi.Dec6(100), inv.Reserved(usdc))
+
+	// Native reservations retain min safe ETH
+	ethBalances := []*big.Int{bi.Add(bi.Ether(1), minSafeETH)}
+	require.NoError(t, inv.Check([]TokenAmt{{Token: eth, Amount: bi.Ether(1)}}, ethBalances))
+	requireInsufficient(inv.Check([]TokenAmt{{Token: eth, Amount: bi.Ether(1.01)}}, ethBalances))
+	require.NoError(t, inv.Reserve(order3, []TokenAmt{{Token: eth, Amount: bi.Ether(0.5)}}, ethBalances))
+	requireInsufficient(inv.Check([]TokenAmt{{Token: eth, Amount: bi.Ether(0.6)}}, ethBalances))
+
+	// Releasing frees inventory
+	inv.Release(order1)
+	require.Equal(t, bi.Dec6(40), inv.Reserved(usdc))
+	require.NoError(t, inv.Check([]TokenAmt{{Token: usdc, Amount: bi.Dec6(60)}}, balances))
+
+	inv.Release(order2)
+	inv.Release(order3)
+	inv.Release(order3) // Idempotent
+	require.Equal(t, bi.Zero(), inv.Reserved(usdc))
+	require.Equal(t, bi.Zero(), inv.Reserved(eth))
+	require.Empty(t, inv.orders)
+}
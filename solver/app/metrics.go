@@ -72,6 +72,27 @@ var (
 		Help:      "Number of concurrent requests being served by the API server (at scrape time)",
 	})
 
+	apiRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "solver",
+		Subsystem: "api",
+		Name:      "rate_limited_total",
+		Help:      "Total requests rejected by the API server rate limiter per endpoint per client class (ip, api_key)",
+	}, []string{"endpoint", "class"})
+
+	streamSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "solver",
+		Subsystem: "api",
+		Name:      "stream_subscribers",
+		Help:      "Number of order stream subscribers connected to the API server",
+	})
+
+	streamDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "solver",
+		Subsystem: "api",
+		Name:      "stream_dropped_total",
+		Help:      "Total order stream subscribers dropped for falling behind",
+	})
+
 	workActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "solver",
 		Subsystem: "worker",
@@ -94,6 +115,70 @@ var (
 		Help:      "Total job errors by chain and event status",
 	}, []string{"chain", "status"})
 
+	inventoryReserved = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "solver",
+		Subsystem: "inventory",
+		Name:      "reserved",
+		Help:      "Token amount reserved by in-flight fills by chain and token",
+	}, []string{"chain", "token"})
+
+	inventoryFree = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "solver",
+		Subsystem: "inventory",
+		Name:      "free",
+		Help:      "Last observed token balance less reserved inventory by chain and token",
+	}, []string{"chain", "token"})
+
+	deferredClaims = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "solver",
+		Subsystem: "claims",
+		Name:      "deferred",
+		Help:      "Number of filled orders awaiting a deferred claim by source chain",
+	}, []string{"chain"})
+
+	deferredClaimsUSD = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "solver",
+		Subsystem: "claims",
+		Name:      "deferred_usd",
+		Help:      "Deposit value in USD of filled orders awaiting a deferred claim by source chain",
+	}, []string{"chain"})
+
+	deferredClaimReleases = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "solver",
+		Subsystem: "claims",
+		Name:      "deferred_releases_total",
+		Help:      "Total number of times deferred claims were released by a policy trigger by source chain and trigger",
+	}, []string{"chain", "trigger"})
+
+	deferredClaimReleaseSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "solver",
+		Subsystem: "claims",
+		Name:      "deferred_release_size",
+		Help:      "Number of deferred orders claimed (one tx each) per release by source chain",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 8),
+	}, []string{"chain"})
+
+	trackedFills = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "solver",
+		Subsystem: "filltracker",
+		Name:      "tracked",
+		Help:      "Number of fills awaiting finalization by destination chain",
+	}, []string{"chain"})
+
+	finalizedFills = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "solver",
+		Subsystem: "filltracker",
+		Name:      "finalized_total",
+		Help:      "Total number of tracked fills finalized by source and destination chain",
+	}, []string{"src_chain", "dst_chain"})
+
+	orphanedFills = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "solver",
+		Subsystem: "filltracker",
+		Name:      "orphaned_total",
+		Help:      "Total number of tracked fills orphaned (e.g. reorged out) before finalization by source and destination chain",
+	}, []string{"src_chain", "dst_chain"})
+
 	priceGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "solver",
 		Subsystem: "pricer",
@@ -0,0 +1,236 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/solver/types"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"cosmossdk.io/orm/types/ormerrors"
+	db "github.com/cosmos/cosmos-db"
+)
+
+// pausedFunc returns the active pause of the scope matching the chain and any of the tokens, if any.
+type pausedFunc func(scope types.PauseScope, chainID uint64, tkns ...tokens.Token) (types.Pause, bool)
+
+// pauser provides a thread-safe persisted store of pauses, set via the admin API.
+// Pauses are cached in memory, since they are checked for every order and quote.
+type pauser struct {
+	mu     sync.RWMutex
+	table  PauseTable
+	active []*Pause
+	now    func() time.Time
+}
+
+func newPauser(ctx context.Context, db db.DB) (*pauser, error) {
+	dbStore, err := newSolverStore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pauser{
+		table: dbStore.PauseTable(),
+		now:   time.Now,
+	}
+
+	if err := p.load(ctx); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// load loads all pauses from the DB into the cache.
+func (p *pauser) load(ctx context.Context) error {
+	iter, err := p.table.List(ctx, PausePrimaryKey{})
+	if err != nil {
+		return errors.Wrap(err, "list pauses")
+	}
+	defer iter.Close()
+
+	var active []*Pause
+	for iter.Next() {
+		pause, err := iter.Value()
+		if err != nil {
+			return errors.Wrap(err, "get value")
+		}
+
+		active = append(active, proto.Clone(pause).(*Pause)) //nolint:forcetypeassert // Type known
+	}
+
+	p.active = active
+
+	return nil
+}
+
+// Pause persists the pause, replacing the reason of an identical existing pause.
+func (p *pauser) Pause(ctx context.Context, req types.PauseRequest) error {
+	req, err := normalizePause(req)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err = p.table.Save(ctx, &Pause{
+		Scope:     string(req.Scope),
+		ChainId:   req.ChainID,
+		Token:     req.Token,
+		Reason:    req.Reason,
+		CreatedAt: timestamppb.New(p.now()),
+	})
+	if err != nil {
+		return errors.Wrap(err, "save pause")
+	}
+
+	return p.load(ctx)
+}
+
+// Resume deletes the pause. It returns a not found API error if the pause doesn't exist.
+func (p *pauser) Resume(ctx context.Context, req types.PauseRequest) error {
+	req, err := normalizePause(req)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pause, err := p.table.Get(ctx, string(req.Scope), req.ChainID, req.Token)
+	if ormerrors.IsNotFound(err) {
+		return newAPIError(errors.New("pause not found"), http.StatusNotFound)
+	} else if err != nil {
+		return errors.Wrap(err, "get pause")
+	}
+
+	if err := p.table.Delete(ctx, pause); err != nil {
+		return errors.Wrap(err, "delete pause")
+	}
+
+	return p.load(ctx)
+}
+
+// List returns all active pauses.
+func (p *pauser) List() []types.Pause {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	resp := make([]types.Pause, 0, len(p.active))
+	for _, pause := range p.active {
+		resp = append(resp, pauseToType(pause))
+	}
+
+	return resp
+}
+
+// Paused returns the active pause of the scope matching the chain and any of the tokens, if any.
+// Pauses with zero chain ID match all chains, pauses with an empty token match all tokens.
+func (p *pauser) Paused(scope types.PauseScope, chainID uint64, tkns ...tokens.Token) (types.Pause, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, pause := range p.active {
+		if pause.GetScope() != string(scope) {
+			continue
+		}
+
+		if pause.GetChainId() != 0 && pause.GetChainId() != chainID {
+			continue
+		}
+
+		if pause.GetToken() != "" && !containsSymbol(tkns, pause.GetToken()) {
+			continue
+		}
+
+		return pauseToType(pause), true
+	}
+
+	return types.Pause{}, false
+}
+
+// normalizePause validates the request, replacing the token symbol with its canonical case.
+func normalizePause(req types.PauseRequest) (types.PauseRequest, error) {
+	if err := req.Scope.Validate(); err != nil {
+		return types.PauseRequest{}, newAPIError(err, http.StatusBadRequest)
+	}
+
+	if req.Token == "" {
+		return req, nil
+	}
+
+	for _, tkn := range tokens.All() {
+		if strings.EqualFold(tkn.Symbol, req.Token) {
+			req.Token = tkn.Symbol
+			return req, nil
+		}
+	}
+
+	return types.PauseRequest{}, newAPIError(errors.New("unknown token", "token", req.Token), http.StatusBadRequest)
+}
+
+func containsSymbol(tkns []tokens.Token, symbol string) bool {
+	for _, tkn := range tkns {
+		if tkn.Symbol == symbol {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pauseToType(pause *Pause) types.Pause {
+	return types.Pause{
+		Scope:     types.PauseScope(pause.GetScope()),
+		ChainID:   pause.GetChainId(),
+		Token:     pause.GetToken(),
+		Reason:    pause.GetReason(),
+		CreatedAt: pause.GetCreatedAt().AsTime(),
+	}
+}
+
+// checkPaused returns a paused rejection if the scope is paused for the source chain deposits or destination chain expenses.
+func checkPaused(
+	isPaused pausedFunc,
+	scope types.PauseScope,
+	srcChainID uint64,
+	deposits []TokenAmt,
+	destChainID uint64,
+	expenses []TokenAmt,
+) error {
+	for _, leg := range []struct {
+		ChainID uint64
+		Amts    []TokenAmt
+	}{
+		{srcChainID, deposits},
+		{destChainID, expenses},
+	} {
+		if pause, ok := isPaused(scope, leg.ChainID, amtTokens(leg.Amts)...); ok {
+			return newRejection(types.RejectPaused, errors.New("paused",
+				"scope", pause.Scope,
+				"chain_id", pause.ChainID,
+				"token", pause.Token,
+				"reason", pause.Reason,
+			))
+		}
+	}
+
+	return nil
+}
+
+func amtTokens(amts []TokenAmt) []tokens.Token {
+	resp := make([]tokens.Token, 0, len(amts))
+	for _, amt := range amts {
+		resp = append(resp, amt.Token)
+	}
+
+	return resp
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/cosmos/cosmos-db"
+)
+
+func notPaused(types.PauseScope, uint64, ...tokens.Token) (types.Pause, bool) {
+	return types.Pause{}, false
+}
+
+func TestPauser(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	memDB := db.NewMemDB()
+	pauses, err := newPauser(ctx, memDB)
+	require.NoError(t, err)
+
+	ethUSDC := erc20(evmchain.IDEthereum, tokens.USDC)
+	ethETH := erc20(evmchain.IDEthereum, tokens.ETH)
+	baseUSDC := erc20(evmchain.IDBase, tokens.USDC)
+
+	_, ok := pauses.Paused(types.PauseFill, evmchain.IDEthereum, ethUSDC)
+	require.False(t, ok)
+
+	// Invalid requests
+	require.Error(t, pauses.Pause(ctx, types.PauseRequest{Scope: "invalid"}))
+	require.Error(t, pauses.Pause(ctx, types.PauseRequest{Scope: types.PauseFill, Token: "UNKNOWN"}))
+
+	// Pause USDC fills on all chains (token symbols are case-insensitive)
+	require.NoError(t, pauses.Pause(ctx, types.PauseRequest{Scope: types.PauseFill, Token: "usdc", Reason: "depeg"}))
+
+	pause, ok := pauses.Paused(types.PauseFill, evmchain.IDBase, baseUSDC)
+	require.True(t, ok)
+	require.Equal(t, "USDC", pause.Token)
+	require.Equal(t, "depeg", pause.Reason)
+
+	_, ok = pauses.Paused(types.PauseFill, evmchain.IDEthereum, ethETH)
+	require.False(t, ok)
+	_, ok = pauses.Paused(types.PauseQuote, evmchain.IDEthereum, ethUSDC)
+	require.False(t, ok)
+
+	// Pause all claims on ethereum
+	require.NoError(t, pauses.Pause(ctx, types.PauseRequest{Scope: types.PauseClaim, ChainID: evmchain.IDEthereum}))
+
+	_, ok = pauses.Paused(types.PauseClaim, evmchain.IDEthereum, ethETH)
+	require.True(t, ok)
+	_, ok = pauses.Paused(types.PauseClaim, evmchain.IDBase, baseUSDC)
+	require.False(t, ok)
+
+	// Paused orders are rejected
+	err = checkPaused(pauses.Paused, types.PauseFill, evmchain.IDEthereum, []TokenAmt{{Token: ethETH}}, evmchain.IDBase, []TokenAmt{{Token: baseUSDC}})
+	r := new(RejectionError)
+	require.ErrorAs(t, err, &r)
+	require.Equal(t, types.RejectPaused, r.Reason)
+
+	// Pauses are persisted
+	reloaded, err := newPauser(ctx, memDB)
+	require.NoError(t, err)
+	require.Equal(t, pauses.List(), reloaded.List())
+	require.Len(t, reloaded.List(), 2)
+
+	// Resume
+	require.NoError(t, pauses.Resume(ctx, types.PauseRequest{Scope: types.PauseFill, Token: "USDC"}))
+	_, ok = pauses.Paused(types.PauseFill, evmchain.IDBase, baseUSDC)
+	require.False(t, ok)
+	require.Len(t, pauses.List(), 1)
+
+	var apiErr APIError
+	require.ErrorAs(t, pauses.Resume(ctx, types.PauseRequest{Scope: types.PauseFill, Token: "USDC"}), &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+func TestAdminHandlers(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	pauses, err := newPauser(ctx, db.NewMemDB())
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	for _, h := range newAdminHandlers("secret", pauses) {
+		mux.Handle(h.Endpoint, handlerAdapter(h))
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	post := func(endpoint, token, body string) int {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+endpoint, strings.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	pause := `{"scope":"quote","chainId":1}`
+
+	require.Equal(t, http.StatusUnauthorized, post(endpointAdminPause, "", pause))
+	require.Equal(t, http.StatusUnauthorized, post(endpointAdminPause, "wrong", pause))
+	require.Empty(t, pauses.List())
+
+	require.Equal(t, http.StatusOK, post(endpointAdminPause, "secret", pause))
+	_, ok := pauses.Paused(types.PauseQuote, 1)
+	require.True(t, ok)
+
+	require.Equal(t, http.StatusOK, post(endpointAdminPauses, "secret", ""))
+	require.Equal(t, http.StatusBadRequest, post(endpointAdminPause, "secret", `{"scope":"invalid"}`))
+
+	require.Equal(t, http.StatusOK, post(endpointAdminResume, "secret", pause))
+	require.Empty(t, pauses.List())
+	require.Equal(t, http.StatusNotFound, post(endpointAdminResume, "secret", pause))
+
+}
@@ -121,15 +121,7 @@ func gasPnL(
 	subCat string,
 	id string,
 ) error {
-	amount := bi.MulRaw(rec.EffectiveGasPrice, rec.GasUsed)
-	if rec.OPL1Fee != nil {
-		amount = bi.Add(amount, rec.OPL1Fee)
-	}
-
-	// Add any xcall fees included in tx
-	if fee, ok := maybeParseXCallFee(rec); ok {
-		amount = bi.Add(amount, fee)
-	}
+	amount := txFee(rec)
 
 	nativeToken, ok := tokens.Native(chainID)
 	if !ok {
@@ -152,6 +144,21 @@ func gasPnL(
 	return nil
 }
 
+// txFee returns the total native fee paid by the tx; gas, L1 fees and any xcall fees.
+func txFee(rec *ethclient.Receipt) *big.Int {
+	amount := bi.MulRaw(rec.EffectiveGasPrice, rec.GasUsed)
+	if rec.OPL1Fee != nil {
+		amount = bi.Add(amount, rec.OPL1Fee)
+	}
+
+	// Add any xcall fees included in tx
+	if fee, ok := maybeParseXCallFee(rec); ok {
+		amount = bi.Add(amount, fee)
+	}
+
+	return amount
+}
+
 // maybeParseXCallFee returns the xcall fee from the receipt if present or false.
 func maybeParseXCallFee(rec *ethclient.Receipt) (*big.Int, bool) {
 	portal, _ := bindings.NewOmniPortal(common.Address{}, nil) // Safe to pass in zeros since we only parse events.
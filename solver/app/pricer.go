@@ -5,29 +5,88 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"strconv"
 	"time"
 
+	"github.com/omni-network/omni/lib/bi"
 	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient/ethbackend"
 	"github.com/omni-network/omni/lib/evmchain"
 	"github.com/omni-network/omni/lib/log"
 	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tokenpricer"
 	"github.com/omni-network/omni/lib/tokenpricer/coingecko"
 	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/lib/uniswap"
 	"github.com/omni-network/omni/solver/types"
 )
 
-func newPricer(ctx context.Context, network netconf.ID, apiKey string) tokenpricer.Pricer {
+const (
+	defaultPriceMaxAge       = 5 * time.Minute
+	defaultPriceMaxDeviation = 0.05
+)
+
+// newPricer returns the solver's token pricer. Outside devnet, it is a cached median aggregator
+// of CoinGecko (primary) and on-chain Uniswap (mainnet only), falling back to configured static prices.
+func newPricer(ctx context.Context, network netconf.ID, cfg Config, backends ethbackend.Backends) (tokenpricer.Pricer, error) {
 	if network == netconf.Devnet {
-		return tokenpricer.NewDevnetMock()
+		return tokenpricer.NewDevnetMock(), nil
+	}
+
+	sources := []tokenpricer.Source{
+		coingecko.NewSource(coingecko.New(coingecko.WithAPIKey(cfg.CoinGeckoAPIKey))),
+	}
+
+	if network == netconf.Mainnet {
+		backend, err := backends.Backend(evmchain.IDEthereum)
+		if err != nil {
+			return nil, err
+		}
+
+		uniswapSource, err := uniswap.NewPriceSource(backend)
+		if err != nil {
+			return nil, errors.Wrap(err, "uniswap price source")
+		}
+
+		sources = append(sources, uniswapSource)
 	}
 
-	pricer := tokenpricer.NewCached(coingecko.New(coingecko.WithAPIKey(apiKey)))
+	static, err := parseStaticPrices(cfg.StaticPrices)
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		priceCacheTTL        = time.Minute
+		priceRefreshInterval = 30 * time.Second
+	)
+	pricer := tokenpricer.NewCached(
+		tokenpricer.NewAggregator(cfg.PriceMaxAge, cfg.PriceMaxDeviation, sources...).WithStaticFallback(static),
+		tokenpricer.WithTTL(priceCacheTTL),
+	)
+	go pricer.RefreshForever(ctx, priceRefreshInterval)
+
+	return pricer, nil
+}
+
+// parseStaticPrices parses the configured static USD prices by asset symbol.
+func parseStaticPrices(config map[string]string) (map[tokens.Asset]float64, error) {
+	prices := make(map[tokens.Asset]float64)
+	for symbol, value := range config {
+		asset, err := tokens.AssetBySymbol(symbol)
+		if err != nil {
+			return nil, errors.Wrap(err, "static price asset", "symbol", symbol)
+		}
+
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil || price <= 0 {
+			return nil, errors.New("invalid static price", "symbol", symbol, "price", value)
+		}
 
-	const priceCacheEvictInterval = time.Minute
-	go pricer.ClearCacheForever(ctx, priceCacheEvictInterval)
+		prices[asset] = price
+	}
 
-	return pricer
+	return prices, nil
 }
 
 // priceFunc returns the unit price of the `deposit` denominated in `expense`.
@@ -68,7 +127,9 @@ func newPriceFunc(pricer tokenpricer.Pricer) priceFunc {
 
 type priceHandlerFunc func(ctx context.Context, request types.PriceRequest) (types.Price, error)
 
-func wrapPriceHandlerFunc(priceFunc priceFunc) priceHandlerFunc {
+// wrapPriceHandlerFunc returns a priceHandlerFunc that includes solver fees in the price.
+// Since no amounts are provided, the fee of the smallest order size band applies.
+func wrapPriceHandlerFunc(priceFunc priceFunc, feeFunc feeFunc) priceHandlerFunc {
 	return func(ctx context.Context, req types.PriceRequest) (types.Price, error) {
 		srcToken, ok := tokens.ByAddress(req.SourceChainID, req.DepositToken)
 		if !ok {
@@ -84,12 +145,17 @@ func wrapPriceHandlerFunc(priceFunc priceFunc) priceHandlerFunc {
 			return types.Price{}, errors.Wrap(err, "price")
 		}
 
-		return price.WithFeeBips(feeBips(srcToken.Asset, dstToken.Asset)), nil // Add fee to the price.
+		rule, err := feeFunc(ctx, srcToken, TokenAmt{Token: dstToken, Amount: bi.Zero()})
+		if err != nil {
+			return types.Price{}, errors.Wrap(err, "fee")
+		}
+
+		return price.WithFeeBips(rule.Bips), nil // Add fee to the price.
 	}
 }
 
 // debugOrderPrice log order amounts and prices if applicable.
-func debugOrderPrice(ctx context.Context, priceFunc priceFunc, order Order) {
+func debugOrderPrice(ctx context.Context, priceFunc priceFunc, feeFunc feeFunc, order Order) {
 	pendingData, err := order.PendingData()
 	if err != nil {
 		return
@@ -122,10 +188,15 @@ func debugOrderPrice(ctx context.Context, priceFunc priceFunc, order Order) {
 		return
 	}
 
+	rule, err := feeFunc(ctx, depositTkn, TokenAmt{Token: expenseTkn, Amount: expenseAmt})
+	if err != nil {
+		return
+	}
+
 	currentF64, _ := currentPrice.Price.Float64()
 	orderF64, _ := orderPrice.Price.Float64()
 	profitBips := math.Round((currentF64 - orderF64) / orderF64 * 10_000)
-	slippageBips := int64(profitBips) - feeBips(depositTkn.Asset, expenseTkn.Asset)
+	slippageBips := int64(profitBips) - rule.Bips
 
 	log.Debug(ctx, "Pending order amounts",
 		"deposit", depositTkn.FormatAmt(depositAmt),
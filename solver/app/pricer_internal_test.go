@@ -34,7 +34,7 @@ func TestPriceHandler(t *testing.T) {
 	l2wstETH := must(tokens.ByAsset(evmchain.IDMockL2, tokens.WSTETH))
 	l1wstETH := must(tokens.ByAsset(evmchain.IDMockL1, tokens.WSTETH))
 
-	handlerFunc := wrapPriceHandlerFunc(newPriceFunc(tokenpricer.NewDevnetMock()))
+	handlerFunc := wrapPriceHandlerFunc(newPriceFunc(tokenpricer.NewDevnetMock()), testFeeFunc())
 
 	tests := []struct {
 		Deposit tokens.Token
@@ -88,7 +88,7 @@ func TestPriceHandler(t *testing.T) {
 				Expense: test.Expense.Asset,
 			}
 			if !test.NoFees {
-				expected = expected.WithFeeBips(feeBips(test.Deposit.Asset, test.Expense.Asset))
+				expected = expected.WithFeeBips(standardFeeBips)
 			}
 
 			require.Equalf(t, expected, actual, "expected=%v, actual=%v", expected, actual)
@@ -28,7 +28,12 @@ type procDeps struct {
 
 	Reject func(ctx context.Context, order Order, reason stypes.RejectReason) error
 	Fill   func(ctx context.Context, order Order) error
-	Claim  func(ctx context.Context, order Order) error
+	Claim  func(ctx context.Context, order Order) (deferred bool, err error)
+
+	// RecordHistory records order lifecycle entries (best-effort).
+	RecordHistory recordHistoryFunc
+	// TrackFill follows filled orders until the fill is finalized, re-processing orphaned fills.
+	TrackFill func(ctx context.Context, order Order, e Event)
 
 	// Monitoring helpers
 	ProcessorName     func(chainID uint64) string
@@ -124,7 +129,7 @@ func newFiller(
 		if ok, err := outbox.DidFill(callOpts, order.ID, pendingData.FillOriginData); err != nil {
 			return errors.Wrap(err, "did fill")
 		} else if ok {
-			// TODO(corver): We don't wait for confirmation in this case, so this could still reorg out :(
+			// The processor tracks the fill until finalized, since it could still reorg out.
 			log.Info(ctx, "Skipping already filled order")
 			return nil
 		}
@@ -249,26 +254,9 @@ func newRejector(
 // It returns false/nil on invalid orders, as invalid orders are never filled.
 // It only returns temporary RPC errors, so it is safe to retry always.
 func newDidFiller(outboxContracts map[uint64]*bindings.SolverNetOutbox) func(ctx context.Context, order Order) (bool, error) {
+	didFillAt := newDidFillerAt(outboxContracts)
 	return func(ctx context.Context, order Order) (bool, error) {
-		ctx, span := tracer.Start(ctx, "proc/did_fill")
-		defer span.End()
-
-		pendingData, err := order.PendingData()
-		if err != nil {
-			return false, nil
-		}
-
-		outbox, ok := outboxContracts[pendingData.DestinationChainID]
-		if !ok {
-			return false, nil
-		}
-
-		filled, err := outbox.DidFill(&bind.CallOpts{Context: ctx}, order.ID, pendingData.FillOriginData)
-		if err != nil {
-			return false, errors.Wrap(err, "did fill")
-		}
-
-		return filled, nil
+		return didFillAt(ctx, order, nil) // Latest
 	}
 }
 
@@ -47,6 +47,7 @@ func newEventProcFunc(deps procDeps, chainID uint64) eventProcFunc {
 		}
 
 		age := deps.InstrumentAge(ctx, chainID, e.Height, order)
+		deps.RecordHistory(ctx, order, historyEntry{Action: historyEvent, Event: e})
 
 		log.Debug(ctx, "Processing order event", age)
 
@@ -70,6 +71,7 @@ func newEventProcFunc(deps procDeps, chainID uint64) eventProcFunc {
 				deps.ChainName(order.SourceChainID),
 				reason.String(),
 			).Inc()
+			deps.RecordHistory(ctx, order, historyEntry{Action: historyReject, Event: e, Reason: reason})
 
 			return true, nil
 		}
@@ -79,8 +81,11 @@ func newEventProcFunc(deps procDeps, chainID uint64) eventProcFunc {
 			if filled, err := deps.DidFill(ctx, order); err != nil {
 				return errors.Wrap(err, "already filled")
 			} else if filled {
-				// TODO(corver): We don't wait for confirmation in this case, so this could still reorg out :(
+				// Track the fill until finalized, since it could still reorg out.
 				log.Info(ctx, "Skipping already filled order")
+				deps.RecordHistory(ctx, order, historyEntry{Action: historySkip, Event: e})
+				deps.TrackFill(ctx, order, e)
+
 				break
 			}
 
@@ -96,11 +101,19 @@ func newEventProcFunc(deps procDeps, chainID uint64) eventProcFunc {
 			if err := deps.Fill(ctx, order); err != nil {
 				return errors.Wrap(err, "fill order")
 			}
+			deps.RecordHistory(ctx, order, historyEntry{Action: historyFill, Event: e})
+			deps.TrackFill(ctx, order, e)
 		case solvernet.StatusFilled:
 			log.Info(ctx, "Claiming order")
-			if err := deps.Claim(ctx, order); err != nil {
+			deferred, err := deps.Claim(ctx, order)
+			if err != nil {
 				return errors.Wrap(err, "claim order")
+			} else if deferred {
+				// The claim is recorded when it lands.
+				deps.RecordHistory(ctx, order, historyEntry{Action: historyDefer, Event: e})
+				break
 			}
+			deps.RecordHistory(ctx, order, historyEntry{Action: historyClaim, Event: e})
 		case solvernet.StatusRejected, solvernet.StatusClosed, solvernet.StatusClaimed:
 			// Noop for now
 		default:
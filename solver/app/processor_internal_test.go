@@ -30,7 +30,9 @@ func TestEventProcessor(t *testing.T) {
 		event        common.Hash
 		getStatus    solvernet.OrderStatus
 		rejectReason stypes.RejectReason
+		deferClaim   bool
 		expect       string
+		history      string // Last recorded history action
 		err          string
 	}{
 		{
@@ -51,6 +53,15 @@ func TestEventProcessor(t *testing.T) {
 			event:     solvernet.TopicFilled,
 			getStatus: solvernet.StatusFilled,
 			expect:    claim,
+			history:   historyClaim,
+		},
+		{
+			name:       "defer claim",
+			event:      solvernet.TopicFilled,
+			getStatus:  solvernet.StatusFilled,
+			deferClaim: true,
+			expect:     claim,
+			history:    historyDefer,
 		},
 		{
 			name:      "ignore rejected",
@@ -85,6 +96,7 @@ func TestEventProcessor(t *testing.T) {
 			const height = 123
 			orderID := tutil.RandomHash()
 			actual := ignored
+			var history []string
 
 			deps := procDeps{
 				GetOrder: func(ctx context.Context, chainID uint64, id OrderID) (Order, bool, error) {
@@ -117,13 +129,17 @@ func TestEventProcessor(t *testing.T) {
 
 					return nil
 				},
-				Claim: func(ctx context.Context, order Order) error {
+				Claim: func(ctx context.Context, order Order) (bool, error) {
 					actual = claim
 					require.Equal(t, test.getStatus, order.Status)
 					require.EqualValues(t, orderID, order.ID)
 
-					return nil
+					return test.deferClaim, nil
 				},
+				RecordHistory: func(_ context.Context, _ Order, entry historyEntry) {
+					history = append(history, entry.Action)
+				},
+				TrackFill:         func(context.Context, Order, Event) {},
 				ProcessorName:     func(uint64) string { return "" },
 				ChainName:         func(uint64) string { return "" },
 				TargetName:        func(PendingData) string { return "" },
@@ -148,6 +164,9 @@ func TestEventProcessor(t *testing.T) {
 			}
 			require.NoError(t, err)
 			require.Equal(t, test.expect, actual)
+			if test.history != "" {
+				require.Equal(t, test.history, history[len(history)-1])
+			}
 		})
 	}
 }
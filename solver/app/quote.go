@@ -6,63 +6,174 @@ import (
 	"net/http"
 
 	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/contracts/solvernet"
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/tokens"
 	"github.com/omni-network/omni/solver/types"
-)
 
-// standardFeeBips is the standard fee charged by the solver (0.3%).
-const standardFeeBips = 30
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type quoteFunc func(context.Context, types.QuoteRequest) (types.QuoteResponse, error)
 
 // quoter is quoteFunc that can be used to quoter an expense or deposit.
 // It is the logic behind the /quoter endpoint.
-func newQuoter(priceFunc priceFunc) quoteFunc {
+//
+// It supports multi-leg requests (a single deposit, M expenses) where exactly one
+// leg amount is omitted; that amount is quoted such that the deposit covers expenses.
+//
+// Quotes are rejected if the solver lacks free liquidity (not reserved by in-flight fills) to pay for expenses.
+// Firm quote requests additionally lock the quoted terms until expiry for the user's order (identified by nonce), see firmQuoteFunc.
+// Quotes are rejected while quoting is paused for any of the legs' chains or tokens.
+func newQuoter(priceFunc priceFunc, feeFunc feeFunc, checkLiquidity liquidityFunc, firmQuoteFunc firmQuoteFunc, isPaused pausedFunc) quoteFunc {
 	return func(ctx context.Context, req types.QuoteRequest) (types.QuoteResponse, error) {
 		returnErr := func(code int, msg string) (types.QuoteResponse, error) {
 			return types.QuoteResponse{}, newAPIError(errors.New(msg), code)
 		}
 
-		isDepositQuote := bi.IsZero(req.Deposit.Amount)
-		isExpenseQuote := bi.IsZero(req.Expense.Amount)
+		deposits, quoteDeposit, err := parseQuoteLegs(req.SourceChainID, []types.AddrAmt{req.Deposit})
+		if err != nil {
+			return returnErr(http.StatusNotFound, "unsupported deposit token")
+		}
 
-		if isDepositQuote == isExpenseQuote {
-			return returnErr(http.StatusBadRequest, "deposit and expense amount cannot be both zero or both non-zero")
+		expenses, quoteExpense, err := parseQuoteLegs(req.DestinationChainID, req.ExpenseLegs())
+		if err != nil {
+			return returnErr(http.StatusNotFound, "unsupported expense token")
 		}
 
-		depositTkn, ok := tokens.ByAddress(req.SourceChainID, req.Deposit.Token)
-		if !ok {
-			return returnErr(http.StatusNotFound, "unsupported deposit token")
+		if hasDuplicateTokens(expenses) {
+			return returnErr(http.StatusBadRequest, "duplicate expense token")
 		}
 
-		expenseTkn, ok := tokens.ByAddress(req.DestinationChainID, req.Expense.Token)
-		if !ok {
-			return returnErr(http.StatusNotFound, "unsupported expense token")
+		if err := checkPaused(isPaused, types.PauseQuote, req.SourceChainID, deposits, req.DestinationChainID, expenses); err != nil {
+			return types.QuoteResponse{}, err
 		}
 
-		// Get the price of the order
-		price, err := priceFunc(ctx, depositTkn, expenseTkn)
-		if err != nil {
-			return types.QuoteResponse{}, newAPIError(newRejection(types.RejectInvalidDeposit, err), http.StatusBadRequest)
+		if len(quoteDeposit)+len(quoteExpense) != 1 {
+			if !req.IsMultiLeg() {
+				return returnErr(http.StatusBadRequest, "deposit and expense amount cannot be both zero or both non-zero")
+			}
+
+			return returnErr(http.StatusBadRequest, "exactly one deposit or expense amount must be zero")
 		}
 
-		// Add solver fee to price
-		price = price.WithFeeBips(feeBips(depositTkn.Asset, expenseTkn.Asset))
+		var expenseFees []expenseFee
+		if len(quoteDeposit) == 1 {
+			// Quote the omitted deposit using the same logic as checkQuote, so quotes and checks match.
+			quote, fees, err := getQuote(ctx, priceFunc, feeFunc, deposits, expenses)
+			if err != nil {
+				return types.QuoteResponse{}, newAPIError(err, http.StatusBadRequest)
+			}
 
-		if isDepositQuote {
-			req.Deposit.Amount = price.ToDeposit(req.Expense.Amount)
+			deposits[0].Amount = quote[0].Amount
+			expenseFees = fees
 		} else {
-			req.Expense.Amount = price.ToExpense(req.Deposit.Amount)
+			i := quoteExpense[0]
+
+			amt, fees, err := quoteExpenseAmt(ctx, priceFunc, feeFunc, deposits, without(expenses, i), expenses[i].Token)
+			if err != nil {
+				return types.QuoteResponse{}, newAPIError(err, http.StatusBadRequest)
+			}
+
+			expenses[i].Amount = amt
+			expenseFees = fees
+		}
+
+		resp := quoteResponse(req, deposits, expenses)
+		resp.Fees = feesResponse(expenseFees)
+
+		if err := maybeMinMaxRejects(expenses); err != nil {
+			return resp, err
+		}
+
+		if err := checkLiquidity(ctx, expenses); err != nil {
+			return resp, err
+		}
+
+		if req.Firm {
+			if req.User == nil || *req.User == (common.Address{}) {
+				return returnErr(http.StatusBadRequest, "firm quotes require user")
+			}
+
+			nonce := bi.Zero()
+			if req.Nonce != nil {
+				nonce = req.Nonce.ToInt()
+			}
+
+			orderID := solvernet.EVMOrderID(*req.User, nonce, req.Gasless, req.SourceChainID)
+			firm, err := firmQuoteFunc(ctx, orderID, req.SourceChainID, req.DestinationChainID, deposits, expenses)
+			if err != nil {
+				return types.QuoteResponse{}, errors.Wrap(err, "firm quote")
+			}
+			resp.Firm = &firm
 		}
 
+		return resp, nil
+	}
+}
+
+// parseQuoteLegs resolves the tokens of the requested legs. It also returns
+// the indexes of legs with omitted (zero) amounts, which need to be quoted.
+func parseQuoteLegs(chainID uint64, legs []types.AddrAmt) ([]TokenAmt, []int, error) {
+	var resp []TokenAmt
+	var toQuote []int
+	for i, leg := range legs {
+		tkn, ok := tokens.ByAddress(chainID, leg.Token)
+		if !ok {
+			return nil, nil, errors.New("unsupported token", "token", leg.Token)
+		}
+
+		amt := leg.Amount
+		if amt == nil || bi.IsZero(amt) {
+			amt = bi.Zero()
+			toQuote = append(toQuote, i)
+		}
+
+		resp = append(resp, TokenAmt{Token: tkn, Amount: amt})
+	}
+
+	return resp, toQuote, nil
+}
+
+// quoteResponse returns the response for the quoted legs, populating either
+// single or multi-leg fields depending on the request.
+func quoteResponse(req types.QuoteRequest, deposits, expenses []TokenAmt) types.QuoteResponse {
+	toAddrAmts := func(legs []types.AddrAmt, quoted []TokenAmt) []types.AddrAmt {
+		resp := make([]types.AddrAmt, len(legs))
+		for i, leg := range legs {
+			resp[i] = types.AddrAmt{Token: leg.Token, Amount: quoted[i].Amount}
+		}
+
+		return resp
+	}
+
+	deposit := types.AddrAmt{Token: req.Deposit.Token, Amount: deposits[0].Amount}
+	expenseResps := toAddrAmts(req.ExpenseLegs(), expenses)
+
+	if req.IsMultiLeg() {
 		return types.QuoteResponse{
-			Deposit: req.Deposit,
-			Expense: req.Expense,
-		}, maybeMinMaxReject(req.Expense.Amount, expenseTkn)
+			Deposit:  deposit,
+			Expenses: expenseResps,
+		}
+	}
+
+	return types.QuoteResponse{
+		Deposit: deposit,
+		Expense: expenseResps[0],
 	}
 }
 
+// maybeMinMaxRejects returns the first min/max rejection of the expenses, if any.
+func maybeMinMaxRejects(expenses []TokenAmt) error {
+	for _, expense := range expenses {
+		if err := maybeMinMaxReject(expense.Amount, expense.Token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func maybeMinMaxReject(expenseAmt *big.Int, expenseTkn tokens.Token) error {
 	bounds, ok := GetSpendBounds(expenseTkn)
 	if !ok {
@@ -88,53 +199,169 @@ func maybeMinMaxReject(expenseAmt *big.Int, expenseTkn tokens.Token) error {
 	return nil
 }
 
-// getQuote returns payment in `depositTkns` required to pay for `expenses`.
-func getQuote(ctx context.Context, priceFunc priceFunc, depositTkns []tokens.Token, expenses []TokenAmt) ([]TokenAmt, error) {
-	if len(depositTkns) != 1 {
-		return nil, newRejection(types.RejectInvalidDeposit, errors.New("only single deposit token supported"))
+// expenseFee is the solver fee charged for an expense, paid in deposit tokens.
+type expenseFee struct {
+	Rule    FeeRule
+	Expense tokens.Token
+	Fee     TokenAmt
+}
+
+// feesResponse converts expense fees to the API response type.
+func feesResponse(fees []expenseFee) []types.Fee {
+	var resp []types.Fee
+	for _, f := range fees {
+		resp = append(resp, types.Fee{
+			Rule:    f.Rule.Name,
+			Bips:    f.Rule.Bips,
+			Expense: f.Expense.Address,
+			Token:   f.Fee.Token.Address,
+			Amount:  f.Fee.Amount,
+		})
+	}
+
+	return resp
+}
+
+// getQuote returns payment in the deposit token required to pay for `expenses`, as well as the fee per expense.
+// Only a single deposit is supported, since orders only support a single deposit.
+func getQuote(ctx context.Context, priceFunc priceFunc, feeFunc feeFunc, deposits []TokenAmt, expenses []TokenAmt) ([]TokenAmt, []expenseFee, error) {
+	if err := checkSingleDeposit(deposits); err != nil {
+		return nil, nil, err
+	}
+
+	if len(expenses) == 0 {
+		return nil, nil, newRejection(types.RejectInvalidExpense, errors.New("no expenses"))
+	}
+
+	refTkn := deposits[0].Token
+
+	required := bi.Zero()
+	var fees []expenseFee
+	for _, expense := range expenses {
+		deposit, fee, err := quoteDeposit(ctx, priceFunc, feeFunc, refTkn, expense)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		required = bi.Add(required, deposit.Amount)
+		fees = append(fees, fee)
 	}
 
-	if len(expenses) != 1 {
-		return nil, newRejection(types.RejectInvalidExpense, errors.New("only single expense supported"))
+	return []TokenAmt{{Token: refTkn, Amount: required}}, fees, nil
+}
+
+// quoteExpenseAmt returns the amount of `expenseTkn` that the deposit pays for, after paying for `otherExpenses`.
+// It also returns the fee per expense.
+func quoteExpenseAmt(
+	ctx context.Context,
+	priceFunc priceFunc,
+	feeFunc feeFunc,
+	deposits []TokenAmt,
+	otherExpenses []TokenAmt,
+	expenseTkn tokens.Token,
+) (*big.Int, []expenseFee, error) {
+	if err := checkSingleDeposit(deposits); err != nil {
+		return nil, nil, err
 	}
 
-	expense := expenses[0]
-	depositTkn := depositTkns[0]
+	refTkn := deposits[0].Token
+	available := deposits[0].Amount
 
-	deposit, err := quoteDeposit(ctx, priceFunc, depositTkn, expense)
+	var fees []expenseFee
+	for _, expense := range otherExpenses {
+		deposit, fee, err := quoteDeposit(ctx, priceFunc, feeFunc, refTkn, expense)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		available = bi.Sub(available, deposit.Amount)
+		fees = append(fees, fee)
+	}
+
+	if available.Sign() <= 0 {
+		return nil, nil, newRejection(types.RejectInsufficientDeposit, errors.New("deposits do not cover other expenses"))
+	}
+
+	price, err := priceFunc(ctx, refTkn, expenseTkn)
+	if err != nil {
+		return nil, nil, newRejection(types.RejectInvalidDeposit, err)
+	}
+
+	// Size the fee using the expense amount before fees.
+	rule, err := feeFunc(ctx, refTkn, TokenAmt{Token: expenseTkn, Amount: price.ToExpense(available)})
 	if err != nil {
-		return nil, err
+		return nil, nil, errors.Wrap(err, "get fee")
+	}
+
+	// Add fee to price.
+	amt := price.WithFeeBips(rule.Bips).ToExpense(available)
+
+	fees = append(fees, expenseFee{
+		Rule:    rule,
+		Expense: expenseTkn,
+		Fee:     TokenAmt{Token: refTkn, Amount: bi.Sub(available, price.ToDeposit(amt))},
+	})
+
+	return amt, fees, nil
+}
+
+// checkSingleDeposit returns a rejection unless there is exactly one deposit.
+func checkSingleDeposit(deposits []TokenAmt) error {
+	if len(deposits) == 0 {
+		return newRejection(types.RejectInvalidDeposit, errors.New("no deposits"))
+	} else if len(deposits) > 1 {
+		return newRejection(types.RejectInvalidDeposit, errors.New("multiple deposits not supported"))
 	}
 
-	return []TokenAmt{deposit}, nil
+	return nil
 }
 
-// quoteDeposit returns the source chain deposit required to cover `expense`.
-func quoteDeposit(ctx context.Context, priceFunc priceFunc, depositTkn tokens.Token, expense TokenAmt) (TokenAmt, error) {
+// quoteDeposit returns the source chain deposit required to cover `expense`, as well as the fee charged.
+func quoteDeposit(ctx context.Context, priceFunc priceFunc, feeFunc feeFunc, depositTkn tokens.Token, expense TokenAmt) (TokenAmt, expenseFee, error) {
 	price, err := priceFunc(ctx, depositTkn, expense.Token)
 	if err != nil {
-		return TokenAmt{}, newRejection(types.RejectInvalidDeposit, errors.Wrap(err, "", "expense", expense, "deposit", depositTkn))
+		return TokenAmt{}, expenseFee{}, newRejection(types.RejectInvalidDeposit, errors.Wrap(err, "", "expense", expense, "deposit", depositTkn))
+	}
+
+	rule, err := feeFunc(ctx, depositTkn, expense)
+	if err != nil {
+		return TokenAmt{}, expenseFee{}, errors.Wrap(err, "get fee")
 	}
 
 	// Add fee to price.
-	price = price.WithFeeBips(feeBips(depositTkn.Asset, expense.Token.Asset))
+	amt := price.WithFeeBips(rule.Bips).ToDeposit(expense.Amount)
 
 	return TokenAmt{
 		Token:  depositTkn,
-		Amount: price.ToDeposit(expense.Amount),
+		Amount: amt,
+	}, expenseFee{
+		Rule:    rule,
+		Expense: expense.Token,
+		Fee:     TokenAmt{Token: depositTkn, Amount: bi.Sub(amt, price.ToDeposit(expense.Amount))},
 	}, nil
 }
 
-func areEqualBySymbol(a, b tokens.Token) bool {
-	return a.Symbol == b.Symbol
+// without returns a copy of `ps` without the element at index `i`.
+func without(ps []TokenAmt, i int) []TokenAmt {
+	resp := make([]TokenAmt, 0, len(ps)-1)
+	resp = append(resp, ps[:i]...)
+
+	return append(resp, ps[i+1:]...)
 }
 
-// feeBips returns the fee in bips for a given pair.
-func feeBips(a, b tokens.Asset) int64 {
-	// if OMNI<>OMNI, charge no fee
-	if a == tokens.OMNI && b == tokens.OMNI {
-		return 0
+// hasDuplicateTokens returns true if `ps` contains the same token more than once.
+func hasDuplicateTokens(ps []TokenAmt) bool {
+	seen := make(map[tokens.Token]bool)
+	for _, p := range ps {
+		if seen[p.Token] {
+			return true
+		}
+		seen[p.Token] = true
 	}
 
-	return standardFeeBips
+	return false
+}
+
+func areEqualBySymbol(a, b tokens.Token) bool {
+	return a.Symbol == b.Symbol
 }
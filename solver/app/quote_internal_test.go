@@ -18,6 +18,7 @@ import (
 	"github.com/omni-network/omni/solver/types"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	fuzz "github.com/google/gofuzz"
 	"github.com/stretchr/testify/require"
@@ -105,6 +106,10 @@ func TestQuote(t *testing.T) {
 	t.Parallel()
 
 	omegaOMNIAddr := omniERC20(netconf.Omega).Address
+	baseUSDCAddr := erc20(evmchain.IDBase, tokens.USDC).Address
+	arbUSDCAddr := erc20(evmchain.IDArbitrumOne, tokens.USDC).Address
+	// Single-leg fields are omitted from multi-leg responses, unmarshalling to zero amounts.
+	unsetAddrAmt := types.AddrAmt{Amount: bi.Zero()}
 
 	tests := []struct {
 		name     string
@@ -124,6 +129,7 @@ func TestQuote(t *testing.T) {
 			res: types.QuoteResponse{
 				Deposit: mockAddrAmt("1003000000000000000"),
 				Expense: mockAddrAmt("1000000000000000000"),
+				Fees:    []types.Fee{{Rule: "default", Bips: 30, Amount: parseInt("3000000000000000")}},
 			},
 			testdata: true,
 		},
@@ -179,6 +185,7 @@ func TestQuote(t *testing.T) {
 			res: types.QuoteResponse{
 				Deposit: types.AddrAmt{Amount: parseInt("10000000000000000000"), Token: omegaOMNIAddr},
 				Expense: mockAddrAmt("10000000000000000000"),
+				Fees:    []types.Fee{{Rule: "omni", Bips: 0, Token: omegaOMNIAddr, Amount: hexutil.MustDecodeBig("0x0")}},
 			},
 			testdata: true,
 		},
@@ -321,12 +328,109 @@ func TestQuote(t *testing.T) {
 			},
 			testdata: true,
 		},
+		{
+			name: "multi-leg quote deposit",
+			req: types.QuoteRequest{
+				SourceChainID:      evmchain.IDBase,
+				DestinationChainID: evmchain.IDArbitrumOne,
+				Deposit:            zeroAddrAmt,
+				Expenses: []types.AddrAmt{
+					mockAddrAmt("1000000000000000000"),
+					{Token: arbUSDCAddr, Amount: bi.Dec6(1500)},
+				},
+			},
+			res: types.QuoteResponse{
+				Deposit: mockAddrAmt("1504500000000000000"), // (1 ETH + 1500 USDC (0.5 ETH)) * 1.003
+				Expense: unsetAddrAmt,
+				Expenses: []types.AddrAmt{
+					mockAddrAmt("1000000000000000000"),
+					{Token: arbUSDCAddr, Amount: bi.Dec6(1500)},
+				},
+			},
+			testdata: true,
+		},
+		{
+			name: "multi-leg quote expense",
+			req: types.QuoteRequest{
+				SourceChainID:      evmchain.IDBase,
+				DestinationChainID: evmchain.IDArbitrumOne,
+				Deposit:            mockAddrAmt("1003000000000000000"),
+				Expenses: []types.AddrAmt{
+					{Token: arbUSDCAddr, Amount: bi.Dec6(1500)},
+					zeroAddrAmt,
+				},
+			},
+			res: types.QuoteResponse{
+				Deposit: mockAddrAmt("1003000000000000000"),
+				Expense: unsetAddrAmt,
+				Expenses: []types.AddrAmt{
+					{Token: arbUSDCAddr, Amount: bi.Dec6(1500)},
+					mockAddrAmt("500000000000000000"), // 1.003 ETH - 1504.5 USDC (0.5015 ETH) / 1.003
+				},
+				Fees: []types.Fee{
+					{Rule: "default", Bips: 30, Expense: arbUSDCAddr, Amount: parseInt("1500000000000000")},
+					{Rule: "default", Bips: 30, Amount: parseInt("1500000000000000")},
+				},
+			},
+		},
+		{
+			name: "multi-leg expense over max",
+			req: types.QuoteRequest{
+				SourceChainID:      evmchain.IDBase,
+				DestinationChainID: evmchain.IDArbitrumOne,
+				Deposit:            types.AddrAmt{Token: baseUSDCAddr},
+				Expenses: []types.AddrAmt{
+					{Token: arbUSDCAddr, Amount: bi.Dec6(10)},
+					{Amount: bi.Ether(10)},
+				},
+			},
+			res: types.QuoteResponse{
+				Deposit: types.AddrAmt{Token: baseUSDCAddr, Amount: bi.Dec6(30100.03)},
+				Expense: unsetAddrAmt,
+				Expenses: []types.AddrAmt{
+					{Token: arbUSDCAddr, Amount: bi.Dec6(10)},
+					{Amount: bi.Ether(10)},
+				},
+				Rejected:          true,
+				RejectCode:        types.RejectExpenseOverMax,
+				RejectReason:      types.RejectExpenseOverMax.String(),
+				RejectDescription: "requested expense exceeds maximum [ask=10 ETH, max=6 ETH]",
+			},
+		},
+		{
+			name: "multi-leg multiple amounts omitted",
+			req: types.QuoteRequest{
+				SourceChainID:      evmchain.IDBase,
+				DestinationChainID: evmchain.IDArbitrumOne,
+				Deposit:            zeroAddrAmt,
+				Expenses:           []types.AddrAmt{mockAddrAmt("1000000000000000000"), {Token: arbUSDCAddr}},
+			},
+			expErr: types.JSONError{
+				Code:    http.StatusBadRequest,
+				Status:  http.StatusText(http.StatusBadRequest),
+				Message: "exactly one deposit or expense amount must be zero",
+			},
+		},
+		{
+			name: "multi-leg duplicate expense token",
+			req: types.QuoteRequest{
+				SourceChainID:      evmchain.IDBase,
+				DestinationChainID: evmchain.IDArbitrumOne,
+				Deposit:            zeroAddrAmt,
+				Expenses:           []types.AddrAmt{mockAddrAmt("1000000000000000000"), mockAddrAmt("1000000000000000000")},
+			},
+			expErr: types.JSONError{
+				Code:    http.StatusBadRequest,
+				Status:  http.StatusText(http.StatusBadRequest),
+				Message: "duplicate expense token",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			priceFunc := newPriceFunc(tokenpricer.NewDevnetMock())
-			srv := httptest.NewServer(handlerAdapter(newQuoteHandler(newQuoter(priceFunc))))
+			srv := httptest.NewServer(handlerAdapter(newQuoteHandler(newQuoter(priceFunc, testFeeFunc(), anyLiquidity, nil, notPaused))))
 
 			var reqBody, respBody []byte
 			cl := client.New(srv.URL, client.WithDebugBodies(
@@ -336,6 +440,9 @@ func TestQuote(t *testing.T) {
 
 			resp, err := cl.Quote(t.Context(), tt.req)
 			if err == nil {
+				if tt.res.Fees == nil {
+					resp.Fees = nil // Only assert fee breakdown if explicitly expected.
+				}
 				require.Equal(t, tt.res, resp)
 			} else {
 				var errResp types.JSONErrorResponse
@@ -351,6 +458,55 @@ func TestQuote(t *testing.T) {
 	}
 }
 
+func TestCheckQuoteMultiLeg(t *testing.T) {
+	t.Parallel()
+
+	priceFunc := newPriceFunc(tokenpricer.NewDevnetMock())
+
+	eth := mustNative(t, evmchain.IDBase)
+	usdc := erc20(evmchain.IDBase, tokens.USDC)
+	arbETH := mustNative(t, evmchain.IDArbitrumOne)
+	arbUSDC := erc20(evmchain.IDArbitrumOne, tokens.USDC)
+
+	expenses := []TokenAmt{
+		{Token: arbETH, Amount: bi.Ether(1)},
+		{Token: arbUSDC, Amount: bi.Dec6(1000)},
+	}
+
+	// The quoter and the check price the deposit identically, charging fees on every expense.
+	quote, fees, err := getQuote(t.Context(), priceFunc, testFeeFunc(), []TokenAmt{{Token: eth}}, expenses)
+	require.NoError(t, err)
+	require.Len(t, fees, len(expenses))
+	for _, fee := range fees {
+		require.Equal(t, eth, fee.Fee.Token)
+		require.Positive(t, fee.Fee.Amount.Sign())
+	}
+
+	err = checkQuote(t.Context(), priceFunc, testFeeFunc(), quote, expenses)
+	require.NoError(t, err)
+
+	err = checkQuote(t.Context(), priceFunc, testFeeFunc(), []TokenAmt{
+		{Token: eth, Amount: bi.Sub(quote[0].Amount, bi.N(1))},
+	}, expenses)
+	require.ErrorContains(t, err, "InsufficientDeposit")
+
+	// Orders only support a single deposit.
+	err = checkQuote(t.Context(), priceFunc, testFeeFunc(), []TokenAmt{
+		{Token: eth, Amount: quote[0].Amount},
+		{Token: usdc, Amount: bi.Dec6(1000)},
+	}, expenses)
+	require.ErrorContains(t, err, "multiple deposits not supported")
+}
+
+func mustNative(t *testing.T, chainID uint64) tokens.Token {
+	t.Helper()
+
+	tkn, ok := tokens.Native(chainID)
+	require.True(t, ok)
+
+	return tkn
+}
+
 func TestFees(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,300 @@
+package app
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// headerAPIKey is the request header identifying API key clients.
+	headerAPIKey = "X-API-Key"
+
+	// rateLimitAll is the rate limit endpoint matching all endpoints without a specific limit.
+	rateLimitAll = "*"
+
+	// rateLimitSimulate is the rate limit endpoint of check fill simulations. Simulations are limited
+	// in addition to the /check endpoint, since each resets and transacts on a local anvil fork.
+	rateLimitSimulate = "simulate"
+
+	classIP     = "ip"
+	classAPIKey = "api_key"
+
+	// rateLimitPruneInterval is the interval at which idle client buckets are pruned.
+	rateLimitPruneInterval = time.Minute
+)
+
+// defaultSimulateLimit is the simulation rate limit, unless configured.
+var defaultSimulateLimit = RateLimit{Endpoint: rateLimitSimulate, IPRate: 1.0 / 60, IPBurst: 5, KeyRate: 1, KeyBurst: 10}
+
+// RateLimit defines the token bucket rate limits of an API endpoint per client class.
+// Clients are identified by API key if provided, otherwise by IP.
+// Zero rates disable limiting of that class.
+type RateLimit struct {
+	Endpoint string  `toml:"endpoint"`  // Endpoint path, e.g. "/api/v1/check", "*" for all endpoints without a specific limit, or "simulate" for check simulations
+	IPRate   float64 `toml:"ip-rate"`   // Requests per second per client IP
+	IPBurst  int     `toml:"ip-burst"`  // Max burst of requests per client IP
+	KeyRate  float64 `toml:"key-rate"`  // Requests per second per API key
+	KeyBurst int     `toml:"key-burst"` // Max burst of requests per API key
+}
+
+func (l RateLimit) Validate() error {
+	if l.Endpoint == "" {
+		return errors.New("missing endpoint")
+	}
+
+	if l.IPRate < 0 || l.KeyRate < 0 {
+		return errors.New("negative rate", "endpoint", l.Endpoint)
+	}
+
+	if (l.IPRate > 0 && l.IPBurst <= 0) || (l.KeyRate > 0 && l.KeyBurst <= 0) {
+		return errors.New("burst must be positive", "endpoint", l.Endpoint)
+	}
+
+	return nil
+}
+
+// limit returns the rate and burst of the client class, or false if the class isn't limited.
+func (l RateLimit) limit(class string) (rate.Limit, int, bool) {
+	if class == classAPIKey {
+		return rate.Limit(l.KeyRate), l.KeyBurst, l.KeyRate > 0
+	}
+
+	return rate.Limit(l.IPRate), l.IPBurst, l.IPRate > 0
+}
+
+// RateLimits defines the API rate limits per endpoint and the known API keys.
+type RateLimits struct {
+	APIKeys []string `toml:"api-keys"` // Known API keys, provided via the X-API-Key header
+	// TrustedProxyHops is the number of trusted proxies (e.g. load balancers) in front of the solver,
+	// each appending the address it received the request from to X-Forwarded-For.
+	// Zero limits clients by remote address, ignoring X-Forwarded-For.
+	TrustedProxyHops int         `toml:"trusted-proxy-hops"`
+	Limits           []RateLimit `toml:"limits"`
+}
+
+func (l RateLimits) Validate() error {
+	if l.TrustedProxyHops < 0 {
+		return errors.New("negative trusted proxy hops")
+	}
+
+	dups := make(map[string]bool)
+	for _, limit := range l.Limits {
+		if err := limit.Validate(); err != nil {
+			return err
+		}
+
+		if dups[limit.Endpoint] {
+			return errors.New("duplicate endpoint limit", "endpoint", limit.Endpoint)
+		}
+		dups[limit.Endpoint] = true
+	}
+
+	for _, key := range l.APIKeys {
+		if key == "" {
+			return errors.New("empty api key")
+		}
+	}
+
+	return nil
+}
+
+// LoadRateLimits loads and validates a TOML rate limits file.
+// No limits are returned if path is empty.
+func LoadRateLimits(path string) (RateLimits, error) {
+	if path == "" {
+		return RateLimits{}, nil
+	}
+
+	var resp RateLimits
+	if _, err := toml.DecodeFile(path, &resp); err != nil {
+		return RateLimits{}, errors.Wrap(err, "decode rate limits", "path", path)
+	}
+
+	if err := resp.Validate(); err != nil {
+		return RateLimits{}, errors.Wrap(err, "validate rate limits", "path", path)
+	}
+
+	return resp, nil
+}
+
+type bucketKey struct {
+	Endpoint string
+	Class    string
+	Client   string
+}
+
+// rateLimiter limits API requests using token buckets per endpoint and client.
+type rateLimiter struct {
+	limits    map[string]RateLimit
+	keys      map[string]bool
+	proxyHops int
+	now       func() time.Time
+
+	mu         sync.Mutex
+	buckets    map[bucketKey]*rate.Limiter
+	lastPruned time.Time
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	byEndpoint := map[string]RateLimit{rateLimitSimulate: defaultSimulateLimit}
+	for _, limit := range limits.Limits {
+		byEndpoint[limit.Endpoint] = limit
+	}
+
+	keys := make(map[string]bool)
+	for _, key := range limits.APIKeys {
+		keys[key] = true
+	}
+
+	return &rateLimiter{
+		limits:    byEndpoint,
+		keys:      keys,
+		proxyHops: limits.TrustedProxyHops,
+		now:       time.Now,
+		buckets:   make(map[bucketKey]*rate.Limiter),
+	}
+}
+
+// Wrap returns a handler that rate limits requests to the endpoint,
+// responding with 429 and a Retry-After header if the client exceeded its limit.
+// Requests with unknown API keys to limited endpoints are unauthorized.
+// The client is added to the request context, for limiting nested operations, see WrapSimulate.
+func (l *rateLimiter) Wrap(endpoint string, handler http.Handler) http.Handler {
+	limit, limited := l.limits[endpoint]
+	if !limited {
+		limit, limited = l.limits[rateLimitAll]
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class, client := classIP, limitedIP(r, l.proxyHops)
+		if key := r.Header.Get(headerAPIKey); key != "" {
+			if l.keys[key] {
+				class, client = classAPIKey, key
+			} else if limited {
+				writeErrResponse(r.Context(), w, newAPIError(errors.New("invalid api key"), http.StatusUnauthorized))
+				return
+			}
+		}
+
+		if limited {
+			if delay, ok := l.allow(bucketKey{Endpoint: endpoint, Class: class, Client: client}, limit); !ok {
+				apiRateLimited.WithLabelValues(endpoint, class).Inc()
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+				writeErrResponse(r.Context(), w, newAPIError(errors.New("rate limit exceeded"), http.StatusTooManyRequests))
+
+				return
+			}
+		}
+
+		ctx := context.WithValue(r.Context(), rateLimitClientKey{}, bucketKey{Class: class, Client: client})
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// rateLimitClientKey is the context key of the rate limited client (bucketKey without endpoint).
+type rateLimitClientKey struct{}
+
+// WrapSimulate returns a simulateFunc that rate limits simulations per client, as identified by Wrap.
+// Clients not identified by Wrap share a single bucket.
+func (l *rateLimiter) WrapSimulate(simulate simulateFunc) simulateFunc {
+	limit := l.limits[rateLimitSimulate]
+
+	return func(ctx context.Context, req types.CheckRequest) (types.Simulation, error) {
+		key, ok := ctx.Value(rateLimitClientKey{}).(bucketKey)
+		if !ok {
+			key = bucketKey{Class: classIP}
+		}
+		key.Endpoint = rateLimitSimulate
+
+		if delay, ok := l.allow(key, limit); !ok {
+			apiRateLimited.WithLabelValues(rateLimitSimulate, key.Class).Inc()
+			return types.Simulation{}, errors.New("simulation rate limit exceeded", "retry_after", delay.Round(time.Second))
+		}
+
+		return simulate(ctx, req)
+	}
+}
+
+// allow consumes a token from the client's bucket. It returns false and the delay
+// until a token is available if the bucket is empty.
+func (l *rateLimiter) allow(key bucketKey, limit RateLimit) (time.Duration, bool) {
+	r, burst, ok := limit.limit(key.Class)
+	if !ok {
+		return 0, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.maybePrune(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(r, burst)
+		l.buckets[key] = bucket
+	}
+
+	reservation := bucket.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay, false
+	}
+
+	return 0, true
+}
+
+// maybePrune deletes full buckets, since they are equivalent to new buckets.
+// This bounds memory to recently active clients.
+func (l *rateLimiter) maybePrune(now time.Time) {
+	if now.Sub(l.lastPruned) < rateLimitPruneInterval {
+		return
+	}
+	l.lastPruned = now
+
+	for key, bucket := range l.buckets {
+		if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// limitedIP returns the client IP to rate limit by.
+// Behind trusted proxies, it is the right-most X-Forwarded-For entry appended by the outermost
+// trusted proxy, since entries to its left are client controlled. Otherwise, or if the request
+// didn't pass through all trusted proxies, it is the remote address host.
+func limitedIP(r *http.Request, trustedHops int) string {
+	if trustedHops > 0 {
+		var entries []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, entry := range strings.Split(header, ",") {
+				entries = append(entries, strings.TrimSpace(entry))
+			}
+		}
+
+		if len(entries) >= trustedHops {
+			if ip := entries[len(entries)-trustedHops]; ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
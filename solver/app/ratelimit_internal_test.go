@@ -0,0 +1,212 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRateLimits(t *testing.T) {
+	t.Parallel()
+
+	write := func(content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "ratelimits.toml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		return path
+	}
+
+	limits, err := LoadRateLimits(write(`
+api-keys = ["partner"]
+trusted-proxy-hops = 1
+
+[[limits]]
+endpoint = "/api/v1/check"
+ip-rate = 0.5
+ip-burst = 2
+key-rate = 10
+key-burst = 20
+`))
+	require.NoError(t, err)
+	require.Equal(t, RateLimits{
+		APIKeys:          []string{"partner"},
+		TrustedProxyHops: 1,
+		Limits:           []RateLimit{{Endpoint: endpointCheck, IPRate: 0.5, IPBurst: 2, KeyRate: 10, KeyBurst: 20}},
+	}, limits)
+
+	limits, err = LoadRateLimits("")
+	require.NoError(t, err)
+	require.Empty(t, limits.Limits)
+
+	_, err = LoadRateLimits(write("[[limits]]\nendpoint = \"*\"\nip-rate = 1\n"))
+	require.ErrorContains(t, err, "burst must be positive")
+
+	_, err = LoadRateLimits(write("[[limits]]\nendpoint = \"*\"\n[[limits]]\nendpoint = \"*\"\n"))
+	require.ErrorContains(t, err, "duplicate endpoint limit")
+
+	_, err = LoadRateLimits(write("trusted-proxy-hops = -1\n"))
+	require.ErrorContains(t, err, "negative trusted proxy hops")
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter(RateLimits{
+		APIKeys:          []string{"partner"},
+		TrustedProxyHops: 1,
+		Limits: []RateLimit{
+			{Endpoint: endpointCheck, IPRate: 1, IPBurst: 2, KeyRate: 10, KeyBurst: 5},
+			{Endpoint: rateLimitAll, IPRate: 1, IPBurst: 1},
+		},
+	})
+
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	check := limiter.Wrap(endpointCheck, ok)
+	quote := limiter.Wrap(endpointQuote, ok)
+
+	serve := func(handler http.Handler, ip string, key string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("X-Forwarded-For", ip)
+		if key != "" {
+			req.Header.Set(headerAPIKey, key)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		return w
+	}
+
+	// IP burst
+	require.Equal(t, http.StatusOK, serve(check, "1.1.1.1", "").Code)
+	require.Equal(t, http.StatusOK, serve(check, "1.1.1.1", "").Code)
+	limited := serve(check, "1.1.1.1", "")
+	require.Equal(t, http.StatusTooManyRequests, limited.Code)
+	require.Equal(t, "1", limited.Header().Get("Retry-After"))
+
+	// Other IPs and endpoints have separate buckets
+	require.Equal(t, http.StatusOK, serve(check, "2.2.2.2", "").Code)
+	require.Equal(t, http.StatusOK, serve(quote, "1.1.1.1", "").Code)
+	require.Equal(t, http.StatusTooManyRequests, serve(quote, "1.1.1.1", "").Code)
+
+	// API keys have their own limits, regardless of IP
+	for range 5 {
+		require.Equal(t, http.StatusOK, serve(check, "1.1.1.1", "partner").Code)
+	}
+	require.Equal(t, http.StatusTooManyRequests, serve(check, "3.3.3.3", "partner").Code)
+	require.Equal(t, http.StatusUnauthorized, serve(check, "1.1.1.1", "unknown").Code)
+
+	// Buckets refill
+	now = now.Add(time.Second)
+	require.Equal(t, http.StatusOK, serve(check, "1.1.1.1", "").Code)
+	require.Equal(t, http.StatusTooManyRequests, serve(check, "1.1.1.1", "").Code)
+
+	// Full buckets are pruned
+	now = now.Add(rateLimitPruneInterval)
+	require.Equal(t, http.StatusOK, serve(check, "1.1.1.1", "").Code)
+	require.Len(t, limiter.buckets, 1)
+
+	// Endpoints without limits are not wrapped
+	unlimited := newRateLimiter(RateLimits{TrustedProxyHops: 1, Limits: []RateLimit{{Endpoint: endpointCheck, IPRate: 1, IPBurst: 1}}})
+	for range 5 {
+		require.Equal(t, http.StatusOK, serve(unlimited.Wrap(endpointQuote, ok), "1.1.1.1", "").Code)
+	}
+}
+
+func TestLimitedIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		hops       int
+		expect     string
+	}{
+		{name: "remote addr", remoteAddr: "1.1.1.1:1234", xff: []string{"2.2.2.2"}, expect: "1.1.1.1"},
+		{name: "remote addr ipv6", remoteAddr: "[::1]:1234", expect: "::1"},
+		{name: "remote addr without port", remoteAddr: "1.1.1.1", expect: "1.1.1.1"},
+		{name: "single proxy", remoteAddr: "10.0.0.1:1234", xff: []string{"2.2.2.2"}, hops: 1, expect: "2.2.2.2"},
+		{name: "spoofed entries ignored", remoteAddr: "10.0.0.1:1234", xff: []string{"6.6.6.6, 2.2.2.2"}, hops: 1, expect: "2.2.2.2"},
+		{name: "multiple headers", remoteAddr: "10.0.0.1:1234", xff: []string{"6.6.6.6", "2.2.2.2"}, hops: 1, expect: "2.2.2.2"},
+		{name: "two proxies", remoteAddr: "10.0.0.2:1234", xff: []string{"6.6.6.6,2.2.2.2, 10.0.0.1"}, hops: 2, expect: "2.2.2.2"},
+		{name: "bypassed proxy", remoteAddr: "3.3.3.3:1234", xff: []string{"2.2.2.2"}, hops: 2, expect: "3.3.3.3"},
+		{name: "missing header", remoteAddr: "3.3.3.3:1234", hops: 1, expect: "3.3.3.3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.RemoteAddr = test.remoteAddr
+			for _, xff := range test.xff {
+				req.Header.Add("X-Forwarded-For", xff)
+			}
+
+			require.Equal(t, test.expect, limitedIP(req, test.hops))
+		})
+	}
+}
+
+func TestWrapSimulate(t *testing.T) {
+	t.Parallel()
+
+	newSimulate := func(limiter *rateLimiter) func(ip string, key string) error {
+		simulate := limiter.WrapSimulate(func(context.Context, types.CheckRequest) (types.Simulation, error) {
+			return types.Simulation{}, nil
+		})
+
+		return func(ip string, key string) error {
+			t.Helper()
+
+			var err error
+			handler := limiter.Wrap(endpointCheck, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				_, err = simulate(r.Context(), types.CheckRequest{})
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("X-Forwarded-For", ip)
+			if key != "" {
+				req.Header.Set(headerAPIKey, key)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			return err
+		}
+	}
+
+	// Simulations are limited by default, even if the endpoint is not
+	simulate := newSimulate(newRateLimiter(RateLimits{APIKeys: []string{"partner"}, TrustedProxyHops: 1}))
+	for range defaultSimulateLimit.IPBurst {
+		require.NoError(t, simulate("1.1.1.1", ""))
+	}
+	require.ErrorContains(t, simulate("1.1.1.1", ""), "simulation rate limit exceeded")
+
+	// Other IPs and API keys have separate buckets
+	require.NoError(t, simulate("2.2.2.2", ""))
+	for range defaultSimulateLimit.KeyBurst {
+		require.NoError(t, simulate("1.1.1.1", "partner"))
+	}
+	require.ErrorContains(t, simulate("2.2.2.2", "partner"), "simulation rate limit exceeded")
+
+	// Configured limits override the default
+	simulate = newSimulate(newRateLimiter(RateLimits{
+		TrustedProxyHops: 1,
+		Limits:           []RateLimit{{Endpoint: rateLimitSimulate, IPRate: 1, IPBurst: 1}},
+	}))
+	require.NoError(t, simulate("1.1.1.1", ""))
+	require.ErrorContains(t, simulate("1.1.1.1", ""), "simulation rate limit exceeded")
+}
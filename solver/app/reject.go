@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/omni-network/omni/contracts/bindings"
 	"github.com/omni-network/omni/lib/bi"
@@ -12,9 +13,7 @@ import (
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/ethclient"
 	"github.com/omni-network/omni/lib/tokens"
-	"github.com/omni-network/omni/lib/tokens/tokenutil"
 	"github.com/omni-network/omni/lib/tracer"
-	"github.com/omni-network/omni/lib/uni"
 	"github.com/omni-network/omni/lib/unibackend"
 	"github.com/omni-network/omni/solver/types"
 
@@ -59,6 +58,11 @@ func newShouldRejector(
 	backends unibackend.Backends,
 	isAllowedCall callAllowFunc,
 	priceFunc priceFunc,
+	feeFunc feeFunc,
+	isFirm isFirmFunc,
+	orderOpenedAt openedAtFunc,
+	inv *inventory,
+	isPaused pausedFunc,
 	solverAddr, outboxAddr common.Address,
 ) func(ctx context.Context, order Order) (types.RejectReason, bool, error) {
 	return func(ctx context.Context, order Order) (types.RejectReason, bool, error) {
@@ -96,11 +100,20 @@ func newShouldRejector(
 				return err
 			}
 
-			if err := checkQuote(ctx, priceFunc, deposits, expenses); err != nil {
+			if err := checkPaused(isPaused, types.PauseFill, order.SourceChainID, deposits, pendingData.DestinationChainID, expenses); err != nil {
 				return err
 			}
 
-			if err := checkLiquidity(ctx, expenses, backend, solverAddr); err != nil {
+			openedAt, ok := orderOpenedAt(order.ID)
+			if !ok {
+				openedAt = time.Now() // Best-effort, only honours firm quotes not yet expired
+			}
+
+			if err := checkFirmOrQuote(ctx, priceFunc, feeFunc, isFirm, order.ID, openedAt, order.SourceChainID, pendingData.DestinationChainID, deposits, expenses); err != nil {
+				return err
+			}
+
+			if err := reserveLiquidity(ctx, order.ID, expenses, backend, solverAddr, inv); err != nil {
 				return err
 			}
 
@@ -122,6 +135,9 @@ func newShouldRejector(
 			return types.RejectNone, false, nil
 		}
 
+		// Release any inventory reserved for orders not being filled.
+		inv.Release(order.ID)
+
 		r := new(RejectionError)
 		if !errors.As(err, &r) { // Error, but no rejection
 			return types.RejectNone, false, err
@@ -346,9 +362,9 @@ func nativeAmt(ps []TokenAmt) *big.Int {
 }
 
 // checkQuote checks if deposits match or exceed quote for expenses.
-// Only a single deposit and expense supported.
-func checkQuote(ctx context.Context, priceFunc priceFunc, deposits, expenses []TokenAmt) error {
-	quote, err := getQuote(ctx, priceFunc, tkns(deposits), expenses)
+// Multiple expenses are supported, each expense is priced (incl. fees) in the single deposit token.
+func checkQuote(ctx context.Context, priceFunc priceFunc, feeFunc feeFunc, deposits, expenses []TokenAmt) error {
+	quote, _, err := getQuote(ctx, priceFunc, feeFunc, deposits, expenses)
 	if err != nil {
 		return err
 	}
@@ -356,30 +372,47 @@ func checkQuote(ctx context.Context, priceFunc priceFunc, deposits, expenses []T
 	return coversQuote(deposits, quote)
 }
 
-// checkLiquidity checks that the solver has enough liquidity to pay for the expenses.
-func checkLiquidity(ctx context.Context, expenses []TokenAmt, backend unibackend.Backend, solverAddr common.Address) error {
-	for _, expense := range expenses {
-		bal, err := tokenutil.UniBalanceOf(ctx, backend, expense.Token, uni.EVMAddress(solverAddr))
-		if err != nil {
-			return errors.Wrap(err, "get balance", "token", expense.Token.Symbol)
-		}
+// checkFirmOrQuote skips re-pricing orders that reference a valid firm quote, honouring the locked price.
+// Other orders are checked against the current quote.
+func checkFirmOrQuote(
+	ctx context.Context,
+	priceFunc priceFunc,
+	feeFunc feeFunc,
+	isFirm isFirmFunc,
+	orderID OrderID,
+	openedAt time.Time,
+	srcChainID, destChainID uint64,
+	deposits, expenses []TokenAmt,
+) error {
+	if ok, err := isFirm(ctx, orderID, openedAt, srcChainID, destChainID, deposits, expenses); err != nil {
+		return errors.Wrap(err, "is firm quote")
+	} else if ok {
+		return nil
+	}
 
-		minSafe := bi.Zero()
-		if expense.Token.Is(tokens.ETH) {
-			minSafe = minSafeETH
-		}
+	return checkQuote(ctx, priceFunc, feeFunc, deposits, expenses)
+}
 
-		// TODO: for native tokens, even if we have enough, we don't want to
-		// spend out whole balance. we'll need to keep some for gas
-		if bi.LT(bal, bi.Add(expense.Amount, minSafe)) {
-			return newRejection(types.RejectInsufficientInventory, errors.New("insufficient balance",
-				"balance", expense.Token.FormatAmt(bal),
-				"expense", expense,
-			))
-		}
+// checkLiquidity checks that the solver has enough free liquidity to pay for the expenses.
+// Free liquidity is the solver balance less inventory reserved by in-flight fills.
+func checkLiquidity(ctx context.Context, expenses []TokenAmt, backend unibackend.Backend, solverAddr common.Address, inv *inventory) error {
+	balances, err := expenseBalances(ctx, expenses, backend, solverAddr)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return inv.Check(expenses, balances)
+}
+
+// reserveLiquidity checks that the solver has enough free liquidity to pay for the order's expenses
+// and atomically reserves it. Reservations are released when the fill is confirmed or failed.
+func reserveLiquidity(ctx context.Context, id OrderID, expenses []TokenAmt, backend unibackend.Backend, solverAddr common.Address, inv *inventory) error {
+	balances, err := expenseBalances(ctx, expenses, backend, solverAddr)
+	if err != nil {
+		return err
+	}
+
+	return inv.Reserve(id, expenses, balances)
 }
 
 // checkOrderCalls checks if all calls in an order are allowed.
@@ -404,12 +437,3 @@ func checkCalls(destChainID uint64, calls []types.Call, isAllowed callAllowFunc)
 
 	return nil
 }
-
-func tkns(payments []TokenAmt) []tokens.Token {
-	tkns := make([]tokens.Token, len(payments))
-	for i, p := range payments {
-		tkns[i] = p.Token
-	}
-
-	return tkns
-}
@@ -56,7 +56,7 @@ func TestShouldReject(t *testing.T) {
 			uniBackends := unibackend.EVMBackends(backends)
 
 			callAllower := func(_ uint64, _ common.Address, _ []byte) bool { return !tt.disallowCall }
-			shouldReject := newShouldRejector(uniBackends, callAllower, priceFunc, solver, outbox)
+			shouldReject := newShouldRejector(uniBackends, callAllower, priceFunc, testFeeFunc(), notFirm, notOpened, newInventory(), notPaused, solver, outbox)
 
 			if tt.mock != nil {
 				tt.mock(clients)
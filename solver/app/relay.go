@@ -10,10 +10,12 @@ import (
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/ethclient"
 	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/tokenpricer"
 	"github.com/omni-network/omni/lib/tracer"
 	"github.com/omni-network/omni/lib/unibackend"
 	"github.com/omni-network/omni/solver/types"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -28,6 +30,10 @@ const (
 	RelayErrorInvalidOriginSettler = "INVALID_ORIGIN_SETTLER"
 	RelayErrorInvalidOrderData     = "INVALID_ORDER_DATA"
 	RelayErrorSubmissionFailed     = "SUBMISSION_FAILED"
+	RelayErrorDuplicate            = "DUPLICATE_RELAY"
+	RelayErrorNonceUsed            = "NONCE_USED"
+	RelayErrorBudgetExceeded       = "BUDGET_EXCEEDED"
+	RelayErrorTimeout              = "RELAY_TIMEOUT"
 
 	// Error messages.
 	RelayMsgOrderValidationFailed  = "Order validation failed"
@@ -40,11 +46,18 @@ const (
 	RelayMsgConvertOrderDataFailed = "Failed to convert order data"
 	RelayMsgOrderRejected          = "Order rejected by solver"
 	RelayMsgSubmissionFailed       = "Failed to submit gasless order"
+	RelayMsgInProgress             = "Identical gasless order is already being relayed"
+	RelayMsgNonceUsed              = "Nonce already used by another gasless order"
+	RelayMsgBudgetExceeded         = "Daily gas sponsorship budget exceeded"
+	RelayMsgTimeout                = "Relay timed out"
 
 	// Error descriptions.
 	RelayDescSignatureEmpty    = "The signature field cannot be empty"
 	RelayDescChainNotSupported = "The specified origin chain is not supported"
 	RelayDescEVMRequired       = "The specified chain does not have EVM support"
+	RelayDescBudgetExceeded    = "The user's sponsored gas for today exceeds the limit, retry tomorrow"
+	RelayDescPendingTimeout    = "The gasless order was not submitted in time, retry"
+	RelayDescSubmittedTimeout  = "The submitted transaction was not mined in time, retry"
 )
 
 // relayFunc abstracts the relay processing function.
@@ -63,12 +76,15 @@ func (e RelayError) Error() string {
 
 // newRelayer creates a new relay function that can submit gasless orders on behalf of users.
 // It integrates with the existing check logic to pre-validate orders before submission.
+// Relays are recorded in the ledger, which deduplicates submissions and enforces daily sponsorship budgets.
 func newRelayer(
 	inboxContracts map[uint64]*bindings.SolverNetInbox,
 	backends unibackend.Backends,
 	solverAddr common.Address,
 	inboxAddr common.Address,
 	checkFunc checkFunc,
+	ledger *relayLedger,
+	pricer tokenpricer.Pricer,
 ) relayFunc {
 	return func(ctx context.Context, req types.RelayRequest) (types.RelayResponse, error) {
 		ctx, span := tracer.Start(ctx, "relay/submit_gasless_order")
@@ -195,9 +211,49 @@ func newRelayer(
 			return types.RelayResponse{}, err
 		}
 
-		// All validations passed, submit the gasless order
+		// All validations passed, record the relay, deduplicating submissions and enforcing budgets
+		relayID := relayID(req.Order)
+		reqHash, err := relayRequestHash(req)
+		if err != nil {
+			return types.RelayResponse{}, err
+		}
+
+		existing, ok, err := ledger.Begin(ctx, relayID, reqHash, chainID, req.Order.User)
+		if r := new(RelayError); errors.As(err, &r) {
+			return types.RelayResponse{
+				Success: false,
+				RelayID: relayID,
+				Error: &types.RelayError{
+					Code:        r.Code,
+					Message:     r.Message,
+					Description: r.Description,
+				},
+			}, nil
+		} else if err != nil {
+			return types.RelayResponse{}, errors.Wrap(err, "begin relay")
+		} else if ok {
+			// Identical order already relayed, respond idempotently
+			return types.RelayResponse{
+				Success: true,
+				TxHash:  common.HexToHash(existing.GetTxHash()),
+				OrderID: common.BytesToHash(existing.GetOrderId()),
+				RelayID: relayID,
+			}, nil
+		}
+
+		// Ledger updates must outlive the request once submitted.
+		ledgerCtx := context.WithoutCancel(ctx)
+		failRelay := func(relayErr types.RelayError) types.RelayResponse {
+			if err := ledger.Fail(ledgerCtx, relayID, relayErr); err != nil {
+				log.Warn(ctx, "Failed to record failed relay", err)
+			}
+
+			return types.RelayResponse{Success: false, RelayID: relayID, Error: &relayErr}
+		}
+
 		txOpts, err := uniBackend.EVMBackend().BindOpts(ctx, solverAddr)
 		if err != nil {
+			failRelay(types.RelayError{Code: RelayErrorBackendError, Message: RelayMsgBackendFailed, Description: err.Error()})
 			return types.RelayResponse{}, errors.Wrap(err, "failed to get bind opts")
 		}
 
@@ -205,22 +261,43 @@ func newRelayer(
 		tx, err := inbox.OpenFor(txOpts, req.Order, req.Signature, req.OriginFillerData)
 		if err != nil {
 			// Any openFor failure is treated as a submission error
-			return types.RelayResponse{
-				Success: false,
-				Error: &types.RelayError{
-					Code:        RelayErrorSubmissionFailed,
-					Message:     RelayMsgSubmissionFailed,
-					Description: errors.Format(err),
-				},
-			}, nil
+			return failRelay(types.RelayError{
+				Code:        RelayErrorSubmissionFailed,
+				Message:     RelayMsgSubmissionFailed,
+				Description: errors.Format(err),
+			}), nil
+		}
+
+		if err := ledger.Submitted(ledgerCtx, relayID, tx.Hash()); err != nil {
+			log.Warn(ctx, "Failed to record submitted relay", err)
 		}
 
 		// Wait for transaction confirmation
 		rec, err := uniBackend.EVMBackend().WaitMined(ctx, tx)
-		if err != nil {
+		if err != nil && rec == nil {
+			// Relay remains submitted, since the tx may still be mined. It is reconciled from its receipt when accessed.
 			return types.RelayResponse{}, errors.Wrap(err, "transaction submitted but confirmation failed")
 		}
 
+		feeUSD, feeErr := txFeeUSD(ctx, pricer, chainID, rec)
+		if feeErr != nil {
+			log.Warn(ctx, "Failed to calculate sponsored relay fee", feeErr)
+		}
+
+		if err != nil {
+			// Reverted, record the sponsored fee
+			relayErr := types.RelayError{
+				Code:        RelayErrorSubmissionFailed,
+				Message:     RelayMsgSubmissionFailed,
+				Description: errors.Format(err),
+			}
+			if err := ledger.Mined(ledgerCtx, relayID, tx.Hash(), common.Hash{}, feeUSD, &relayErr); err != nil {
+				log.Warn(ctx, "Failed to record reverted relay", err)
+			}
+
+			return types.RelayResponse{Success: false, TxHash: tx.Hash(), RelayID: relayID, Error: &relayErr}, nil
+		}
+
 		// Extract order ID from transaction receipt
 		orderID, err := extractOrderIDFromReceipt(rec)
 		if err != nil {
@@ -228,21 +305,49 @@ func newRelayer(
 			// Don't fail the request since transaction was successful
 		}
 
+		if err := ledger.Mined(ledgerCtx, relayID, tx.Hash(), orderID, feeUSD, nil); err != nil {
+			log.Warn(ctx, "Failed to record mined relay", err)
+		}
+
 		log.Info(ctx, "Successfully relayed gasless order",
 			"tx_hash", tx.Hash().Hex(),
 			"order_id", orderID.Hex(),
+			"relay_id", relayID.Hex(),
 			"user", req.Order.User.Hex(),
 			"chain_id", chainID,
+			"fee_usd", feeUSD,
 		)
 
 		return types.RelayResponse{
 			Success: true,
 			TxHash:  tx.Hash(),
 			OrderID: orderID,
+			RelayID: relayID,
 		}, nil
 	}
 }
 
+// newRelayReceiptFunc returns a relayReceiptFunc fetching relay tx receipts from the chain's backend.
+func newRelayReceiptFunc(backends unibackend.Backends) relayReceiptFunc {
+	return func(ctx context.Context, chainID uint64, txHash common.Hash) (*ethclient.Receipt, bool, error) {
+		backend, err := backends.Backend(chainID)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "get backend")
+		} else if !backend.IsEVM() {
+			return nil, false, errors.New("relay chain not evm", "chain_id", chainID)
+		}
+
+		rec, err := backend.EVMBackend().TxReceipt(ctx, txHash)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, false, nil
+		} else if err != nil {
+			return nil, false, errors.Wrap(err, "get tx receipt")
+		}
+
+		return rec, true, nil
+	}
+}
+
 // validateGaslessOrder performs basic validation on the gasless order.
 func validateGaslessOrder(order bindings.IERC7683GaslessCrossChainOrder) error {
 	if order.User == (common.Address{}) {
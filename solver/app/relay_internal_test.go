@@ -1,13 +1,21 @@
 package app
 
 import (
+	"context"
 	"math/big"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/omni-network/omni/contracts/bindings"
+	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/solver/types"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
+	db "github.com/cosmos/cosmos-db"
 	"github.com/stretchr/testify/require"
 )
 
@@ -107,3 +115,214 @@ func TestValidateGaslessOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestRelayLedger(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	ledger, err := newRelayLedger(db.NewMemDB(), 1, noRelayReceipt, noRelayFee)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ledger.now = func() time.Time { return now }
+
+	user := common.HexToAddress("0xabcd")
+	order := bindings.IERC7683GaslessCrossChainOrder{
+		OriginSettler: common.HexToAddress("0x1234"),
+		User:          user,
+		Nonce:         big.NewInt(1),
+		OriginChainId: big.NewInt(1),
+	}
+	req := types.RelayRequest{Order: order, Signature: []byte("sig")}
+	id := relayID(order)
+	reqHash, err := relayRequestHash(req)
+	require.NoError(t, err)
+
+	requireRelayErr := func(err error, code string) {
+		t.Helper()
+		r := new(RelayError)
+		require.ErrorAs(t, err, r)
+		require.Equal(t, code, r.Code)
+	}
+
+	_, err = ledger.Get(ctx, id)
+	var apiErr APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	// Begin
+	_, ok, err := ledger.Begin(ctx, id, reqHash, 1, user)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Identical concurrent submission
+	_, _, err = ledger.Begin(ctx, id, reqHash, 1, user)
+	requireRelayErr(err, RelayErrorDuplicate)
+
+	// Other order reusing the nonce
+	other := req
+	other.Signature = []byte("other")
+	otherHash, err := relayRequestHash(other)
+	require.NoError(t, err)
+	_, _, err = ledger.Begin(ctx, id, otherHash, 1, user)
+	requireRelayErr(err, RelayErrorNonceUsed)
+
+	// Failed relays may be retried, retaining sponsored fees
+	relayErr := types.RelayError{Code: RelayErrorSubmissionFailed, Message: RelayMsgSubmissionFailed}
+	require.NoError(t, ledger.Submitted(ctx, id, common.HexToHash("0x01")))
+	require.NoError(t, ledger.Mined(ctx, id, common.HexToHash("0x01"), common.Hash{}, 0.25, &relayErr))
+
+	relay, err := ledger.Get(ctx, id)
+	require.NoError(t, err)
+	resp := relayStatusResponse(relay)
+	require.Equal(t, types.RelayFailed, resp.Status)
+	require.Equal(t, &relayErr, resp.Error)
+	require.Equal(t, user, resp.User)
+
+	_, ok, err = ledger.Begin(ctx, id, reqHash, 1, user)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	orderID := common.HexToHash("0x02")
+	require.NoError(t, ledger.Submitted(ctx, id, common.HexToHash("0x03")))
+	require.NoError(t, ledger.Mined(ctx, id, common.HexToHash("0x03"), orderID, 1, nil))
+
+	relay, err = ledger.Get(ctx, id)
+	require.NoError(t, err)
+	resp = relayStatusResponse(relay)
+	require.Equal(t, types.RelaySuccess, resp.Status)
+	require.Equal(t, orderID, resp.OrderID)
+	require.Equal(t, common.HexToHash("0x03").Hex(), resp.TxHash)
+	require.InDelta(t, 1.25, resp.FeeUSD, 1e-9)
+	require.Nil(t, resp.Error)
+
+	// Identical submission after success is idempotent
+	existing, ok, err := ledger.Begin(ctx, id, reqHash, 1, user)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, orderID[:], existing.GetOrderId())
+
+	// Daily budget exhausted
+	next := order
+	next.Nonce = big.NewInt(2)
+	nextReq := types.RelayRequest{Order: next, Signature: []byte("sig")}
+	nextHash, err := relayRequestHash(nextReq)
+	require.NoError(t, err)
+	_, _, err = ledger.Begin(ctx, relayID(next), nextHash, 1, user)
+	requireRelayErr(err, RelayErrorBudgetExceeded)
+
+	// Other users and days have separate budgets
+	_, _, err = ledger.Begin(ctx, relayID(next), nextHash, 1, common.HexToAddress("0xef"))
+	require.NoError(t, err)
+
+	now = now.Add(24 * time.Hour)
+	next.Nonce = big.NewInt(3)
+	_, _, err = ledger.Begin(ctx, relayID(next), nextHash, 1, user)
+	require.NoError(t, err)
+}
+
+func TestRelayLedgerReconcile(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	receipts := make(map[common.Hash]*ethclient.Receipt)
+	receipt := func(_ context.Context, chainID uint64, txHash common.Hash) (*ethclient.Receipt, bool, error) {
+		require.EqualValues(t, 1, chainID)
+		rec, ok := receipts[txHash]
+
+		return rec, ok, nil
+	}
+	fee := func(context.Context, uint64, *ethclient.Receipt) (float64, error) { return 0.5, nil }
+
+	ledger, err := newRelayLedger(db.NewMemDB(), 0, receipt, fee)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ledger.now = func() time.Time { return now }
+
+	user := common.HexToAddress("0xabcd")
+	begin := func(nonce int64) common.Hash {
+		t.Helper()
+		req := types.RelayRequest{
+			Order: bindings.IERC7683GaslessCrossChainOrder{
+				OriginSettler: common.HexToAddress("0x1234"),
+				User:          user,
+				Nonce:         big.NewInt(nonce),
+				OriginChainId: big.NewInt(1),
+			},
+			Signature: []byte("sig"),
+		}
+		reqHash, err := relayRequestHash(req)
+		require.NoError(t, err)
+
+		id := relayID(req.Order)
+		_, ok, err := ledger.Begin(ctx, id, reqHash, 1, user)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		return id
+	}
+	requireStatus := func(id common.Hash, status types.RelayStatus) types.RelayStatusResponse {
+		t.Helper()
+		relay, err := ledger.Get(ctx, id)
+		require.NoError(t, err)
+		resp := relayStatusResponse(relay)
+		require.Equal(t, status, resp.Status)
+
+		return resp
+	}
+
+	pending := begin(1)
+
+	minedTx, revertedTx, droppedTx := common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0x03")
+	orderID := common.HexToHash("0x04")
+	mined := begin(2)
+	require.NoError(t, ledger.Submitted(ctx, mined, minedTx))
+	reverted := begin(3)
+	require.NoError(t, ledger.Submitted(ctx, reverted, revertedTx))
+	dropped := begin(4)
+	require.NoError(t, ledger.Submitted(ctx, dropped, droppedTx))
+
+	// Relays are in progress until mined or timed out
+	requireStatus(pending, types.RelayPending)
+	requireStatus(mined, types.RelaySubmitted)
+
+	// Submitted relays are resolved from their receipt
+	receipts[minedTx] = &ethclient.Receipt{
+		Status: ethtypes.ReceiptStatusSuccessful,
+		Logs:   []*ethtypes.Log{{Topics: []common.Hash{solvernet.TopicOpened, orderID}}},
+	}
+	receipts[revertedTx] = &ethclient.Receipt{Status: ethtypes.ReceiptStatusFailed}
+
+	resp := requireStatus(mined, types.RelaySuccess)
+	require.Equal(t, orderID, resp.OrderID)
+	require.InDelta(t, 0.5, resp.FeeUSD, 1e-9)
+
+	resp = requireStatus(reverted, types.RelayFailed)
+	require.Equal(t, RelayErrorSubmissionFailed, resp.Error.Code)
+	require.InDelta(t, 0.5, resp.FeeUSD, 1e-9)
+
+	// Late confirmations of reconciled relays are ignored
+	require.NoError(t, ledger.Mined(ctx, mined, minedTx, orderID, 0.5, nil))
+	require.InDelta(t, 0.5, requireStatus(mined, types.RelaySuccess).FeeUSD, 1e-9)
+
+	// Pending relays time out
+	now = now.Add(relayPendingTimeout)
+	resp = requireStatus(pending, types.RelayFailed)
+	require.Equal(t, RelayErrorTimeout, resp.Error.Code)
+	requireStatus(dropped, types.RelaySubmitted)
+
+	// Submitted relays not mined time out, and may be retried
+	now = now.Add(relaySubmittedTimeout)
+	resp = requireStatus(dropped, types.RelayFailed)
+	require.Equal(t, RelayErrorTimeout, resp.Error.Code)
+	require.Equal(t, dropped, begin(4))
+}
+
+func noRelayReceipt(context.Context, uint64, common.Hash) (*ethclient.Receipt, bool, error) {
+	return nil, false, nil
+}
+
+func noRelayFee(context.Context, uint64, *ethclient.Receipt) (float64, error) {
+	return 0, nil
+}
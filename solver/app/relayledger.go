@@ -0,0 +1,369 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/contracts/bindings"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"cosmossdk.io/orm/types/ormerrors"
+	db "github.com/cosmos/cosmos-db"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultRelayMaxUserDailyUSD is the default max tx fees in USD sponsored per user per UTC day.
+	defaultRelayMaxUserDailyUSD = 10
+
+	// relayPendingTimeout is the duration after which relays not yet submitted are failed,
+	// e.g. if the solver restarted while submitting.
+	relayPendingTimeout = 2 * time.Minute
+
+	// relaySubmittedTimeout is the duration after which submitted relays whose tx isn't mined are failed,
+	// e.g. if the tx was dropped.
+	relaySubmittedTimeout = 30 * time.Minute
+)
+
+// relayReceiptFunc returns the receipt of a relay tx, or false if not (yet) mined.
+type relayReceiptFunc func(ctx context.Context, chainID uint64, txHash common.Hash) (*ethclient.Receipt, bool, error)
+
+// relayFeeFunc returns the USD value of the tx fee sponsored by a mined relay tx.
+type relayFeeFunc func(ctx context.Context, chainID uint64, rec *ethclient.Receipt) (float64, error)
+
+// relayID returns the ID of a gasless order, identifying it by origin chain, settler, user and nonce.
+// Orders reusing a nonce therefore have the same relay ID.
+func relayID(order bindings.IERC7683GaslessCrossChainOrder) common.Hash {
+	return crypto.Keccak256Hash(
+		common.BigToHash(order.OriginChainId).Bytes(),
+		order.OriginSettler.Bytes(),
+		order.User.Bytes(),
+		common.BigToHash(order.Nonce).Bytes(),
+	)
+}
+
+// relayRequestHash returns the hash of the relayed order and signature, identifying identical submissions.
+func relayRequestHash(req types.RelayRequest) (common.Hash, error) {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "marshal relay request")
+	}
+
+	return crypto.Keccak256Hash(bz), nil
+}
+
+func newRelayLedger(db db.DB, maxUserDailyUSD float64, receipt relayReceiptFunc, fee relayFeeFunc) (*relayLedger, error) {
+	dbStore, err := newSolverStore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &relayLedger{
+		table:           dbStore.RelayTable(),
+		maxUserDailyUSD: maxUserDailyUSD,
+		receipt:         receipt,
+		fee:             fee,
+		now:             time.Now,
+	}, nil
+}
+
+// relayLedger provides a thread-safe persisted ledger of relayed gasless orders.
+// It deduplicates submissions and limits the tx fees sponsored per user per UTC day.
+// Relays left pending or submitted (e.g. canceled requests or restarts) are reconciled when accessed.
+type relayLedger struct {
+	mu              sync.Mutex
+	table           RelayTable
+	maxUserDailyUSD float64 // Zero disables the daily budget
+	receipt         relayReceiptFunc
+	fee             relayFeeFunc
+	now             func() time.Time
+}
+
+// Begin records a pending relay, unless identical, conflicting or over budget.
+// It returns the existing relay and true if an identical order was already relayed successfully.
+// It returns a RelayError if an identical relay is in progress, another order used the nonce,
+// or the user's daily budget is exhausted. Failed relays may be retried.
+func (l *relayLedger) Begin(ctx context.Context, id common.Hash, reqHash common.Hash, chainID uint64, user common.Address) (*Relay, bool, error) {
+	if err := l.reconcile(ctx, id); err != nil {
+		return nil, false, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	existing, err := l.table.Get(ctx, id[:])
+	if err != nil && !ormerrors.IsNotFound(err) {
+		return nil, false, errors.Wrap(err, "get relay")
+	} else if err == nil && types.RelayStatus(existing.GetStatus()) != types.RelayFailed {
+		if !bytes.Equal(existing.GetRequestHash(), reqHash[:]) {
+			return nil, false, RelayError{
+				Code:    RelayErrorNonceUsed,
+				Message: RelayMsgNonceUsed,
+			}
+		} else if types.RelayStatus(existing.GetStatus()) == types.RelaySuccess {
+			return proto.Clone(existing).(*Relay), true, nil //nolint:forcetypeassert // Type known
+		}
+
+		return nil, false, RelayError{
+			Code:    RelayErrorDuplicate,
+			Message: RelayMsgInProgress,
+		}
+	}
+
+	now := l.now()
+	day := now.UTC().Format(time.DateOnly)
+
+	if l.maxUserDailyUSD > 0 {
+		spent, err := l.spentUSD(ctx, user, day)
+		if err != nil {
+			return nil, false, err
+		} else if spent >= l.maxUserDailyUSD {
+			return nil, false, RelayError{
+				Code:        RelayErrorBudgetExceeded,
+				Message:     RelayMsgBudgetExceeded,
+				Description: RelayDescBudgetExceeded,
+			}
+		}
+	}
+
+	err = l.table.Save(ctx, &Relay{
+		Id:          id[:],
+		RequestHash: reqHash[:],
+		ChainId:     chainID,
+		User:        user[:],
+		Day:         day,
+		Status:      string(types.RelayPending),
+		FeeUsd:      existing.GetFeeUsd(), // Retain fees sponsored by failed attempts
+		CreatedAt:   timestamppb.New(now),
+		UpdatedAt:   timestamppb.New(now),
+	})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "save relay")
+	}
+
+	return nil, false, nil
+}
+
+// spentUSD returns the tx fees sponsored for the user on the UTC day. It must be called with the lock held.
+func (l *relayLedger) spentUSD(ctx context.Context, user common.Address, day string) (float64, error) {
+	iter, err := l.table.List(ctx, RelayUserDayIndexKey{}.WithUserDay(user[:], day))
+	if err != nil {
+		return 0, errors.Wrap(err, "list relays")
+	}
+	defer iter.Close()
+
+	var resp float64
+	for iter.Next() {
+		relay, err := iter.Value()
+		if err != nil {
+			return 0, errors.Wrap(err, "get value")
+		}
+		resp += relay.GetFeeUsd()
+	}
+
+	return resp, nil
+}
+
+// Submitted records the submitted openFor tx hash.
+func (l *relayLedger) Submitted(ctx context.Context, id common.Hash, txHash common.Hash) error {
+	return l.update(ctx, id, func(relay *Relay) {
+		relay.Status = string(types.RelaySubmitted)
+		relay.TxHash = txHash.Hex()
+	})
+}
+
+// Mined records the mined openFor tx; adding its sponsored fee, and the opened order ID
+// if successful, or the relay error if reverted. It is a noop if the tx was already reconciled.
+func (l *relayLedger) Mined(ctx context.Context, id common.Hash, txHash common.Hash, orderID common.Hash, feeUSD float64, relayErr *types.RelayError) error {
+	return l.update(ctx, id, func(relay *Relay) {
+		if !isSubmitted(relay, txHash) {
+			return
+		}
+
+		relay.FeeUsd += feeUSD
+		if relayErr != nil {
+			setRelayFailed(relay, *relayErr)
+			return
+		}
+
+		relay.Status = string(types.RelaySuccess)
+		relay.OrderId = orderID[:]
+	})
+}
+
+// Fail records the relay as failed, allowing it to be retried.
+func (l *relayLedger) Fail(ctx context.Context, id common.Hash, relayErr types.RelayError) error {
+	return l.update(ctx, id, func(relay *Relay) {
+		setRelayFailed(relay, relayErr)
+	})
+}
+
+func (l *relayLedger) update(ctx context.Context, id common.Hash, fn func(*Relay)) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	relay, err := l.table.Get(ctx, id[:])
+	if err != nil {
+		return errors.Wrap(err, "get relay")
+	}
+
+	fn(relay)
+	relay.UpdatedAt = timestamppb.New(l.now())
+
+	if err := l.table.Update(ctx, relay); err != nil {
+		return errors.Wrap(err, "update relay")
+	}
+
+	return nil
+}
+
+// Get returns the relay, reconciled if stuck. It returns a not found API error if the relay doesn't exist.
+func (l *relayLedger) Get(ctx context.Context, id common.Hash) (*Relay, error) {
+	if err := l.reconcile(ctx, id); err != nil {
+		return nil, err
+	}
+
+	relay, ok, err := l.get(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, newAPIError(errors.New("relay not found"), http.StatusNotFound)
+	}
+
+	return relay, nil
+}
+
+// get returns a copy of the relay, or false if it doesn't exist.
+func (l *relayLedger) get(ctx context.Context, id common.Hash) (*Relay, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	relay, err := l.table.Get(ctx, id[:])
+	if ormerrors.IsNotFound(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, errors.Wrap(err, "get relay")
+	}
+
+	return proto.Clone(relay).(*Relay), true, nil //nolint:forcetypeassert // Type known
+}
+
+// reconcile resolves the relay if stuck. Relays not submitted within relayPendingTimeout are failed.
+// Submitted relays are resolved from their tx receipt, or failed if not mined within relaySubmittedTimeout.
+// The tx receipt is fetched without holding the lock, so updates are skipped if the relay changed meanwhile.
+func (l *relayLedger) reconcile(ctx context.Context, id common.Hash) error {
+	relay, ok, err := l.get(ctx, id)
+	if err != nil || !ok {
+		return err
+	}
+
+	age := l.now().Sub(relay.GetUpdatedAt().AsTime())
+	txHash := common.HexToHash(relay.GetTxHash())
+
+	// updateIfUnchanged updates the relay unless it changed since reconciliation started.
+	updateIfUnchanged := func(fn func(*Relay)) error {
+		return l.update(ctx, id, func(r *Relay) {
+			if r.GetStatus() == relay.GetStatus() && r.GetUpdatedAt().AsTime().Equal(relay.GetUpdatedAt().AsTime()) {
+				fn(r)
+			}
+		})
+	}
+
+	switch types.RelayStatus(relay.GetStatus()) {
+	case types.RelayPending:
+		if age < relayPendingTimeout {
+			return nil
+		}
+
+		return updateIfUnchanged(func(r *Relay) {
+			setRelayFailed(r, types.RelayError{Code: RelayErrorTimeout, Message: RelayMsgTimeout, Description: RelayDescPendingTimeout})
+		})
+	case types.RelaySubmitted:
+		rec, mined, err := l.receipt(ctx, relay.GetChainId(), txHash)
+		if err != nil {
+			return errors.Wrap(err, "get relay receipt")
+		} else if !mined {
+			if age < relaySubmittedTimeout {
+				return nil
+			}
+
+			return updateIfUnchanged(func(r *Relay) {
+				setRelayFailed(r, types.RelayError{Code: RelayErrorTimeout, Message: RelayMsgTimeout, Description: RelayDescSubmittedTimeout})
+			})
+		}
+
+		feeUSD, err := l.fee(ctx, relay.GetChainId(), rec)
+		if err != nil {
+			log.Warn(ctx, "Failed to calculate sponsored relay fee", err)
+		}
+
+		if rec.Status != ethtypes.ReceiptStatusSuccessful {
+			return updateIfUnchanged(func(r *Relay) {
+				r.FeeUsd += feeUSD
+				setRelayFailed(r, types.RelayError{Code: RelayErrorSubmissionFailed, Message: RelayMsgSubmissionFailed, Description: "transaction reverted"})
+			})
+		}
+
+		orderID, err := extractOrderIDFromReceipt(rec)
+		if err != nil {
+			log.Warn(ctx, "Failed to extract order ID from receipt", err)
+		}
+
+		return updateIfUnchanged(func(r *Relay) {
+			r.FeeUsd += feeUSD
+			r.Status = string(types.RelaySuccess)
+			r.OrderId = orderID[:]
+		})
+	default:
+		return nil
+	}
+}
+
+// isSubmitted returns true if the relay is awaiting confirmation of the tx.
+func isSubmitted(relay *Relay, txHash common.Hash) bool {
+	return types.RelayStatus(relay.GetStatus()) == types.RelaySubmitted && relay.GetTxHash() == txHash.Hex()
+}
+
+func setRelayFailed(relay *Relay, relayErr types.RelayError) {
+	relay.Status = string(types.RelayFailed)
+	relay.ErrorCode = relayErr.Code
+	relay.ErrorMessage = relayErr.Message
+	relay.ErrorDescription = relayErr.Description
+}
+
+// relayStatusResponse converts the relay to the API response type.
+func relayStatusResponse(relay *Relay) types.RelayStatusResponse {
+	resp := types.RelayStatusResponse{
+		RelayID:   common.BytesToHash(relay.GetId()),
+		Status:    types.RelayStatus(relay.GetStatus()),
+		ChainID:   relay.GetChainId(),
+		User:      common.BytesToAddress(relay.GetUser()),
+		TxHash:    relay.GetTxHash(),
+		OrderID:   common.BytesToHash(relay.GetOrderId()),
+		FeeUSD:    relay.GetFeeUsd(),
+		CreatedAt: relay.GetCreatedAt().AsTime(),
+		UpdatedAt: relay.GetUpdatedAt().AsTime(),
+	}
+
+	if relay.GetErrorCode() != "" {
+		resp.Error = &types.RelayError{
+			Code:        relay.GetErrorCode(),
+			Message:     relay.GetErrorMessage(),
+			Description: relay.GetErrorDescription(),
+		}
+	}
+
+	return resp
+}
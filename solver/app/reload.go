@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/solver/fundthresh"
+	"github.com/omni-network/omni/solver/rebalance"
+)
+
+// reloadOnSignal blocks and calls all reload functions on SIGHUP.
+func reloadOnSignal(ctx context.Context, reloads ...func(context.Context)) {
+	if len(reloads) == 0 {
+		return
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigs:
+			for _, reload := range reloads {
+				reload(ctx)
+			}
+		}
+	}
+}
+
+// reloadThresholds reloads the fund thresholds file, updating the threshold gauges.
+// Invalid thresholds files are logged and ignored, retaining the previous thresholds.
+func reloadThresholds(ctx context.Context, path string, network netconf.Network) {
+	if err := fundthresh.Reload(path); err != nil {
+		log.Warn(ctx, "Ignoring invalid fund thresholds", err, "path", path)
+		return
+	}
+
+	rebalance.UpdateThresholdMetrics(network)
+	log.Info(ctx, "Reloaded fund thresholds", "path", path)
+}
+
+// reloadFees reloads the fee schedule file into the store.
+// Invalid fee schedules are logged and ignored, retaining the previous schedule.
+func reloadFees(ctx context.Context, path string, store *feeStore) {
+	schedule, err := LoadFeeSchedule(path)
+	if err != nil {
+		log.Warn(ctx, "Ignoring invalid fee schedule", err, "path", path)
+		return
+	}
+
+	store.Set(schedule)
+	log.Info(ctx, "Reloaded fee schedule", "path", path, "rules", len(schedule.Rules), "default_bips", schedule.DefaultBips)
+}
@@ -17,21 +17,35 @@ import (
 
 //nolint:gosec // False positive: this is not a secret
 const (
-	endpointQuote     = "/api/v1/quote"
-	endpointContracts = "/api/v1/contracts"
-	endpointCheck     = "/api/v1/check"
-	endpointPrice     = "/api/v1/price"
-	endpointTokens    = "/api/v1/tokens"
-	endpointRelay     = "/api/v1/relay"
+	endpointQuote       = "/api/v1/quote"
+	endpointContracts   = "/api/v1/contracts"
+	endpointCheck       = "/api/v1/check"
+	endpointPrice       = "/api/v1/price"
+	endpointTokens      = "/api/v1/tokens"
+	endpointRelay       = "/api/v1/relay"
+	endpointRelayStatus = "/api/v1/relay/{id}"
+	endpointOrders      = "/api/v1/orders/{id}"
+	endpointOrderStream = "/api/v1/orders/stream"
+
+	endpointAdminPause  = "/admin/v1/pause"
+	endpointAdminResume = "/admin/v1/resume"
+	endpointAdminPauses = "/admin/v1/pauses"
 )
 
 // serveAPI starts the API server, returning a async error and shutdown function.
-func serveAPI(address string, handlers ...Handler) (<-chan error, func()) {
+// Requests are rate limited if the limiter is non-nil.
+func serveAPI(address string, limiter *rateLimiter, handlers ...Handler) (<-chan error, func()) {
 	mux := http.NewServeMux()
 
 	// Add all handlers
 	for _, handler := range handlers {
 		fn := handlerAdapter(handler)
+		if handler.Stream != nil {
+			fn = handler.Stream
+		}
+		if limiter != nil {
+			fn = limiter.Wrap(handler.Endpoint, fn)
+		}
 		if !handler.SkipInstrument {
 			fn = instrumentHandler(handler.Endpoint, fn)
 		}
@@ -45,7 +59,7 @@ func serveAPI(address string, handlers ...Handler) (<-chan error, func()) {
 	c := cors.New(cors.Options{
 		AllowedOrigins: []string{"*"},
 		AllowedMethods: []string{"GET", "POST", "OPTIONS"},
-		AllowedHeaders: []string{"Accept", "Content-Type", "Origin", "User-Agent"},
+		AllowedHeaders: []string{"Accept", "Content-Type", "Origin", "User-Agent", headerAPIKey},
 	})
 
 	srv := &http.Server{
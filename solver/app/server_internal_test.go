@@ -17,7 +17,7 @@ func TestServerCORS(t *testing.T) {
 
 	addr := fmt.Sprintf("127.0.0.1:%d", tutil.RandomAvailablePort(t))
 
-	_, apiCancel := serveAPI(addr, Handler{
+	_, apiCancel := serveAPI(addr, nil, Handler{
 		Endpoint: "/api/v1/test",
 		ZeroReq:  func() any { return nil },
 		HandleFunc: func(ctx context.Context, req any) (any, error) {
@@ -0,0 +1,317 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/omni-network/omni/contracts/bindings"
+	"github.com/omni-network/omni/lib/anvil"
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient"
+	"github.com/omni-network/omni/lib/expbackoff"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/xchain"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// simMaxQueue is the max number of simulations queued (including running) per fork.
+	simMaxQueue = 8
+
+	// simQueueTimeout is the max duration a simulation waits for its fork.
+	simQueueTimeout = 30 * time.Second
+)
+
+// simulateFunc simulates filling a check request against a fork of the destination chain.
+type simulateFunc func(context.Context, types.CheckRequest) (types.Simulation, error)
+
+// simFork is a local anvil fork of a destination chain.
+type simFork struct {
+	sem     chan struct{} // Simulations are serialized, since each resets the fork
+	queued  atomic.Int64  // Simulations waiting for or holding the fork
+	client  ethclient.Client
+	forkURL string
+}
+
+func newSimFork(client ethclient.Client, forkURL string) *simFork {
+	return &simFork{
+		sem:     make(chan struct{}, 1),
+		client:  client,
+		forkURL: forkURL,
+	}
+}
+
+// acquire waits for exclusive use of the fork, returning a release function.
+// It returns an error if the queue is full, or ctx is done or simQueueTimeout elapsed while waiting.
+func (f *simFork) acquire(ctx context.Context) (func(), error) {
+	if f.queued.Add(1) > simMaxQueue {
+		f.queued.Add(-1)
+		return nil, errors.New("simulation queue full")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, simQueueTimeout)
+	defer cancel()
+
+	select {
+	case f.sem <- struct{}{}:
+		return func() {
+			<-f.sem
+			f.queued.Add(-1)
+		}, nil
+	case <-ctx.Done():
+		f.queued.Add(-1)
+		return nil, errors.Wrap(ctx.Err(), "await simulation fork")
+	}
+}
+
+// forkSimulator simulates fills against local anvil forks of destination chains.
+type forkSimulator struct {
+	solverAddr common.Address
+	outboxAddr common.Address
+
+	mu    sync.RWMutex
+	forks map[uint64]*simFork
+}
+
+// startSimulator starts anvil forks of all EVM chains in the background, stopping them when ctx is done.
+// Chains are available for simulation once their fork has started.
+func startSimulator(
+	ctx context.Context,
+	dir string,
+	network netconf.Network,
+	endpoints xchain.RPCEndpoints,
+	solverAddr, outboxAddr common.Address,
+) (*forkSimulator, error) {
+	s := &forkSimulator{
+		solverAddr: solverAddr,
+		outboxAddr: outboxAddr,
+		forks:      make(map[uint64]*simFork),
+	}
+
+	for _, chain := range network.EVMChains() {
+		forkURL, err := endpoints.ByNameOrID(chain.Name, chain.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		go s.startForkForever(ctx, filepath.Join(dir, chain.Name), chain.ID, forkURL)
+	}
+
+	return s, nil
+}
+
+// startForkForever starts an anvil fork of the chain, retrying until started or ctx is done.
+func (s *forkSimulator) startForkForever(ctx context.Context, dir string, chainID uint64, forkURL string) {
+	ctx = log.WithCtx(ctx, "chain_id", chainID)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Error(ctx, "Failed creating simulation fork dir", err)
+		return
+	}
+
+	backoff := expbackoff.New(ctx)
+	for ctx.Err() == nil {
+		client, stop, err := anvil.Start(ctx, dir, chainID, anvil.WithFork(forkURL), anvil.WithAutoImpersonate())
+		if err != nil {
+			log.Warn(ctx, "Failed starting simulation fork (will retry)", err)
+			backoff()
+
+			continue
+		}
+
+		s.mu.Lock()
+		s.forks[chainID] = newSimFork(client, forkURL)
+		s.mu.Unlock()
+
+		log.Info(ctx, "Started simulation fork")
+
+		<-ctx.Done()
+		stop()
+
+		return
+	}
+}
+
+// Simulate simulates filling the check request against a fork of the destination chain at its latest block.
+// The solver is funded with the native value and approves the outbox to spend all ERC20 expenses before the fill.
+// It returns an error if the simulation could not be performed, not if the fill reverts.
+func (s *forkSimulator) Simulate(ctx context.Context, req types.CheckRequest) (types.Simulation, error) {
+	s.mu.RLock()
+	fork, ok := s.forks[req.DestinationChainID]
+	s.mu.RUnlock()
+	if !ok {
+		return types.Simulation{}, errors.New("simulation fork not available", "chain_id", req.DestinationChainID)
+	}
+
+	release, err := fork.acquire(ctx)
+	if err != nil {
+		return types.Simulation{}, err
+	}
+	defer release()
+
+	if err := anvil.Reset(ctx, fork.client, fork.forkURL); err != nil {
+		return types.Simulation{}, err
+	}
+
+	height, err := fork.client.BlockNumber(ctx)
+	if err != nil {
+		return types.Simulation{}, errors.Wrap(err, "block number")
+	}
+
+	fillOriginData, err := getFillOriginData(req)
+	if err != nil {
+		return types.Simulation{}, errors.Wrap(err, "pack fill origin data")
+	}
+
+	// Random orderID (since unfilled).
+	var orderID OrderID
+	_, _ = rand.Read(orderID[:])
+
+	msg, err := fillCallMsg(ctx, fork.client, orderID, fillOriginData, nativeExpense(req.Expenses), s.solverAddr, s.outboxAddr)
+	if err != nil {
+		return types.Simulation{}, errors.Wrap(err, "create call msg")
+	}
+
+	if err := s.prepareFill(ctx, fork.client, req.Expenses, msg.Value); err != nil {
+		return types.Simulation{}, err
+	}
+
+	rec, err := sendSimTx(ctx, fork.client, msg)
+	if err != nil {
+		return types.Simulation{}, err
+	}
+
+	resp := types.Simulation{
+		Success:     rec.Status == ethtypes.ReceiptStatusSuccessful,
+		BlockNumber: height,
+		GasUsed:     rec.GasUsed,
+		Logs:        simulationLogs(rec.Logs),
+	}
+	if !resp.Success {
+		resp.Error = "fill reverted"
+	}
+
+	diff, err := traceStateDiff(ctx, fork.client, rec.TxHash)
+	if err != nil {
+		log.DebugErr(ctx, "Failed tracing simulation state diff", err)
+	} else {
+		resp.StateDiff = diff
+	}
+
+	return resp, nil
+}
+
+// prepareFill funds the solver with the native value (and gas), and approves the outbox to spend ERC20 expenses.
+func (s *forkSimulator) prepareFill(ctx context.Context, client ethclient.Client, expenses []types.Expense, value *big.Int) error {
+	balance, err := client.BalanceAt(ctx, s.solverAddr, nil)
+	if err != nil {
+		return errors.Wrap(err, "balance")
+	}
+
+	gasBuffer := bi.Ether(1)
+	if err := anvil.FundAccounts(ctx, client, bi.Add(balance, value, gasBuffer), s.solverAddr); err != nil {
+		return err
+	}
+
+	erc20, err := bindings.IERC20MetaData.GetAbi()
+	if err != nil {
+		return errors.Wrap(err, "get abi")
+	}
+
+	for _, e := range expenses {
+		if isNative(e) {
+			continue
+		}
+
+		data, err := erc20.Pack("approve", s.outboxAddr, e.Amount)
+		if err != nil {
+			return errors.Wrap(err, "pack approve")
+		}
+
+		rec, err := sendSimTx(ctx, client, ethereum.CallMsg{From: s.solverAddr, To: &e.Token, Data: data})
+		if err != nil {
+			return errors.Wrap(err, "approve", "token", e.Token)
+		} else if rec.Status != ethtypes.ReceiptStatusSuccessful {
+			return errors.New("approve reverted", "token", e.Token)
+		}
+	}
+
+	return nil
+}
+
+// sendSimTx sends the message as an impersonated tx to the fork, returning its receipt.
+func sendSimTx(ctx context.Context, client ethclient.Client, msg ethereum.CallMsg) (*ethclient.Receipt, error) {
+	tx := map[string]any{
+		"from": msg.From.Hex(),
+		"to":   msg.To.Hex(),
+		"data": hexutil.Encode(msg.Data),
+	}
+	if msg.Value != nil {
+		tx["value"] = hexutil.EncodeBig(msg.Value)
+	}
+
+	var txHash common.Hash
+	if err := client.CallContext(ctx, &txHash, "eth_sendTransaction", tx); err != nil {
+		return nil, errors.Wrap(err, "send tx")
+	}
+
+	rec, err := client.TxReceipt(ctx, txHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx receipt")
+	}
+
+	return rec, nil
+}
+
+// traceStateDiff returns the pre and post state of accounts modified by the tx.
+func traceStateDiff(ctx context.Context, client ethclient.Client, txHash common.Hash) (json.RawMessage, error) {
+	var diff json.RawMessage
+	err := client.CallContext(ctx, &diff, "debug_traceTransaction", txHash, map[string]any{
+		"tracer":       "prestateTracer",
+		"tracerConfig": map[string]any{"diffMode": true},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "debug_traceTransaction")
+	}
+
+	return diff, nil
+}
+
+func simulationLogs(logs []*ethtypes.Log) []types.SimulationLog {
+	resp := make([]types.SimulationLog, 0, len(logs))
+	for _, l := range logs {
+		resp = append(resp, types.SimulationLog{
+			Address: l.Address,
+			Topics:  l.Topics,
+			Data:    l.Data,
+		})
+	}
+
+	return resp
+}
+
+// nativeExpense returns the sum of native expenses.
+func nativeExpense(expenses []types.Expense) *big.Int {
+	resp := bi.Zero()
+	for _, e := range expenses {
+		if isNative(e) {
+			resp.Add(resp, e.Amount)
+		}
+	}
+
+	return resp
+}
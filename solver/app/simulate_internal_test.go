@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSimulation(t *testing.T) {
+	t.Parallel()
+
+	accept := func(context.Context, types.CheckRequest) error { return nil }
+	sim := types.Simulation{
+		Success:     true,
+		BlockNumber: 100,
+		GasUsed:     21000,
+		Logs:        []types.SimulationLog{{Address: common.HexToAddress("0x1111"), Topics: []common.Hash{{1}}, Data: []byte{2}}},
+	}
+	simulate := func(_ context.Context, req types.CheckRequest) (types.Simulation, error) {
+		require.True(t, req.Simulate)
+		if req.DestinationChainID != 1 {
+			return types.Simulation{}, errors.New("simulation fork not available")
+		}
+
+		return sim, nil
+	}
+
+	handler := handlerAdapter(newCheckHandler(accept, noopTracer, simulate))
+
+	res := fetchResponseViaClient(t, handler, types.CheckRequest{DestinationChainID: 1})
+	require.True(t, res.Accepted)
+	require.Nil(t, res.Simulation)
+
+	res = fetchResponseViaClient(t, handler, types.CheckRequest{DestinationChainID: 1, Simulate: true})
+	require.Equal(t, &sim, res.Simulation)
+
+	res = fetchResponseViaClient(t, handler, types.CheckRequest{DestinationChainID: 2, Simulate: true})
+	require.Contains(t, res.Simulation.Error, "simulation fork not available")
+
+	disabled := handlerAdapter(newCheckHandler(accept, noopTracer, nil))
+	res = fetchResponseViaClient(t, disabled, types.CheckRequest{Simulate: true})
+	require.Equal(t, "simulation disabled", res.Simulation.Error)
+}
+
+func TestNativeExpense(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, bi.N(3), nativeExpense([]types.Expense{
+		{Token: tokens.NativeAddr, Amount: bi.N(1)},
+		{Token: common.HexToAddress("0x1111"), Amount: bi.N(10)},
+		{Token: tokens.NativeAddr, Amount: bi.N(2)},
+	}))
+	require.Equal(t, bi.Zero(), nativeExpense(nil))
+}
+
+func TestSimForkAcquire(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	fork := newSimFork(nil, "")
+
+	release, err := fork.acquire(ctx)
+	require.NoError(t, err)
+
+	// Waiting simulations respect the request context
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = fork.acquire(cancelled)
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualValues(t, 1, fork.queued.Load())
+
+	// Queue is capped
+	fork.queued.Store(simMaxQueue)
+	_, err = fork.acquire(ctx)
+	require.ErrorContains(t, err, "simulation queue full")
+	fork.queued.Store(1)
+
+	// Released fork is acquired by next simulation
+	release()
+	release, err = fork.acquire(ctx)
+	require.NoError(t, err)
+	release()
+	require.Zero(t, fork.queued.Load())
+}
@@ -7,6 +7,7 @@ import (
 	ormlist "cosmossdk.io/orm/model/ormlist"
 	ormtable "cosmossdk.io/orm/model/ormtable"
 	ormerrors "cosmossdk.io/orm/types/ormerrors"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type CursorTable interface {
@@ -128,20 +129,830 @@ func NewCursorTable(db ormtable.Schema) (CursorTable, error) {
 	return cursorTable{table}, nil
 }
 
+type FirmQuoteTable interface {
+	Insert(ctx context.Context, firmQuote *FirmQuote) error
+	Update(ctx context.Context, firmQuote *FirmQuote) error
+	Save(ctx context.Context, firmQuote *FirmQuote) error
+	Delete(ctx context.Context, firmQuote *FirmQuote) error
+	Has(ctx context.Context, id []byte) (found bool, err error)
+	// Get returns nil and an error which responds true to ormerrors.IsNotFound() if the record was not found.
+	Get(ctx context.Context, id []byte) (*FirmQuote, error)
+	List(ctx context.Context, prefixKey FirmQuoteIndexKey, opts ...ormlist.Option) (FirmQuoteIterator, error)
+	ListRange(ctx context.Context, from, to FirmQuoteIndexKey, opts ...ormlist.Option) (FirmQuoteIterator, error)
+	DeleteBy(ctx context.Context, prefixKey FirmQuoteIndexKey) error
+	DeleteRange(ctx context.Context, from, to FirmQuoteIndexKey) error
+
+	doNotImplement()
+}
+
+type FirmQuoteIterator struct {
+	ormtable.Iterator
+}
+
+func (i FirmQuoteIterator) Value() (*FirmQuote, error) {
+	var firmQuote FirmQuote
+	err := i.UnmarshalMessage(&firmQuote)
+	return &firmQuote, err
+}
+
+type FirmQuoteIndexKey interface {
+	id() uint32
+	values() []interface{}
+	firmQuoteIndexKey()
+}
+
+// primary key starting index..
+type FirmQuotePrimaryKey = FirmQuoteIdIndexKey
+
+type FirmQuoteIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x FirmQuoteIdIndexKey) id() uint32            { return 0 }
+func (x FirmQuoteIdIndexKey) values() []interface{} { return x.vs }
+func (x FirmQuoteIdIndexKey) firmQuoteIndexKey()    {}
+
+func (this FirmQuoteIdIndexKey) WithId(id []byte) FirmQuoteIdIndexKey {
+	this.vs = []interface{}{id}
+	return this
+}
+
+type FirmQuoteExpiryIndexKey struct {
+	vs []interface{}
+}
+
+func (x FirmQuoteExpiryIndexKey) id() uint32            { return 1 }
+func (x FirmQuoteExpiryIndexKey) values() []interface{} { return x.vs }
+func (x FirmQuoteExpiryIndexKey) firmQuoteIndexKey()    {}
+
+func (this FirmQuoteExpiryIndexKey) WithExpiry(expiry *timestamppb.Timestamp) FirmQuoteExpiryIndexKey {
+	this.vs = []interface{}{expiry}
+	return this
+}
+
+type FirmQuoteOrderIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x FirmQuoteOrderIdIndexKey) id() uint32            { return 2 }
+func (x FirmQuoteOrderIdIndexKey) values() []interface{} { return x.vs }
+func (x FirmQuoteOrderIdIndexKey) firmQuoteIndexKey()    {}
+
+func (this FirmQuoteOrderIdIndexKey) WithOrderId(order_id []byte) FirmQuoteOrderIdIndexKey {
+	this.vs = []interface{}{order_id}
+	return this
+}
+
+type firmQuoteTable struct {
+	table ormtable.Table
+}
+
+func (this firmQuoteTable) Insert(ctx context.Context, firmQuote *FirmQuote) error {
+	return this.table.Insert(ctx, firmQuote)
+}
+
+func (this firmQuoteTable) Update(ctx context.Context, firmQuote *FirmQuote) error {
+	return this.table.Update(ctx, firmQuote)
+}
+
+func (this firmQuoteTable) Save(ctx context.Context, firmQuote *FirmQuote) error {
+	return this.table.Save(ctx, firmQuote)
+}
+
+func (this firmQuoteTable) Delete(ctx context.Context, firmQuote *FirmQuote) error {
+	return this.table.Delete(ctx, firmQuote)
+}
+
+func (this firmQuoteTable) Has(ctx context.Context, id []byte) (found bool, err error) {
+	return this.table.PrimaryKey().Has(ctx, id)
+}
+
+func (this firmQuoteTable) Get(ctx context.Context, id []byte) (*FirmQuote, error) {
+	var firmQuote FirmQuote
+	found, err := this.table.PrimaryKey().Get(ctx, &firmQuote, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ormerrors.NotFound
+	}
+	return &firmQuote, nil
+}
+
+func (this firmQuoteTable) List(ctx context.Context, prefixKey FirmQuoteIndexKey, opts ...ormlist.Option) (FirmQuoteIterator, error) {
+	it, err := this.table.GetIndexByID(prefixKey.id()).List(ctx, prefixKey.values(), opts...)
+	return FirmQuoteIterator{it}, err
+}
+
+func (this firmQuoteTable) ListRange(ctx context.Context, from, to FirmQuoteIndexKey, opts ...ormlist.Option) (FirmQuoteIterator, error) {
+	it, err := this.table.GetIndexByID(from.id()).ListRange(ctx, from.values(), to.values(), opts...)
+	return FirmQuoteIterator{it}, err
+}
+
+func (this firmQuoteTable) DeleteBy(ctx context.Context, prefixKey FirmQuoteIndexKey) error {
+	return this.table.GetIndexByID(prefixKey.id()).DeleteBy(ctx, prefixKey.values()...)
+}
+
+func (this firmQuoteTable) DeleteRange(ctx context.Context, from, to FirmQuoteIndexKey) error {
+	return this.table.GetIndexByID(from.id()).DeleteRange(ctx, from.values(), to.values())
+}
+
+func (this firmQuoteTable) doNotImplement() {}
+
+var _ FirmQuoteTable = firmQuoteTable{}
+
+func NewFirmQuoteTable(db ormtable.Schema) (FirmQuoteTable, error) {
+	table := db.GetTable(&FirmQuote{})
+	if table == nil {
+		return nil, ormerrors.TableNotFound.Wrap(string((&FirmQuote{}).ProtoReflect().Descriptor().FullName()))
+	}
+	return firmQuoteTable{table}, nil
+}
+
+type OrderHistoryTable interface {
+	Insert(ctx context.Context, orderHistory *OrderHistory) error
+	InsertReturningId(ctx context.Context, orderHistory *OrderHistory) (uint64, error)
+	LastInsertedSequence(ctx context.Context) (uint64, error)
+	Update(ctx context.Context, orderHistory *OrderHistory) error
+	Save(ctx context.Context, orderHistory *OrderHistory) error
+	Delete(ctx context.Context, orderHistory *OrderHistory) error
+	Has(ctx context.Context, id uint64) (found bool, err error)
+	// Get returns nil and an error which responds true to ormerrors.IsNotFound() if the record was not found.
+	Get(ctx context.Context, id uint64) (*OrderHistory, error)
+	List(ctx context.Context, prefixKey OrderHistoryIndexKey, opts ...ormlist.Option) (OrderHistoryIterator, error)
+	ListRange(ctx context.Context, from, to OrderHistoryIndexKey, opts ...ormlist.Option) (OrderHistoryIterator, error)
+	DeleteBy(ctx context.Context, prefixKey OrderHistoryIndexKey) error
+	DeleteRange(ctx context.Context, from, to OrderHistoryIndexKey) error
+
+	doNotImplement()
+}
+
+type OrderHistoryIterator struct {
+	ormtable.Iterator
+}
+
+func (i OrderHistoryIterator) Value() (*OrderHistory, error) {
+	var orderHistory OrderHistory
+	err := i.UnmarshalMessage(&orderHistory)
+	return &orderHistory, err
+}
+
+type OrderHistoryIndexKey interface {
+	id() uint32
+	values() []interface{}
+	orderHistoryIndexKey()
+}
+
+// primary key starting index..
+type OrderHistoryPrimaryKey = OrderHistoryIdIndexKey
+
+type OrderHistoryIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x OrderHistoryIdIndexKey) id() uint32            { return 0 }
+func (x OrderHistoryIdIndexKey) values() []interface{} { return x.vs }
+func (x OrderHistoryIdIndexKey) orderHistoryIndexKey() {}
+
+func (this OrderHistoryIdIndexKey) WithId(id uint64) OrderHistoryIdIndexKey {
+	this.vs = []interface{}{id}
+	return this
+}
+
+type OrderHistoryOrderIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x OrderHistoryOrderIdIndexKey) id() uint32            { return 1 }
+func (x OrderHistoryOrderIdIndexKey) values() []interface{} { return x.vs }
+func (x OrderHistoryOrderIdIndexKey) orderHistoryIndexKey() {}
+
+func (this OrderHistoryOrderIdIndexKey) WithOrderId(order_id []byte) OrderHistoryOrderIdIndexKey {
+	this.vs = []interface{}{order_id}
+	return this
+}
+
+type orderHistoryTable struct {
+	table ormtable.AutoIncrementTable
+}
+
+func (this orderHistoryTable) Insert(ctx context.Context, orderHistory *OrderHistory) error {
+	return this.table.Insert(ctx, orderHistory)
+}
+
+func (this orderHistoryTable) Update(ctx context.Context, orderHistory *OrderHistory) error {
+	return this.table.Update(ctx, orderHistory)
+}
+
+func (this orderHistoryTable) Save(ctx context.Context, orderHistory *OrderHistory) error {
+	return this.table.Save(ctx, orderHistory)
+}
+
+func (this orderHistoryTable) Delete(ctx context.Context, orderHistory *OrderHistory) error {
+	return this.table.Delete(ctx, orderHistory)
+}
+
+func (this orderHistoryTable) InsertReturningId(ctx context.Context, orderHistory *OrderHistory) (uint64, error) {
+	return this.table.InsertReturningPKey(ctx, orderHistory)
+}
+
+func (this orderHistoryTable) LastInsertedSequence(ctx context.Context) (uint64, error) {
+	return this.table.LastInsertedSequence(ctx)
+}
+
+func (this orderHistoryTable) Has(ctx context.Context, id uint64) (found bool, err error) {
+	return this.table.PrimaryKey().Has(ctx, id)
+}
+
+func (this orderHistoryTable) Get(ctx context.Context, id uint64) (*OrderHistory, error) {
+	var orderHistory OrderHistory
+	found, err := this.table.PrimaryKey().Get(ctx, &orderHistory, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ormerrors.NotFound
+	}
+	return &orderHistory, nil
+}
+
+func (this orderHistoryTable) List(ctx context.Context, prefixKey OrderHistoryIndexKey, opts ...ormlist.Option) (OrderHistoryIterator, error) {
+	it, err := this.table.GetIndexByID(prefixKey.id()).List(ctx, prefixKey.values(), opts...)
+	return OrderHistoryIterator{it}, err
+}
+
+func (this orderHistoryTable) ListRange(ctx context.Context, from, to OrderHistoryIndexKey, opts ...ormlist.Option) (OrderHistoryIterator, error) {
+	it, err := this.table.GetIndexByID(from.id()).ListRange(ctx, from.values(), to.values(), opts...)
+	return OrderHistoryIterator{it}, err
+}
+
+func (this orderHistoryTable) DeleteBy(ctx context.Context, prefixKey OrderHistoryIndexKey) error {
+	return this.table.GetIndexByID(prefixKey.id()).DeleteBy(ctx, prefixKey.values()...)
+}
+
+func (this orderHistoryTable) DeleteRange(ctx context.Context, from, to OrderHistoryIndexKey) error {
+	return this.table.GetIndexByID(from.id()).DeleteRange(ctx, from.values(), to.values())
+}
+
+func (this orderHistoryTable) doNotImplement() {}
+
+var _ OrderHistoryTable = orderHistoryTable{}
+
+func NewOrderHistoryTable(db ormtable.Schema) (OrderHistoryTable, error) {
+	table := db.GetTable(&OrderHistory{})
+	if table == nil {
+		return nil, ormerrors.TableNotFound.Wrap(string((&OrderHistory{}).ProtoReflect().Descriptor().FullName()))
+	}
+	return orderHistoryTable{table.(ormtable.AutoIncrementTable)}, nil
+}
+
+type PendingClaimTable interface {
+	Insert(ctx context.Context, pendingClaim *PendingClaim) error
+	Update(ctx context.Context, pendingClaim *PendingClaim) error
+	Save(ctx context.Context, pendingClaim *PendingClaim) error
+	Delete(ctx context.Context, pendingClaim *PendingClaim) error
+	Has(ctx context.Context, order_id []byte) (found bool, err error)
+	// Get returns nil and an error which responds true to ormerrors.IsNotFound() if the record was not found.
+	Get(ctx context.Context, order_id []byte) (*PendingClaim, error)
+	List(ctx context.Context, prefixKey PendingClaimIndexKey, opts ...ormlist.Option) (PendingClaimIterator, error)
+	ListRange(ctx context.Context, from, to PendingClaimIndexKey, opts ...ormlist.Option) (PendingClaimIterator, error)
+	DeleteBy(ctx context.Context, prefixKey PendingClaimIndexKey) error
+	DeleteRange(ctx context.Context, from, to PendingClaimIndexKey) error
+
+	doNotImplement()
+}
+
+type PendingClaimIterator struct {
+	ormtable.Iterator
+}
+
+func (i PendingClaimIterator) Value() (*PendingClaim, error) {
+	var pendingClaim PendingClaim
+	err := i.UnmarshalMessage(&pendingClaim)
+	return &pendingClaim, err
+}
+
+type PendingClaimIndexKey interface {
+	id() uint32
+	values() []interface{}
+	pendingClaimIndexKey()
+}
+
+// primary key starting index..
+type PendingClaimPrimaryKey = PendingClaimOrderIdIndexKey
+
+type PendingClaimOrderIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x PendingClaimOrderIdIndexKey) id() uint32            { return 0 }
+func (x PendingClaimOrderIdIndexKey) values() []interface{} { return x.vs }
+func (x PendingClaimOrderIdIndexKey) pendingClaimIndexKey() {}
+
+func (this PendingClaimOrderIdIndexKey) WithOrderId(order_id []byte) PendingClaimOrderIdIndexKey {
+	this.vs = []interface{}{order_id}
+	return this
+}
+
+type PendingClaimSrcChainIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x PendingClaimSrcChainIdIndexKey) id() uint32            { return 1 }
+func (x PendingClaimSrcChainIdIndexKey) values() []interface{} { return x.vs }
+func (x PendingClaimSrcChainIdIndexKey) pendingClaimIndexKey() {}
+
+func (this PendingClaimSrcChainIdIndexKey) WithSrcChainId(src_chain_id uint64) PendingClaimSrcChainIdIndexKey {
+	this.vs = []interface{}{src_chain_id}
+	return this
+}
+
+type pendingClaimTable struct {
+	table ormtable.Table
+}
+
+func (this pendingClaimTable) Insert(ctx context.Context, pendingClaim *PendingClaim) error {
+	return this.table.Insert(ctx, pendingClaim)
+}
+
+func (this pendingClaimTable) Update(ctx context.Context, pendingClaim *PendingClaim) error {
+	return this.table.Update(ctx, pendingClaim)
+}
+
+func (this pendingClaimTable) Save(ctx context.Context, pendingClaim *PendingClaim) error {
+	return this.table.Save(ctx, pendingClaim)
+}
+
+func (this pendingClaimTable) Delete(ctx context.Context, pendingClaim *PendingClaim) error {
+	return this.table.Delete(ctx, pendingClaim)
+}
+
+func (this pendingClaimTable) Has(ctx context.Context, order_id []byte) (found bool, err error) {
+	return this.table.PrimaryKey().Has(ctx, order_id)
+}
+
+func (this pendingClaimTable) Get(ctx context.Context, order_id []byte) (*PendingClaim, error) {
+	var pendingClaim PendingClaim
+	found, err := this.table.PrimaryKey().Get(ctx, &pendingClaim, order_id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ormerrors.NotFound
+	}
+	return &pendingClaim, nil
+}
+
+func (this pendingClaimTable) List(ctx context.Context, prefixKey PendingClaimIndexKey, opts ...ormlist.Option) (PendingClaimIterator, error) {
+	it, err := this.table.GetIndexByID(prefixKey.id()).List(ctx, prefixKey.values(), opts...)
+	return PendingClaimIterator{it}, err
+}
+
+func (this pendingClaimTable) ListRange(ctx context.Context, from, to PendingClaimIndexKey, opts ...ormlist.Option) (PendingClaimIterator, error) {
+	it, err := this.table.GetIndexByID(from.id()).ListRange(ctx, from.values(), to.values(), opts...)
+	return PendingClaimIterator{it}, err
+}
+
+func (this pendingClaimTable) DeleteBy(ctx context.Context, prefixKey PendingClaimIndexKey) error {
+	return this.table.GetIndexByID(prefixKey.id()).DeleteBy(ctx, prefixKey.values()...)
+}
+
+func (this pendingClaimTable) DeleteRange(ctx context.Context, from, to PendingClaimIndexKey) error {
+	return this.table.GetIndexByID(from.id()).DeleteRange(ctx, from.values(), to.values())
+}
+
+func (this pendingClaimTable) doNotImplement() {}
+
+var _ PendingClaimTable = pendingClaimTable{}
+
+func NewPendingClaimTable(db ormtable.Schema) (PendingClaimTable, error) {
+	table := db.GetTable(&PendingClaim{})
+	if table == nil {
+		return nil, ormerrors.TableNotFound.Wrap(string((&PendingClaim{}).ProtoReflect().Descriptor().FullName()))
+	}
+	return pendingClaimTable{table}, nil
+}
+
+type PauseTable interface {
+	Insert(ctx context.Context, pause *Pause) error
+	Update(ctx context.Context, pause *Pause) error
+	Save(ctx context.Context, pause *Pause) error
+	Delete(ctx context.Context, pause *Pause) error
+	Has(ctx context.Context, scope string, chain_id uint64, token string) (found bool, err error)
+	// Get returns nil and an error which responds true to ormerrors.IsNotFound() if the record was not found.
+	Get(ctx context.Context, scope string, chain_id uint64, token string) (*Pause, error)
+	List(ctx context.Context, prefixKey PauseIndexKey, opts ...ormlist.Option) (PauseIterator, error)
+	ListRange(ctx context.Context, from, to PauseIndexKey, opts ...ormlist.Option) (PauseIterator, error)
+	DeleteBy(ctx context.Context, prefixKey PauseIndexKey) error
+	DeleteRange(ctx context.Context, from, to PauseIndexKey) error
+
+	doNotImplement()
+}
+
+type PauseIterator struct {
+	ormtable.Iterator
+}
+
+func (i PauseIterator) Value() (*Pause, error) {
+	var pause Pause
+	err := i.UnmarshalMessage(&pause)
+	return &pause, err
+}
+
+type PauseIndexKey interface {
+	id() uint32
+	values() []interface{}
+	pauseIndexKey()
+}
+
+// primary key starting index..
+type PausePrimaryKey = PauseScopeChainIdTokenIndexKey
+
+type PauseScopeChainIdTokenIndexKey struct {
+	vs []interface{}
+}
+
+func (x PauseScopeChainIdTokenIndexKey) id() uint32            { return 0 }
+func (x PauseScopeChainIdTokenIndexKey) values() []interface{} { return x.vs }
+func (x PauseScopeChainIdTokenIndexKey) pauseIndexKey()        {}
+
+func (this PauseScopeChainIdTokenIndexKey) WithScope(scope string) PauseScopeChainIdTokenIndexKey {
+	this.vs = []interface{}{scope}
+	return this
+}
+
+func (this PauseScopeChainIdTokenIndexKey) WithScopeChainId(scope string, chain_id uint64) PauseScopeChainIdTokenIndexKey {
+	this.vs = []interface{}{scope, chain_id}
+	return this
+}
+
+func (this PauseScopeChainIdTokenIndexKey) WithScopeChainIdToken(scope string, chain_id uint64, token string) PauseScopeChainIdTokenIndexKey {
+	this.vs = []interface{}{scope, chain_id, token}
+	return this
+}
+
+type pauseTable struct {
+	table ormtable.Table
+}
+
+func (this pauseTable) Insert(ctx context.Context, pause *Pause) error {
+	return this.table.Insert(ctx, pause)
+}
+
+func (this pauseTable) Update(ctx context.Context, pause *Pause) error {
+	return this.table.Update(ctx, pause)
+}
+
+func (this pauseTable) Save(ctx context.Context, pause *Pause) error {
+	return this.table.Save(ctx, pause)
+}
+
+func (this pauseTable) Delete(ctx context.Context, pause *Pause) error {
+	return this.table.Delete(ctx, pause)
+}
+
+func (this pauseTable) Has(ctx context.Context, scope string, chain_id uint64, token string) (found bool, err error) {
+	return this.table.PrimaryKey().Has(ctx, scope, chain_id, token)
+}
+
+func (this pauseTable) Get(ctx context.Context, scope string, chain_id uint64, token string) (*Pause, error) {
+	var pause Pause
+	found, err := this.table.PrimaryKey().Get(ctx, &pause, scope, chain_id, token)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ormerrors.NotFound
+	}
+	return &pause, nil
+}
+
+func (this pauseTable) List(ctx context.Context, prefixKey PauseIndexKey, opts ...ormlist.Option) (PauseIterator, error) {
+	it, err := this.table.GetIndexByID(prefixKey.id()).List(ctx, prefixKey.values(), opts...)
+	return PauseIterator{it}, err
+}
+
+func (this pauseTable) ListRange(ctx context.Context, from, to PauseIndexKey, opts ...ormlist.Option) (PauseIterator, error) {
+	it, err := this.table.GetIndexByID(from.id()).ListRange(ctx, from.values(), to.values(), opts...)
+	return PauseIterator{it}, err
+}
+
+func (this pauseTable) DeleteBy(ctx context.Context, prefixKey PauseIndexKey) error {
+	return this.table.GetIndexByID(prefixKey.id()).DeleteBy(ctx, prefixKey.values()...)
+}
+
+func (this pauseTable) DeleteRange(ctx context.Context, from, to PauseIndexKey) error {
+	return this.table.GetIndexByID(from.id()).DeleteRange(ctx, from.values(), to.values())
+}
+
+func (this pauseTable) doNotImplement() {}
+
+var _ PauseTable = pauseTable{}
+
+func NewPauseTable(db ormtable.Schema) (PauseTable, error) {
+	table := db.GetTable(&Pause{})
+	if table == nil {
+		return nil, ormerrors.TableNotFound.Wrap(string((&Pause{}).ProtoReflect().Descriptor().FullName()))
+	}
+	return pauseTable{table}, nil
+}
+
+type RelayTable interface {
+	Insert(ctx context.Context, relay *Relay) error
+	Update(ctx context.Context, relay *Relay) error
+	Save(ctx context.Context, relay *Relay) error
+	Delete(ctx context.Context, relay *Relay) error
+	Has(ctx context.Context, id []byte) (found bool, err error)
+	// Get returns nil and an error which responds true to ormerrors.IsNotFound() if the record was not found.
+	Get(ctx context.Context, id []byte) (*Relay, error)
+	List(ctx context.Context, prefixKey RelayIndexKey, opts ...ormlist.Option) (RelayIterator, error)
+	ListRange(ctx context.Context, from, to RelayIndexKey, opts ...ormlist.Option) (RelayIterator, error)
+	DeleteBy(ctx context.Context, prefixKey RelayIndexKey) error
+	DeleteRange(ctx context.Context, from, to RelayIndexKey) error
+
+	doNotImplement()
+}
+
+type RelayIterator struct {
+	ormtable.Iterator
+}
+
+func (i RelayIterator) Value() (*Relay, error) {
+	var relay Relay
+	err := i.UnmarshalMessage(&relay)
+	return &relay, err
+}
+
+type RelayIndexKey interface {
+	id() uint32
+	values() []interface{}
+	relayIndexKey()
+}
+
+// primary key starting index..
+type RelayPrimaryKey = RelayIdIndexKey
+
+type RelayIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x RelayIdIndexKey) id() uint32            { return 0 }
+func (x RelayIdIndexKey) values() []interface{} { return x.vs }
+func (x RelayIdIndexKey) relayIndexKey()        {}
+
+func (this RelayIdIndexKey) WithId(id []byte) RelayIdIndexKey {
+	this.vs = []interface{}{id}
+	return this
+}
+
+type RelayUserDayIndexKey struct {
+	vs []interface{}
+}
+
+func (x RelayUserDayIndexKey) id() uint32            { return 1 }
+func (x RelayUserDayIndexKey) values() []interface{} { return x.vs }
+func (x RelayUserDayIndexKey) relayIndexKey()        {}
+
+func (this RelayUserDayIndexKey) WithUser(user []byte) RelayUserDayIndexKey {
+	this.vs = []interface{}{user}
+	return this
+}
+
+func (this RelayUserDayIndexKey) WithUserDay(user []byte, day string) RelayUserDayIndexKey {
+	this.vs = []interface{}{user, day}
+	return this
+}
+
+type relayTable struct {
+	table ormtable.Table
+}
+
+func (this relayTable) Insert(ctx context.Context, relay *Relay) error {
+	return this.table.Insert(ctx, relay)
+}
+
+func (this relayTable) Update(ctx context.Context, relay *Relay) error {
+	return this.table.Update(ctx, relay)
+}
+
+func (this relayTable) Save(ctx context.Context, relay *Relay) error {
+	return this.table.Save(ctx, relay)
+}
+
+func (this relayTable) Delete(ctx context.Context, relay *Relay) error {
+	return this.table.Delete(ctx, relay)
+}
+
+func (this relayTable) Has(ctx context.Context, id []byte) (found bool, err error) {
+	return this.table.PrimaryKey().Has(ctx, id)
+}
+
+func (this relayTable) Get(ctx context.Context, id []byte) (*Relay, error) {
+	var relay Relay
+	found, err := this.table.PrimaryKey().Get(ctx, &relay, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ormerrors.NotFound
+	}
+	return &relay, nil
+}
+
+func (this relayTable) List(ctx context.Context, prefixKey RelayIndexKey, opts ...ormlist.Option) (RelayIterator, error) {
+	it, err := this.table.GetIndexByID(prefixKey.id()).List(ctx, prefixKey.values(), opts...)
+	return RelayIterator{it}, err
+}
+
+func (this relayTable) ListRange(ctx context.Context, from, to RelayIndexKey, opts ...ormlist.Option) (RelayIterator, error) {
+	it, err := this.table.GetIndexByID(from.id()).ListRange(ctx, from.values(), to.values(), opts...)
+	return RelayIterator{it}, err
+}
+
+func (this relayTable) DeleteBy(ctx context.Context, prefixKey RelayIndexKey) error {
+	return this.table.GetIndexByID(prefixKey.id()).DeleteBy(ctx, prefixKey.values()...)
+}
+
+func (this relayTable) DeleteRange(ctx context.Context, from, to RelayIndexKey) error {
+	return this.table.GetIndexByID(from.id()).DeleteRange(ctx, from.values(), to.values())
+}
+
+func (this relayTable) doNotImplement() {}
+
+var _ RelayTable = relayTable{}
+
+func NewRelayTable(db ormtable.Schema) (RelayTable, error) {
+	table := db.GetTable(&Relay{})
+	if table == nil {
+		return nil, ormerrors.TableNotFound.Wrap(string((&Relay{}).ProtoReflect().Descriptor().FullName()))
+	}
+	return relayTable{table}, nil
+}
+
+type TrackedFillTable interface {
+	Insert(ctx context.Context, trackedFill *TrackedFill) error
+	Update(ctx context.Context, trackedFill *TrackedFill) error
+	Save(ctx context.Context, trackedFill *TrackedFill) error
+	Delete(ctx context.Context, trackedFill *TrackedFill) error
+	Has(ctx context.Context, order_id []byte) (found bool, err error)
+	// Get returns nil and an error which responds true to ormerrors.IsNotFound() if the record was not found.
+	Get(ctx context.Context, order_id []byte) (*TrackedFill, error)
+	List(ctx context.Context, prefixKey TrackedFillIndexKey, opts ...ormlist.Option) (TrackedFillIterator, error)
+	ListRange(ctx context.Context, from, to TrackedFillIndexKey, opts ...ormlist.Option) (TrackedFillIterator, error)
+	DeleteBy(ctx context.Context, prefixKey TrackedFillIndexKey) error
+	DeleteRange(ctx context.Context, from, to TrackedFillIndexKey) error
+
+	doNotImplement()
+}
+
+type TrackedFillIterator struct {
+	ormtable.Iterator
+}
+
+func (i TrackedFillIterator) Value() (*TrackedFill, error) {
+	var trackedFill TrackedFill
+	err := i.UnmarshalMessage(&trackedFill)
+	return &trackedFill, err
+}
+
+type TrackedFillIndexKey interface {
+	id() uint32
+	values() []interface{}
+	trackedFillIndexKey()
+}
+
+// primary key starting index..
+type TrackedFillPrimaryKey = TrackedFillOrderIdIndexKey
+
+type TrackedFillOrderIdIndexKey struct {
+	vs []interface{}
+}
+
+func (x TrackedFillOrderIdIndexKey) id() uint32            { return 0 }
+func (x TrackedFillOrderIdIndexKey) values() []interface{} { return x.vs }
+func (x TrackedFillOrderIdIndexKey) trackedFillIndexKey()  {}
+
+func (this TrackedFillOrderIdIndexKey) WithOrderId(order_id []byte) TrackedFillOrderIdIndexKey {
+	this.vs = []interface{}{order_id}
+	return this
+}
+
+type trackedFillTable struct {
+	table ormtable.Table
+}
+
+func (this trackedFillTable) Insert(ctx context.Context, trackedFill *TrackedFill) error {
+	return this.table.Insert(ctx, trackedFill)
+}
+
+func (this trackedFillTable) Update(ctx context.Context, trackedFill *TrackedFill) error {
+	return this.table.Update(ctx, trackedFill)
+}
+
+func (this trackedFillTable) Save(ctx context.Context, trackedFill *TrackedFill) error {
+	return this.table.Save(ctx, trackedFill)
+}
+
+func (this trackedFillTable) Delete(ctx context.Context, trackedFill *TrackedFill) error {
+	return this.table.Delete(ctx, trackedFill)
+}
+
+func (this trackedFillTable) Has(ctx context.Context, order_id []byte) (found bool, err error) {
+	return this.table.PrimaryKey().Has(ctx, order_id)
+}
+
+func (this trackedFillTable) Get(ctx context.Context, order_id []byte) (*TrackedFill, error) {
+	var trackedFill TrackedFill
+	found, err := this.table.PrimaryKey().Get(ctx, &trackedFill, order_id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ormerrors.NotFound
+	}
+	return &trackedFill, nil
+}
+
+func (this trackedFillTable) List(ctx context.Context, prefixKey TrackedFillIndexKey, opts ...ormlist.Option) (TrackedFillIterator, error) {
+	it, err := this.table.GetIndexByID(prefixKey.id()).List(ctx, prefixKey.values(), opts...)
+	return TrackedFillIterator{it}, err
+}
+
+func (this trackedFillTable) ListRange(ctx context.Context, from, to TrackedFillIndexKey, opts ...ormlist.Option) (TrackedFillIterator, error) {
+	it, err := this.table.GetIndexByID(from.id()).ListRange(ctx, from.values(), to.values(), opts...)
+	return TrackedFillIterator{it}, err
+}
+
+func (this trackedFillTable) DeleteBy(ctx context.Context, prefixKey TrackedFillIndexKey) error {
+	return this.table.GetIndexByID(prefixKey.id()).DeleteBy(ctx, prefixKey.values()...)
+}
+
+func (this trackedFillTable) DeleteRange(ctx context.Context, from, to TrackedFillIndexKey) error {
+	return this.table.GetIndexByID(from.id()).DeleteRange(ctx, from.values(), to.values())
+}
+
+func (this trackedFillTable) doNotImplement() {}
+
+var _ TrackedFillTable = trackedFillTable{}
+
+func NewTrackedFillTable(db ormtable.Schema) (TrackedFillTable, error) {
+	table := db.GetTable(&TrackedFill{})
+	if table == nil {
+		return nil, ormerrors.TableNotFound.Wrap(string((&TrackedFill{}).ProtoReflect().Descriptor().FullName()))
+	}
+	return trackedFillTable{table}, nil
+}
+
 type SolverStore interface {
 	CursorTable() CursorTable
+	FirmQuoteTable() FirmQuoteTable
+	OrderHistoryTable() OrderHistoryTable
+	PendingClaimTable() PendingClaimTable
+	PauseTable() PauseTable
+	RelayTable() RelayTable
+	TrackedFillTable() TrackedFillTable
 
 	doNotImplement()
 }
 
 type solverStore struct {
-	cursor CursorTable
+	cursor       CursorTable
+	firmQuote    FirmQuoteTable
+	orderHistory OrderHistoryTable
+	pendingClaim PendingClaimTable
+	pause        PauseTable
+	relay        RelayTable
+	trackedFill  TrackedFillTable
 }
 
 func (x solverStore) CursorTable() CursorTable {
 	return x.cursor
 }
 
+func (x solverStore) FirmQuoteTable() FirmQuoteTable {
+	return x.firmQuote
+}
+
+func (x solverStore) OrderHistoryTable() OrderHistoryTable {
+	return x.orderHistory
+}
+
+func (x solverStore) PendingClaimTable() PendingClaimTable {
+	return x.pendingClaim
+}
+
+func (x solverStore) PauseTable() PauseTable {
+	return x.pause
+}
+
+func (x solverStore) RelayTable() RelayTable {
+	return x.relay
+}
+
+func (x solverStore) TrackedFillTable() TrackedFillTable {
+	return x.trackedFill
+}
+
 func (solverStore) doNotImplement() {}
 
 var _ SolverStore = solverStore{}
@@ -152,7 +963,43 @@ func NewSolverStore(db ormtable.Schema) (SolverStore, error) {
 		return nil, err
 	}
 
+	firmQuoteTable, err := NewFirmQuoteTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	orderHistoryTable, err := NewOrderHistoryTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingClaimTable, err := NewPendingClaimTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	pauseTable, err := NewPauseTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	relayTable, err := NewRelayTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	trackedFillTable, err := NewTrackedFillTable(db)
+	if err != nil {
+		return nil, err
+	}
+
 	return solverStore{
 		cursorTable,
+		firmQuoteTable,
+		orderHistoryTable,
+		pendingClaimTable,
+		pauseTable,
+		relayTable,
+		trackedFillTable,
 	}, nil
 }
@@ -10,6 +10,7 @@ import (
 	_ "cosmossdk.io/api/cosmos/orm/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -90,12 +91,580 @@ func (x *Cursor) GetTxSig() []byte {
 	return nil
 }
 
+type FirmQuote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                          // Quote ID; hash of the bound order ID and quoted terms
+	Expiry        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiry,proto3" json:"expiry,omitempty"`                  // Quote is honoured for orders opened before expiry
+	Signature     []byte                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`            // Solver signature of the quote ID and expiry
+	OrderId       []byte                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // Order the quote is bound to, derived from the user and nonce
+	Used          bool                   `protobuf:"varint,5,opt,name=used,proto3" json:"used,omitempty"`                     // True once the order was filled or rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirmQuote) Reset() {
+	*x = FirmQuote{}
+	mi := &file_solver_app_solver_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirmQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirmQuote) ProtoMessage() {}
+
+func (x *FirmQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_solver_app_solver_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirmQuote.ProtoReflect.Descriptor instead.
+func (*FirmQuote) Descriptor() ([]byte, []int) {
+	return file_solver_app_solver_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FirmQuote) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *FirmQuote) GetExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+func (x *FirmQuote) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *FirmQuote) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
+func (x *FirmQuote) GetUsed() bool {
+	if x != nil {
+		return x.Used
+	}
+	return false
+}
+
+type OrderHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // Auto-incremented ID
+	OrderId       []byte                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	SrcChainId    uint64                 `protobuf:"varint,3,opt,name=src_chain_id,json=srcChainId,proto3" json:"src_chain_id,omitempty"`
+	DestChainId   uint64                 `protobuf:"varint,4,opt,name=dest_chain_id,json=destChainId,proto3" json:"dest_chain_id,omitempty"`   // Zero if order not pending
+	Status        uint32                 `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`                                  // On-chain order status (solvernet.OrderStatus)
+	Action        string                 `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`                                   // Processor action or solver tx (e.g. Outbox:Fill)
+	EventTx       string                 `protobuf:"bytes,7,opt,name=event_tx,json=eventTx,proto3" json:"event_tx,omitempty"`                  // Inbox event tx hash or signature
+	EventHeight   uint64                 `protobuf:"varint,8,opt,name=event_height,json=eventHeight,proto3" json:"event_height,omitempty"`     // Inbox event block height or slot
+	Tx            string                 `protobuf:"bytes,9,opt,name=tx,proto3" json:"tx,omitempty"`                                           // Solver tx hash
+	RejectReason  uint32                 `protobuf:"varint,10,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"` // Reject reason (types.RejectReason)
+	PnlUsd        float64                `protobuf:"fixed64,11,opt,name=pnl_usd,json=pnlUsd,proto3" json:"pnl_usd,omitempty"`                  // Solver tx PnL in USD
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderHistory) Reset() {
+	*x = OrderHistory{}
+	mi := &file_solver_app_solver_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderHistory) ProtoMessage() {}
+
+func (x *OrderHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_solver_app_solver_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderHistory.ProtoReflect.Descriptor instead.
+func (*OrderHistory) Descriptor() ([]byte, []int) {
+	return file_solver_app_solver_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderHistory) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OrderHistory) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
+func (x *OrderHistory) GetSrcChainId() uint64 {
+	if x != nil {
+		return x.SrcChainId
+	}
+	return 0
+}
+
+func (x *OrderHistory) GetDestChainId() uint64 {
+	if x != nil {
+		return x.DestChainId
+	}
+	return 0
+}
+
+func (x *OrderHistory) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *OrderHistory) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *OrderHistory) GetEventTx() string {
+	if x != nil {
+		return x.EventTx
+	}
+	return ""
+}
+
+func (x *OrderHistory) GetEventHeight() uint64 {
+	if x != nil {
+		return x.EventHeight
+	}
+	return 0
+}
+
+func (x *OrderHistory) GetTx() string {
+	if x != nil {
+		return x.Tx
+	}
+	return ""
+}
+
+func (x *OrderHistory) GetRejectReason() uint32 {
+	if x != nil {
+		return x.RejectReason
+	}
+	return 0
+}
+
+func (x *OrderHistory) GetPnlUsd() float64 {
+	if x != nil {
+		return x.PnlUsd
+	}
+	return 0
+}
+
+func (x *OrderHistory) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type PendingClaim struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       []byte                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	SrcChainId    uint64                 `protobuf:"varint,2,opt,name=src_chain_id,json=srcChainId,proto3" json:"src_chain_id,omitempty"`
+	UsdValue      float64                `protobuf:"fixed64,3,opt,name=usd_value,json=usdValue,proto3" json:"usd_value,omitempty"` // Order deposits value in USD when filled
+	FilledAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=filled_at,json=filledAt,proto3" json:"filled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingClaim) Reset() {
+	*x = PendingClaim{}
+	mi := &file_solver_app_solver_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingClaim) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingClaim) ProtoMessage() {}
+
+func (x *PendingClaim) ProtoReflect() protoreflect.Message {
+	mi := &file_solver_app_solver_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingClaim.ProtoReflect.Descriptor instead.
+func (*PendingClaim) Descriptor() ([]byte, []int) {
+	return file_solver_app_solver_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PendingClaim) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
+func (x *PendingClaim) GetSrcChainId() uint64 {
+	if x != nil {
+		return x.SrcChainId
+	}
+	return 0
+}
+
+func (x *PendingClaim) GetUsdValue() float64 {
+	if x != nil {
+		return x.UsdValue
+	}
+	return 0
+}
+
+func (x *PendingClaim) GetFilledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FilledAt
+	}
+	return nil
+}
+
+type Pause struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`                     // Paused activity (types.PauseScope)
+	ChainId       uint64                 `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"` // Zero pauses all chains
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`                     // Token symbol, empty pauses all tokens
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pause) Reset() {
+	*x = Pause{}
+	mi := &file_solver_app_solver_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pause) ProtoMessage() {}
+
+func (x *Pause) ProtoReflect() protoreflect.Message {
+	mi := &file_solver_app_solver_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pause.ProtoReflect.Descriptor instead.
+func (*Pause) Descriptor() ([]byte, []int) {
+	return file_solver_app_solver_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Pause) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *Pause) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Pause) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Pause) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Pause) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Relay struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                      // Relay ID; hash of the origin chain, settler, user and nonce
+	RequestHash      []byte                 `protobuf:"bytes,2,opt,name=request_hash,json=requestHash,proto3" json:"request_hash,omitempty"` // Hash of the relayed order and signature
+	ChainId          uint64                 `protobuf:"varint,3,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	User             []byte                 `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	Day              string                 `protobuf:"bytes,5,opt,name=day,proto3" json:"day,omitempty"`                               // UTC day of submission (YYYY-MM-DD), for daily budgets
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                         // Relay status (types.RelayStatus)
+	TxHash           string                 `protobuf:"bytes,7,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`           // OpenFor tx hash, once submitted
+	OrderId          []byte                 `protobuf:"bytes,8,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`        // Opened order ID, once mined
+	FeeUsd           float64                `protobuf:"fixed64,9,opt,name=fee_usd,json=feeUsd,proto3" json:"fee_usd,omitempty"`         // Sponsored tx fee in USD, once mined
+	ErrorCode        string                 `protobuf:"bytes,10,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // Relay error code, if failed
+	ErrorMessage     string                 `protobuf:"bytes,11,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ErrorDescription string                 `protobuf:"bytes,12,opt,name=error_description,json=errorDescription,proto3" json:"error_description,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Relay) Reset() {
+	*x = Relay{}
+	mi := &file_solver_app_solver_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Relay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relay) ProtoMessage() {}
+
+func (x *Relay) ProtoReflect() protoreflect.Message {
+	mi := &file_solver_app_solver_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relay.ProtoReflect.Descriptor instead.
+func (*Relay) Descriptor() ([]byte, []int) {
+	return file_solver_app_solver_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Relay) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Relay) GetRequestHash() []byte {
+	if x != nil {
+		return x.RequestHash
+	}
+	return nil
+}
+
+func (x *Relay) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Relay) GetUser() []byte {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Relay) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *Relay) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Relay) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Relay) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
+func (x *Relay) GetFeeUsd() float64 {
+	if x != nil {
+		return x.FeeUsd
+	}
+	return 0
+}
+
+func (x *Relay) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *Relay) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Relay) GetErrorDescription() string {
+	if x != nil {
+		return x.ErrorDescription
+	}
+	return ""
+}
+
+func (x *Relay) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Relay) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type TrackedFill struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       []byte                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	SrcChainId    uint64                 `protobuf:"varint,2,opt,name=src_chain_id,json=srcChainId,proto3" json:"src_chain_id,omitempty"`
+	EventHeight   uint64                 `protobuf:"varint,3,opt,name=event_height,json=eventHeight,proto3" json:"event_height,omitempty"` // Height of the processed pending order event
+	EventTx       string                 `protobuf:"bytes,4,opt,name=event_tx,json=eventTx,proto3" json:"event_tx,omitempty"`              // Tx of the processed pending order event
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackedFill) Reset() {
+	*x = TrackedFill{}
+	mi := &file_solver_app_solver_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackedFill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackedFill) ProtoMessage() {}
+
+func (x *TrackedFill) ProtoReflect() protoreflect.Message {
+	mi := &file_solver_app_solver_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackedFill.ProtoReflect.Descriptor instead.
+func (*TrackedFill) Descriptor() ([]byte, []int) {
+	return file_solver_app_solver_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TrackedFill) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
+func (x *TrackedFill) GetSrcChainId() uint64 {
+	if x != nil {
+		return x.SrcChainId
+	}
+	return 0
+}
+
+func (x *TrackedFill) GetEventHeight() uint64 {
+	if x != nil {
+		return x.EventHeight
+	}
+	return 0
+}
+
+func (x *TrackedFill) GetEventTx() string {
+	if x != nil {
+		return x.EventTx
+	}
+	return ""
+}
+
 var File_solver_app_solver_proto protoreflect.FileDescriptor
 
 const file_solver_app_solver_proto_rawDesc = "" +
 	"\n" +
 	"\x17solver/app/solver.proto\x12\n" +
-	"solver.app\x1a\x17cosmos/orm/v1/orm.proto\"\x9d\x01\n" +
+	"solver.app\x1a\x17cosmos/orm/v1/orm.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9d\x01\n" +
 	"\x06Cursor\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12\x1d\n" +
 	"\n" +
@@ -103,7 +672,87 @@ const file_solver_app_solver_proto_rawDesc = "" +
 	"\fblock_height\x18\x03 \x01(\x04R\vblockHeight\x12\x15\n" +
 	"\x06tx_sig\x18\x04 \x01(\fR\x05txSig:\x1f\xf2\x9eӎ\x03\x19\n" +
 	"\x15\n" +
-	"\x13chain_id,conf_level\x18\x01B\x8f\x01\n" +
+	"\x13chain_id,conf_level\x18\x01\"\xc6\x01\n" +
+	"\tFirmQuote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x122\n" +
+	"\x06expiry\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06expiry\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\fR\tsignature\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\fR\aorderId\x12\x12\n" +
+	"\x04used\x18\x05 \x01(\bR\x04used:(\xf2\x9eӎ\x03\"\n" +
+	"\x04\n" +
+	"\x02id\x12\n" +
+	"\n" +
+	"\x06expiry\x10\x01\x12\f\n" +
+	"\border_id\x10\x02\x18\x03\"\x96\x03\n" +
+	"\fOrderHistory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\fR\aorderId\x12 \n" +
+	"\fsrc_chain_id\x18\x03 \x01(\x04R\n" +
+	"srcChainId\x12\"\n" +
+	"\rdest_chain_id\x18\x04 \x01(\x04R\vdestChainId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\rR\x06status\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12\x19\n" +
+	"\bevent_tx\x18\a \x01(\tR\aeventTx\x12!\n" +
+	"\fevent_height\x18\b \x01(\x04R\veventHeight\x12\x0e\n" +
+	"\x02tx\x18\t \x01(\tR\x02tx\x12#\n" +
+	"\rreject_reason\x18\n" +
+	" \x01(\rR\frejectReason\x12\x17\n" +
+	"\apnl_usd\x18\v \x01(\x01R\x06pnlUsd\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt:\x1e\xf2\x9eӎ\x03\x18\n" +
+	"\x06\n" +
+	"\x02id\x10\x01\x12\f\n" +
+	"\border_id\x10\x01\x18\x04\"\xc9\x01\n" +
+	"\fPendingClaim\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\fR\aorderId\x12 \n" +
+	"\fsrc_chain_id\x18\x02 \x01(\x04R\n" +
+	"srcChainId\x12\x1b\n" +
+	"\tusd_value\x18\x03 \x01(\x01R\busdValue\x127\n" +
+	"\tfilled_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bfilledAt:&\xf2\x9eӎ\x03 \n" +
+	"\n" +
+	"\n" +
+	"\border_id\x12\x10\n" +
+	"\fsrc_chain_id\x10\x01\x18\x05\"\xc3\x01\n" +
+	"\x05Pause\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\x04R\achainId\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt: \xf2\x9eӎ\x03\x1a\n" +
+	"\x16\n" +
+	"\x14scope,chain_id,token\x18\x06\"\xe5\x03\n" +
+	"\x05Relay\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12!\n" +
+	"\frequest_hash\x18\x02 \x01(\fR\vrequestHash\x12\x19\n" +
+	"\bchain_id\x18\x03 \x01(\x04R\achainId\x12\x12\n" +
+	"\x04user\x18\x04 \x01(\fR\x04user\x12\x10\n" +
+	"\x03day\x18\x05 \x01(\tR\x03day\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x17\n" +
+	"\atx_hash\x18\a \x01(\tR\x06txHash\x12\x19\n" +
+	"\border_id\x18\b \x01(\fR\aorderId\x12\x17\n" +
+	"\afee_usd\x18\t \x01(\x01R\x06feeUsd\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\n" +
+	" \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_message\x18\v \x01(\tR\ferrorMessage\x12+\n" +
+	"\x11error_description\x18\f \x01(\tR\x10errorDescription\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt:\x1c\xf2\x9eӎ\x03\x16\n" +
+	"\x04\n" +
+	"\x02id\x12\f\n" +
+	"\buser,day\x10\x01\x18\a\"\x9e\x01\n" +
+	"\vTrackedFill\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\fR\aorderId\x12 \n" +
+	"\fsrc_chain_id\x18\x02 \x01(\x04R\n" +
+	"srcChainId\x12!\n" +
+	"\fevent_height\x18\x03 \x01(\x04R\veventHeight\x12\x19\n" +
+	"\bevent_tx\x18\x04 \x01(\tR\aeventTx:\x14\xf2\x9eӎ\x03\x0e\n" +
+	"\n" +
+	"\n" +
+	"\border_id\x18\bB\x8f\x01\n" +
 	"\x0ecom.solver.appB\vSolverProtoP\x01Z'github.com/omni-network/omni/solver/app\xa2\x02\x03SAX\xaa\x02\n" +
 	"Solver.App\xca\x02\n" +
 	"Solver\\App\xe2\x02\x16Solver\\App\\GPBMetadata\xea\x02\vSolver::Appb\x06proto3"
@@ -120,16 +769,29 @@ func file_solver_app_solver_proto_rawDescGZIP() []byte {
 	return file_solver_app_solver_proto_rawDescData
 }
 
-var file_solver_app_solver_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_solver_app_solver_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_solver_app_solver_proto_goTypes = []any{
-	(*Cursor)(nil), // 0: solver.app.Cursor
+	(*Cursor)(nil),                // 0: solver.app.Cursor
+	(*FirmQuote)(nil),             // 1: solver.app.FirmQuote
+	(*OrderHistory)(nil),          // 2: solver.app.OrderHistory
+	(*PendingClaim)(nil),          // 3: solver.app.PendingClaim
+	(*Pause)(nil),                 // 4: solver.app.Pause
+	(*Relay)(nil),                 // 5: solver.app.Relay
+	(*TrackedFill)(nil),           // 6: solver.app.TrackedFill
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
 }
 var file_solver_app_solver_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	7, // 0: solver.app.FirmQuote.expiry:type_name -> google.protobuf.Timestamp
+	7, // 1: solver.app.OrderHistory.created_at:type_name -> google.protobuf.Timestamp
+	7, // 2: solver.app.PendingClaim.filled_at:type_name -> google.protobuf.Timestamp
+	7, // 3: solver.app.Pause.created_at:type_name -> google.protobuf.Timestamp
+	7, // 4: solver.app.Relay.created_at:type_name -> google.protobuf.Timestamp
+	7, // 5: solver.app.Relay.updated_at:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_solver_app_solver_proto_init() }
@@ -143,7 +805,7 @@ func file_solver_app_solver_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_solver_app_solver_proto_rawDesc), len(file_solver_app_solver_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,239 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// streamBuffer is the number of updates buffered per subscriber.
+	// Subscribers that fall further behind are dropped and must reconnect.
+	streamBuffer = 64
+
+	// streamKeepAlive is the interval at which keep-alive comments are sent to subscribers.
+	streamKeepAlive = 15 * time.Second
+)
+
+// orderFilter matches order updates by order ID and/or user.
+type orderFilter struct {
+	OrderID *OrderID
+	User    *common.Address
+}
+
+func (f orderFilter) Match(u types.OrderUpdate) bool {
+	if f.OrderID != nil && *f.OrderID != OrderID(u.OrderID) {
+		return false
+	}
+
+	if f.User != nil && *f.User != u.User {
+		return false
+	}
+
+	return true
+}
+
+type orderSub struct {
+	filter orderFilter
+	ch     chan types.OrderUpdate
+}
+
+// orderStream broadcasts order updates to subscribers.
+type orderStream struct {
+	mu   sync.Mutex
+	subs map[*orderSub]bool
+}
+
+func newOrderStream() *orderStream {
+	return &orderStream{subs: make(map[*orderSub]bool)}
+}
+
+// Subscribe returns a channel of updates matching the filter and an unsubscribe function.
+// The channel is closed if the subscriber falls behind.
+func (s *orderStream) Subscribe(filter orderFilter) (<-chan types.OrderUpdate, func()) {
+	sub := &orderSub{
+		filter: filter,
+		ch:     make(chan types.OrderUpdate, streamBuffer),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subs[sub] = true
+	streamSubscribers.Inc()
+
+	return sub.ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.remove(sub)
+	}
+}
+
+// Publish sends the update to all matching subscribers without blocking.
+func (s *orderStream) Publish(update types.OrderUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subs {
+		if !sub.filter.Match(update) {
+			continue
+		}
+
+		select {
+		case sub.ch <- update:
+		default:
+			streamDropped.Inc()
+			s.remove(sub)
+		}
+	}
+}
+
+// remove closes and removes the subscriber if present. It must be called with the lock held.
+func (s *orderStream) remove(sub *orderSub) {
+	if !s.subs[sub] {
+		return
+	}
+
+	delete(s.subs, sub)
+	close(sub.ch)
+	streamSubscribers.Dec()
+}
+
+// withStreamUpdates returns a recordHistoryFunc that also publishes the entries as order updates.
+func withStreamUpdates(record recordHistoryFunc, stream *orderStream) recordHistoryFunc {
+	return func(ctx context.Context, order Order, entry historyEntry) {
+		record(ctx, order, entry)
+
+		stream.Publish(types.OrderUpdate{
+			OrderID:           common.Hash(order.ID),
+			User:              order.User,
+			OrderHistoryEntry: historyToType(newHistoryProto(order, entry, time.Now())),
+		})
+	}
+}
+
+// newOrderStreamHandler returns a handler streaming order updates as server-sent events.
+// Clients subscribe by order ID and/or user address query parameters, e.g. ?orderId=0x..&user=0x..
+// Order ID subscriptions first receive the order's existing history.
+func newOrderStreamHandler(stream *orderStream, history *orderHistory) Handler {
+	return Handler{
+		Endpoint:       endpointOrderStream,
+		SkipInstrument: true, // Long-lived streams skew latency, see streamSubscribers instead.
+		Stream: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			filter, err := parseOrderFilter(r)
+			if err != nil {
+				writeErrResponse(ctx, w, err)
+				return
+			}
+
+			updates, unsubscribe := stream.Subscribe(filter)
+			defer unsubscribe()
+
+			// Subscribe before reading history, so no updates are missed, only possibly duplicated.
+			var replay []*OrderHistory
+			if filter.OrderID != nil {
+				replay, err = history.Get(ctx, *filter.OrderID)
+				if err != nil {
+					writeErrResponse(ctx, w, err)
+					return
+				}
+			}
+
+			rc := http.NewResponseController(w)
+			if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+				log.DebugErr(ctx, "Failed disabling stream write deadline", err)
+			}
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+
+			for _, e := range replay {
+				update := types.OrderUpdate{OrderID: common.Hash(*filter.OrderID), OrderHistoryEntry: historyToType(e)}
+				if err := writeEvent(w, rc, update); err != nil {
+					return
+				}
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+
+			ticker := time.NewTicker(streamKeepAlive)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case update, ok := <-updates:
+					if !ok {
+						log.Debug(ctx, "Dropped slow order stream subscriber")
+						return
+					}
+					if err := writeEvent(w, rc, update); err != nil {
+						return
+					}
+				case <-ticker.C:
+					if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+						return
+					}
+					if err := rc.Flush(); err != nil {
+						return
+					}
+				}
+			}
+		}),
+	}
+}
+
+// parseOrderFilter parses the order ID and user query parameters, requiring at least one.
+func parseOrderFilter(r *http.Request) (orderFilter, error) {
+	var resp orderFilter
+	if s := r.URL.Query().Get("orderId"); s != "" {
+		id, err := parseOrderID(s)
+		if err != nil {
+			return orderFilter{}, err
+		}
+		orderID := OrderID(id)
+		resp.OrderID = &orderID
+	}
+
+	if s := r.URL.Query().Get("user"); s != "" {
+		if !common.IsHexAddress(s) {
+			return orderFilter{}, newAPIError(errors.New("invalid user address"), http.StatusBadRequest)
+		}
+		user := common.HexToAddress(s)
+		resp.User = &user
+	}
+
+	if resp.OrderID == nil && resp.User == nil {
+		return orderFilter{}, newAPIError(errors.New("missing orderId or user query parameter"), http.StatusBadRequest)
+	}
+
+	return resp, nil
+}
+
+// writeEvent writes the update as a server-sent event and flushes it.
+func writeEvent(w http.ResponseWriter, rc *http.ResponseController, update types.OrderUpdate) error {
+	bz, err := json.Marshal(update)
+	if err != nil {
+		return errors.Wrap(err, "marshal update")
+	}
+
+	if _, err := fmt.Fprintf(w, "event: update\ndata: %s\n\n", bz); err != nil {
+		return errors.Wrap(err, "write event")
+	}
+
+	return rc.Flush()
+}
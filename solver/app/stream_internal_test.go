@@ -0,0 +1,152 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/contracts/solvernet"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/solver/types"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	db "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStream(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	history, err := newOrderHistory(db.NewMemDB())
+	require.NoError(t, err)
+	stream := newOrderStream()
+	record := withStreamUpdates(newHistoryRecorder(history), stream)
+
+	user := common.HexToAddress("0x1111")
+	order := Order{ID: OrderID{1}, SourceChainID: evmchain.IDBase, Status: solvernet.StatusPending, User: user}
+	other := Order{ID: OrderID{2}, SourceChainID: evmchain.IDBase, Status: solvernet.StatusPending, User: common.HexToAddress("0x2222")}
+
+	// Existing history is replayed to order ID subscribers
+	record(ctx, order, historyEntry{Action: historyEvent, Event: Event{Tx: "0xopen"}})
+
+	h := newOrderStreamHandler(stream, history)
+	srv := httptest.NewServer(h.Stream)
+	defer srv.Close()
+
+	subscribe := func(query string) (*bufio.Scanner, func()) {
+		t.Helper()
+		ctx, cancel := context.WithCancel(ctx)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?"+query, nil)
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		return bufio.NewScanner(resp.Body), func() {
+			cancel()
+			_ = resp.Body.Close()
+		}
+	}
+
+	next := func(scanner *bufio.Scanner) types.OrderUpdate {
+		t.Helper()
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+
+			var update types.OrderUpdate
+			require.NoError(t, json.Unmarshal([]byte(data), &update))
+
+			return update
+		}
+		require.Fail(t, "stream closed")
+
+		return types.OrderUpdate{}
+	}
+
+	byOrder, cancelByOrder := subscribe("orderId=" + common.Hash(order.ID).Hex())
+	defer cancelByOrder()
+	byUser, cancelByUser := subscribe("user=" + user.Hex())
+	defer cancelByUser()
+
+	update := next(byOrder)
+	require.Equal(t, common.Hash(order.ID), update.OrderID)
+	require.Equal(t, historyEvent, update.Action)
+	require.Equal(t, "0xopen", update.EventTx)
+
+	require.Eventually(t, func() bool {
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+
+		return len(stream.subs) == 2
+	}, time.Second, time.Millisecond*10)
+
+	// Updates of other orders are filtered
+	record(ctx, other, historyEntry{Action: historyFill})
+	record(ctx, order, historyEntry{Action: historyReject, Reason: types.RejectInsufficientInventory})
+	record(ctx, order, historyEntry{Action: historyFillTx, Tx: "0xfill"})
+
+	for _, scanner := range []*bufio.Scanner{byOrder, byUser} {
+		update := next(scanner)
+		require.Equal(t, common.Hash(order.ID), update.OrderID)
+		require.Equal(t, user, update.User)
+		require.Equal(t, solvernet.StatusPending.String(), update.Status)
+		require.Equal(t, types.RejectInsufficientInventory, update.RejectCode)
+
+		update = next(scanner)
+		require.Equal(t, historyFillTx, update.Action)
+		require.Equal(t, "0xfill", update.Tx)
+	}
+}
+
+func TestOrderStreamDropsSlowSubscribers(t *testing.T) {
+	t.Parallel()
+
+	stream := newOrderStream()
+	updates, unsubscribe := stream.Subscribe(orderFilter{})
+	defer unsubscribe()
+
+	for range streamBuffer + 1 {
+		stream.Publish(types.OrderUpdate{})
+	}
+
+	for range streamBuffer {
+		_, ok := <-updates
+		require.True(t, ok)
+	}
+
+	_, ok := <-updates
+	require.False(t, ok)
+	require.Empty(t, stream.subs)
+}
+
+func TestParseOrderFilter(t *testing.T) {
+	t.Parallel()
+
+	parse := func(query string) (orderFilter, error) {
+		return parseOrderFilter(httptest.NewRequest(http.MethodGet, "/?"+query, nil))
+	}
+
+	_, err := parse("")
+	require.Error(t, err)
+	_, err = parse("user=invalid")
+	require.Error(t, err)
+	_, err = parse("orderId=0x01")
+	require.Error(t, err)
+
+	filter, err := parse("user=0x0000000000000000000000000000000000001111")
+	require.NoError(t, err)
+	require.Nil(t, filter.OrderID)
+	require.Equal(t, common.HexToAddress("0x1111"), *filter.User)
+}
@@ -180,8 +180,9 @@ func adaptSVMReject(cl *rpc.Client, solver solana.PrivateKey) func(ctx context.C
 }
 
 // adaptSVMClaim adapts the claimSVMOrder function to the procDeps interface.
-func adaptSVMClaim(cl *rpc.Client, solver solana.PrivateKey) func(ctx context.Context, order Order) error {
-	return func(ctx context.Context, order Order) error {
-		return claimSVMOrder(ctx, cl, solver, order.ID)
+// SVM claims are never deferred.
+func adaptSVMClaim(cl *rpc.Client, solver solana.PrivateKey) func(ctx context.Context, order Order) (bool, error) {
+	return func(ctx context.Context, order Order) (bool, error) {
+		return false, claimSVMOrder(ctx, cl, solver, order.ID)
 	}
 }
@@ -70,6 +70,7 @@ func svmGetOrder(ctx context.Context, cl *rpc.Client, outboxAddr common.Address,
 		Status:        status,
 		Offset:        0,                // N/A
 		UpdatedBy:     common.Address{}, // N/A
+		User:          common.Address{}, // N/A
 		pendingData: PendingData{
 			MinReceived:        minReceived,
 			DestinationSettler: outboxAddr,
@@ -547,8 +547,8 @@ func orderTestCases(t *testing.T, solver common.Address) []orderTestCase {
 			},
 		},
 		{
-			name:   "invalid expense (token and native)",
-			reason: types.RejectInvalidExpense,
+			name:   "invalid deposit (unpriced multiple expenses)",
+			reason: types.RejectInvalidDeposit,
 			reject: true,
 			order: testOrder{
 				srcChainID: evmchain.IDOmniOmega,
@@ -998,7 +998,7 @@ func depositFor(t *testing.T, expense *big.Int) *big.Int {
 	t.Helper()
 
 	// feePrice is unary price function that is 1-to-1 WITH fees.
-	feePrice, err := wrapPriceHandlerFunc(unaryPrice)(t.Context(),
+	feePrice, err := wrapPriceHandlerFunc(unaryPrice, testFeeFunc())(t.Context(),
 		types.PriceRequest{
 			SourceChainID:      evmchain.IDMockL2,
 			DestinationChainID: evmchain.IDMockL1,
@@ -1013,7 +1013,7 @@ func expenseFor(t *testing.T, deposit *big.Int) *big.Int {
 	t.Helper()
 
 	// feePrice is unary price function that is 1-to-1 with fees.
-	feePrice, err := wrapPriceHandlerFunc(unaryPrice)(t.Context(),
+	feePrice, err := wrapPriceHandlerFunc(unaryPrice, testFeeFunc())(t.Context(),
 		types.PriceRequest{
 			SourceChainID:      evmchain.IDMockL2,
 			DestinationChainID: evmchain.IDMockL1,
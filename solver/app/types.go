@@ -37,6 +37,7 @@ type Order struct {
 	SourceChainID uint64
 	Status        solvernet.OrderStatus
 	UpdatedBy     common.Address
+	User          common.Address // Order creator, zero for SVM orders
 
 	pendingData PendingData
 	filledData  FilledData
@@ -94,6 +95,7 @@ func newOrder(resolved OrderResolved, state OrderState, offset *big.Int) (Order,
 		Offset:        offset.Uint64(),
 		Status:        solvernet.OrderStatus(state.Status),
 		UpdatedBy:     state.UpdatedBy,
+		User:          resolved.User,
 		SourceChainID: resolved.OriginChainId.Uint64(),
 		filledData: FilledData{
 			MinReceived: resolved.MinReceived,
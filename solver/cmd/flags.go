@@ -16,6 +16,22 @@ func bindRunFlags(flags *pflag.FlagSet, cfg *solver.Config) {
 	flags.StringVar(&cfg.SolverPrivKey, "private-key", cfg.SolverPrivKey, "The path to the solver private key e.g path/private.key")
 	flags.StringVar(&cfg.MonitoringAddr, "monitoring-addr", cfg.MonitoringAddr, "The address to bind the monitoring server")
 	flags.StringVar(&cfg.APIAddr, "api-addr", cfg.APIAddr, "The address to bind the API server")
+	flags.StringVar(&cfg.AdminAPIToken, "admin-api-token", cfg.AdminAPIToken, "The bearer token authenticating the admin API (pause/resume), which is disabled if empty")
+	flags.StringVar(&cfg.RateLimitsFile, "rate-limits-file", cfg.RateLimitsFile, "The path to the optional TOML API rate limits file, defining token bucket limits per endpoint per client IP and API key")
 	flags.StringVar(&cfg.DBDir, "db-dir", cfg.DBDir, "The path to the database directory")
+	flags.StringVar(&cfg.FeesFile, "fees-file", cfg.FeesFile, "The path to the optional TOML fee schedule file (reloaded on SIGHUP)")
+	flags.StringVar(&cfg.ClaimsFile, "claims-file", cfg.ClaimsFile, "The path to the optional TOML claim policy file, deferring claims per origin chain")
+	flags.StringVar(&cfg.FundThresholdsFile, "fund-thresholds-file", cfg.FundThresholdsFile, "The path to the optional TOML or JSON fund thresholds file overriding compiled defaults (reloaded on SIGHUP)")
+	flags.BoolVar(&cfg.RebalancePlanner, "rebalance-planner", cfg.RebalancePlanner, "Enable the global rebalance planner, replacing per-chain rebalancing loops")
+	flags.BoolVar(&cfg.RebalanceDryRun, "rebalance-dry-run", cfg.RebalanceDryRun, "Run the global rebalance planner in dry-run mode, only logging and exporting plans without executing them")
+	flags.Uint64Var(&cfg.RebalanceMaxSlippageBips, "rebalance-max-slippage-bips", cfg.RebalanceMaxSlippageBips, "The max slippage in bips of rebalance Uniswap swap outputs vs their pre-swap quotes")
+	flags.Uint64Var(&cfg.RebalanceMaxPriceDeviationBips, "rebalance-max-price-deviation-bips", cfg.RebalanceMaxPriceDeviationBips, "The max deviation in bips of rebalance Uniswap swap quotes vs reference token prices, above which swaps are aborted")
+	flags.StringVar(&cfg.RebalancePlanFile, "rebalance-plan-file", cfg.RebalancePlanFile, "The path to export the latest global rebalance plan to as JSON")
+	flags.Float64Var(&cfg.RelayMaxUserDailyUSD, "relay-max-user-daily-usd", cfg.RelayMaxUserDailyUSD, "The max tx fees in USD sponsored per user per UTC day when relaying gasless orders, zero disables the limit")
+	flags.StringVar(&cfg.SimulationDir, "simulation-dir", cfg.SimulationDir, "The path to the directory of local anvil forks used to simulate check fills, simulation is disabled if empty")
+	flags.DurationVar(&cfg.FirmQuoteTTL, "firm-quote-ttl", cfg.FirmQuoteTTL, "The duration that firm quotes are honoured for")
 	flags.StringVar(&cfg.CoinGeckoAPIKey, "coingecko-apikey", cfg.CoinGeckoAPIKey, "The CoinGecko API key to use for fetching token prices")
+	flags.DurationVar(&cfg.PriceMaxAge, "price-max-age", cfg.PriceMaxAge, "The maximum age of source token prices, older prices are ignored")
+	flags.Float64Var(&cfg.PriceMaxDeviation, "price-max-deviation", cfg.PriceMaxDeviation, "The maximum relative deviation of a source token price from the median, e.g. 0.05 for 5%; outliers are dropped")
+	flags.StringToStringVar(&cfg.StaticPrices, "static-prices", cfg.StaticPrices, "Static USD token prices by asset symbol, used only if no pricing source has a fresh price. e.g. \"USDC=1,USDT=1\"")
 }
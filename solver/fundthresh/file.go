@@ -0,0 +1,118 @@
+package fundthresh
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/tokens"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Entry defines the fund thresholds of a single token in a thresholds file.
+// Amounts are in whole token units, e.g. 1.5 for 1.5 ETH.
+type Entry struct {
+	Chain        string  `json:"chain"         toml:"chain"` // Chain name, e.g. "ethereum"
+	Token        string  `json:"token"         toml:"token"` // Token symbol, e.g. "USDC"
+	Min          float64 `json:"min"           toml:"min"`
+	Target       float64 `json:"target"        toml:"target"`
+	Surplus      float64 `json:"surplus"       toml:"surplus"`       // Zero defaults to target
+	NeverSurplus bool    `json:"never-surplus" toml:"never-surplus"` // Never consider the token in surplus
+	MinSwap      float64 `json:"min-swap"      toml:"min-swap"`
+	MaxSwap      float64 `json:"max-swap"      toml:"max-swap"`
+}
+
+// File defines fund thresholds overriding the compiled defaults.
+// Tokens not included in the file use the compiled defaults.
+type File struct {
+	Thresholds []Entry `json:"thresholds" toml:"thresholds"`
+}
+
+// Load loads and validates a TOML or JSON (by .json extension) thresholds file.
+func Load(path string) (map[tokens.Token]FundThreshold, error) {
+	var file File
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		bz, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "read thresholds", "path", path)
+		}
+
+		if err := json.Unmarshal(bz, &file); err != nil {
+			return nil, errors.Wrap(err, "decode thresholds", "path", path)
+		}
+	} else if _, err := toml.DecodeFile(path, &file); err != nil {
+		return nil, errors.Wrap(err, "decode thresholds", "path", path)
+	}
+
+	resp, err := file.parse()
+	if err != nil {
+		return nil, errors.Wrap(err, "parse thresholds", "path", path)
+	}
+
+	return resp, nil
+}
+
+// parse returns the validated thresholds by token.
+func (f File) parse() (map[tokens.Token]FundThreshold, error) {
+	resp := make(map[tokens.Token]FundThreshold)
+	for _, e := range f.Thresholds {
+		meta, ok := evmchain.MetadataByName(e.Chain)
+		if !ok {
+			return nil, errors.New("unknown chain", "chain", e.Chain)
+		}
+
+		token, ok := tokens.BySymbol(meta.ChainID, e.Token)
+		if !ok {
+			return nil, errors.New("unknown token", "chain", e.Chain, "token", e.Token)
+		}
+
+		if _, ok := resp[token]; ok {
+			return nil, errors.New("duplicate token", "chain", e.Chain, "token", e.Token)
+		}
+
+		t := FundThreshold{
+			token:   token,
+			min:     e.Min,
+			target:  e.Target,
+			surplus: e.Surplus,
+			minSwap: e.MinSwap,
+			maxSwap: e.MaxSwap,
+		}
+		if e.NeverSurplus {
+			t.surplus = inf
+		}
+
+		if err := normalize(token, t).Validate(); err != nil {
+			return nil, err
+		}
+
+		resp[token] = t
+	}
+
+	return resp, nil
+}
+
+// Set replaces the active thresholds overriding the compiled defaults.
+// Nil resets all tokens to the compiled defaults.
+func Set(thresholds map[tokens.Token]FundThreshold) {
+	mu.Lock()
+	overrides = thresholds
+	mu.Unlock()
+}
+
+// Reload loads the thresholds file and sets the active thresholds.
+// The active thresholds are retained if the file is invalid.
+func Reload(path string) error {
+	thresholds, err := Load(path)
+	if err != nil {
+		return err
+	}
+
+	Set(thresholds)
+
+	return nil
+}
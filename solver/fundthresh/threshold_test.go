@@ -2,13 +2,18 @@ package fundthresh_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/omni-network/omni/lib/bi"
 	"github.com/omni-network/omni/lib/evmchain"
 	"github.com/omni-network/omni/lib/tokens"
 	"github.com/omni-network/omni/lib/tutil"
 	solver "github.com/omni-network/omni/solver/app"
 	"github.com/omni-network/omni/solver/fundthresh"
+
+	"github.com/stretchr/testify/require"
 )
 
 //go:generate go test . -golden -clean
@@ -49,3 +54,111 @@ func TestThresholdReference(t *testing.T) {
 
 	tutil.RequireGoldenJSON(t, golden, tutil.WithFilename("thresholds.json"))
 }
+
+func TestDefaultsValid(t *testing.T) {
+	t.Parallel()
+
+	for _, token := range tokens.All() {
+		require.NoError(t, fundthresh.Get(token).Validate(), token)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		return path
+	}
+
+	eth := mustToken(t, evmchain.IDEthereum, tokens.ETH)
+	usdt0 := mustToken(t, evmchain.IDHyperEVM, tokens.USDT0)
+
+	thresholds, err := fundthresh.Load(write("thresholds.toml", `
+[[thresholds]]
+chain = "ethereum"
+token = "ETH"
+min = 10
+target = 20
+min-swap = 0.5
+max-swap = 2
+
+[[thresholds]]
+chain = "hyper_evm"
+token = "USDT0"
+target = 100
+never-surplus = true
+`))
+	require.NoError(t, err)
+	require.Len(t, thresholds, 2)
+
+	fromJSON, err := fundthresh.Load(write("thresholds.json", `{"thresholds": [
+		{"chain": "ethereum", "token": "ETH", "min": 10, "target": 20, "min-swap": 0.5, "max-swap": 2},
+		{"chain": "hyper_evm", "token": "USDT0", "target": 100, "never-surplus": true}
+	]}`))
+	require.NoError(t, err)
+	require.Equal(t, thresholds, fromJSON)
+
+	require.Contains(t, thresholds, eth)
+	require.Contains(t, thresholds, usdt0)
+
+	invalid := map[string]string{
+		"min_gt_target":     `[[thresholds]]` + "\n" + `chain = "ethereum"` + "\n" + `token = "ETH"` + "\n" + `min = 30` + "\n" + `target = 20`,
+		"target_gt_surplus": `[[thresholds]]` + "\n" + `chain = "ethereum"` + "\n" + `token = "ETH"` + "\n" + `target = 20` + "\n" + `surplus = 10`,
+		"min_swap_gt_max":   `[[thresholds]]` + "\n" + `chain = "ethereum"` + "\n" + `token = "ETH"` + "\n" + `min-swap = 2` + "\n" + `max-swap = 1`,
+		"negative":          `[[thresholds]]` + "\n" + `chain = "ethereum"` + "\n" + `token = "ETH"` + "\n" + `min = -1`,
+		"unknown_chain":     `[[thresholds]]` + "\n" + `chain = "unknown"` + "\n" + `token = "ETH"`,
+		"unknown_token":     `[[thresholds]]` + "\n" + `chain = "ethereum"` + "\n" + `token = "UNKNOWN"`,
+		"duplicate":         `[[thresholds]]` + "\n" + `chain = "ethereum"` + "\n" + `token = "ETH"` + "\n" + `[[thresholds]]` + "\n" + `chain = "ethereum"` + "\n" + `token = "ETH"`,
+	}
+	for name, content := range invalid {
+		_, err := fundthresh.Load(write(name+".toml", content))
+		require.Error(t, err, name)
+	}
+}
+
+//nolint:paralleltest // Sets global thresholds.
+func TestSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "thresholds.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[thresholds]]
+chain = "ethereum"
+token = "ETH"
+target = 20
+`), 0o644))
+
+	eth := mustToken(t, evmchain.IDEthereum, tokens.ETH)
+	usdc := mustToken(t, evmchain.IDEthereum, tokens.USDC)
+	defaultUSDC := fundthresh.Get(usdc)
+
+	require.NoError(t, fundthresh.Reload(path))
+	t.Cleanup(func() { fundthresh.Set(nil) })
+
+	// Overridden tokens use file thresholds, surplus defaults to target
+	require.Equal(t, bi.Ether(20), fundthresh.Get(eth).Target())
+	require.Equal(t, bi.Ether(20), fundthresh.Get(eth).Surplus())
+	require.True(t, bi.IsZero(fundthresh.Get(eth).Min()))
+
+	// Other tokens use compiled defaults
+	require.Equal(t, defaultUSDC, fundthresh.Get(usdc))
+
+	// Invalid files retain active thresholds
+	require.NoError(t, os.WriteFile(path, []byte(`invalid`), 0o644))
+	require.Error(t, fundthresh.Reload(path))
+	require.Equal(t, bi.Ether(20), fundthresh.Get(eth).Target())
+
+	fundthresh.Set(nil)
+	require.Equal(t, bi.Ether(50), fundthresh.Get(eth).Target())
+}
+
+func mustToken(t *testing.T, chainID uint64, asset tokens.Asset) tokens.Token {
+	t.Helper()
+
+	token, ok := tokens.ByAsset(chainID, asset)
+	require.True(t, ok)
+
+	return token
+}
@@ -3,8 +3,10 @@ package fundthresh
 import (
 	"math"
 	"math/big"
+	"sync"
 
 	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/evmchain"
 	"github.com/omni-network/omni/lib/tokens"
 	"github.com/omni-network/omni/lib/umath"
@@ -12,6 +14,11 @@ import (
 
 var inf = math.Inf(1)
 
+var (
+	mu        sync.RWMutex
+	overrides map[tokens.Token]FundThreshold // Thresholds loaded from file, if any.
+)
+
 type FundThreshold struct {
 	token   tokens.Token
 	min     float64 // alert if below
@@ -73,9 +80,10 @@ func (t FundThreshold) balance(f float64) *big.Int {
 	return bi.Ether(f)
 }
 
-// Get returns the fund thesholds for `token`.
+// Get returns the active fund thesholds for `token`.
+// Thresholds loaded from file override the compiled defaults.
 func Get(token tokens.Token) FundThreshold {
-	t, ok := thresholds[token]
+	t, ok := lookup(token)
 	if !ok {
 		// If threshold not explicitly set, return 0 target w/ inf surplus.
 		// So that the token is never considered in deficit / surplus.
@@ -85,6 +93,34 @@ func Get(token tokens.Token) FundThreshold {
 		}
 	}
 
+	return normalize(token, t)
+}
+
+// Validate returns an error if the thresholds are inconsistent.
+func (t FundThreshold) Validate() error {
+	for _, f := range []float64{t.min, t.target, t.surplus, t.minSwap, t.maxSwap} {
+		if f < 0 || math.IsNaN(f) {
+			return errors.New("negative threshold", "token", t.token)
+		}
+	}
+
+	if t.min > t.target {
+		return errors.New("min exceeds target", "token", t.token, "min", t.min, "target", t.target)
+	}
+
+	if t.target > t.surplus {
+		return errors.New("target exceeds surplus", "token", t.token, "target", t.target, "surplus", t.surplus)
+	}
+
+	if t.minSwap > t.maxSwap {
+		return errors.New("min swap exceeds max swap", "token", t.token, "min_swap", t.minSwap, "max_swap", t.maxSwap)
+	}
+
+	return nil
+}
+
+// normalize returns the thresholds for `token` with defaults applied.
+func normalize(token tokens.Token, t FundThreshold) FundThreshold {
 	surplus := t.surplus
 	if surplus == 0 && t.target > 0 {
 		// Surplus threshold should always be >= target.
@@ -101,8 +137,23 @@ func Get(token tokens.Token) FundThreshold {
 	}
 }
 
+// lookup returns the overridden or default thresholds of `token`, if any.
+func lookup(token tokens.Token) (FundThreshold, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if t, ok := overrides[token]; ok {
+		return t, true
+	}
+
+	t, ok := defaults[token]
+
+	return t, ok
+}
+
 var (
-	thresholds = map[tokens.Token]FundThreshold{
+	// defaults are the compiled-in fund thresholds, used for tokens not overridden by file.
+	defaults = map[tokens.Token]FundThreshold{
 		// ETH
 		mustToken(evmchain.IDEthereum, tokens.ETH): {
 			min:     20,
@@ -0,0 +1,364 @@
+package rebalance
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/cctp"
+	cctpdb "github.com/omni-network/omni/lib/cctp/db"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient/ethbackend"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/mantle"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/lib/usdt0"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// defaultRouteDeadline is the default maximum latency of bridge routes.
+const defaultRouteDeadline = time.Hour
+
+// Gas estimates and latencies used to estimate bridge routes.
+const (
+	cctpSendGas = 150_000          // CCTP depositForBurn, including approval.
+	cctpMintGas = 200_000          // CCTP receiveMessage on the destination chain.
+	cctpLatency = 20 * time.Minute // CCTP standard transfers wait for source chain finality.
+
+	usdt0SendGas    = 250_000 // OFT send, including approval.
+	usdt0ReceiveGas = 100_000 // LayerZero executor delivery, paid in source native fee.
+	usdt0Latency    = 5 * time.Minute
+	usdt0MaxSend    = 10_000 // 10k USDT
+
+	mantleDepositGas = 200_000 // Mantle L1 bridge depositERC20, including approval.
+	mantleLatency    = 15 * time.Minute
+)
+
+// Route bridges a token from a source chain to a token on a destination chain.
+// New bridges are added by implementing Route and registering its routes in newRoutes.
+type Route interface {
+	// Name returns the name of the bridge.
+	Name() string
+	// Src returns the token sent on the source chain.
+	Src() tokens.Token
+	// Dest returns the token received on the destination chain.
+	Dest() tokens.Token
+	// Estimate returns the estimated fee, latency and max amount of bridging `amount` given USD gas costs by chain.
+	Estimate(gasUSD map[uint64]float64, amount *big.Int) RouteEstimate
+	// Send bridges `amount` of the source token to the destination chain.
+	Send(ctx context.Context, amount *big.Int) error
+}
+
+// RouteEstimate is the estimated fee, latency and max amount of a route.
+type RouteEstimate struct {
+	FeeUSD    float64
+	Latency   time.Duration
+	MaxAmount *big.Int // Max amount in a single send, nil if unlimited
+}
+
+// gasUSDFunc returns the USD cost of a single unit of gas on the chain.
+type gasUSDFunc func(ctx context.Context, chainID uint64) (float64, error)
+
+// newGasUSD returns a gasUSDFunc using backend gas prices and native token prices.
+func newGasUSD(backends ethbackend.Backends, pricer tokenpricer.Pricer) gasUSDFunc {
+	return func(ctx context.Context, chainID uint64) (float64, error) {
+		backend, err := backends.Backend(chainID)
+		if err != nil {
+			return 0, errors.Wrap(err, "get backend")
+		}
+
+		gasPrice, err := backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return 0, errors.Wrap(err, "suggest gas price")
+		}
+
+		native, ok := tokens.Native(chainID)
+		if !ok {
+			return 0, errors.New("no native token", "chain", evmchain.Name(chainID))
+		}
+
+		price, err := pricer.USDPrice(ctx, native.Asset)
+		if err != nil {
+			return 0, errors.Wrap(err, "get native price")
+		}
+
+		return bi.ToF64(gasPrice, native.Decimals) * price, nil
+	}
+}
+
+// rankedRoute is a route with its estimate.
+type rankedRoute struct {
+	Route    Route
+	Estimate RouteEstimate
+}
+
+// rankRoutes returns the routes to dest within the deadline, cheapest first.
+func rankRoutes(
+	routes []Route,
+	gasUSD map[uint64]float64,
+	dest tokens.Token,
+	amount *big.Int,
+	deadline time.Duration,
+) []rankedRoute {
+	var resp []rankedRoute
+	for _, route := range routes {
+		if route.Dest() != dest {
+			continue
+		}
+
+		estimate := route.Estimate(gasUSD, amount)
+		if estimate.Latency > deadline {
+			continue
+		}
+
+		resp = append(resp, rankedRoute{Route: route, Estimate: estimate})
+	}
+
+	sort.SliceStable(resp, func(i, j int) bool {
+		return resp[i].Estimate.FeeUSD < resp[j].Estimate.FeeUSD
+	})
+
+	return resp
+}
+
+// selectRoute returns the cheapest route bridging `amount` of src to dest within the deadline.
+// Route estimates are returned with the route, since amounts above MaxAmount must be capped.
+func selectRoute(
+	routes []Route,
+	gasUSD map[uint64]float64,
+	src tokens.Token,
+	dest tokens.Token,
+	amount *big.Int,
+	deadline time.Duration,
+) (Route, RouteEstimate, bool) {
+	for _, ranked := range rankRoutes(routes, gasUSD, dest, amount, deadline) {
+		if ranked.Route.Src() == src {
+			return ranked.Route, ranked.Estimate, true
+		}
+	}
+
+	return nil, RouteEstimate{}, false
+}
+
+// router selects bridge routes, pricing gas on demand.
+type router struct {
+	routes   []Route
+	gasUSD   gasUSDFunc
+	deadline time.Duration
+}
+
+// Select returns the cheapest route bridging `amount` of src to dest within the router deadline.
+func (r router) Select(ctx context.Context, src, dest tokens.Token, amount *big.Int) (Route, RouteEstimate, error) {
+	gasUSD := make(map[uint64]float64)
+	for _, chainID := range []uint64{src.ChainID, dest.ChainID} {
+		usd, err := r.gasUSD(ctx, chainID)
+		if err != nil {
+			return nil, RouteEstimate{}, errors.Wrap(err, "gas usd", "chain", evmchain.Name(chainID))
+		}
+		gasUSD[chainID] = usd
+	}
+
+	route, estimate, ok := selectRoute(r.routes, gasUSD, src, dest, amount, r.deadline)
+	if !ok {
+		return nil, RouteEstimate{}, errors.New("no route", "src", src, "dest", dest, "deadline", r.deadline)
+	}
+
+	return route, estimate, nil
+}
+
+// capAmount caps `amount` to the estimated route max amount, if any.
+func capAmount(amount *big.Int, estimate RouteEstimate) *big.Int {
+	if estimate.MaxAmount != nil && bi.GT(amount, estimate.MaxAmount) {
+		return estimate.MaxAmount
+	}
+
+	return amount
+}
+
+// newRoutes returns all bridge routes between chains in the network.
+func newRoutes(
+	network netconf.Network,
+	backends ethbackend.Backends,
+	solver common.Address,
+	cctpDB *cctpdb.DB,
+	usdt0DB *usdt0.DB,
+) []Route {
+	var routes []Route
+
+	// CCTP USDC routes between all CCTP chains.
+	for _, src := range network.EVMChains() {
+		for _, dest := range network.EVMChains() {
+			if src.ID == dest.ID || !cctp.IsSupportedChain(src.ID) || !cctp.IsSupportedChain(dest.ID) {
+				continue
+			}
+
+			srcUSDC, ok1 := tokens.ByAsset(src.ID, tokens.USDC)
+			destUSDC, ok2 := tokens.ByAsset(dest.ID, tokens.USDC)
+			if !ok1 || !ok2 {
+				continue
+			}
+
+			routes = append(routes, cctpRoute{
+				db:        cctpDB,
+				networkID: network.ID,
+				backends:  backends,
+				solver:    solver,
+				src:       srcUSDC,
+				dest:      destUSDC,
+			})
+		}
+	}
+
+	hasChains := func(chainIDs ...uint64) bool {
+		for _, chainID := range chainIDs {
+			if _, ok := network.Chain(chainID); !ok {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// USDT0 routes between all USDT0 chains, competing with CCTP routes between chains supporting both.
+	for _, src := range network.EVMChains() {
+		for _, dest := range network.EVMChains() {
+			srcUSDT, ok1 := usdt0.TokenByChain(src.ID)
+			destUSDT, ok2 := usdt0.TokenByChain(dest.ID)
+			if src.ID == dest.ID || !ok1 || !ok2 {
+				continue
+			}
+
+			routes = append(routes, usdt0Route{
+				db:       usdt0DB,
+				backends: backends,
+				solver:   solver,
+				src:      srcUSDT,
+				dest:     destUSDT,
+			})
+		}
+	}
+
+	// Mantle native bridge route from Ethereum USDC to Mantle USDC.
+	if hasChains(evmchain.IDEthereum, evmchain.IDMantle) {
+		routes = append(routes, mantleRoute{
+			backends: backends,
+			solver:   solver,
+			src:      ethereumUSDC,
+			dest:     mantleUSDC,
+		})
+	}
+
+	return routes
+}
+
+// cctpRoute bridges USDC via CCTP.
+type cctpRoute struct {
+	db        *cctpdb.DB
+	networkID netconf.ID
+	backends  ethbackend.Backends
+	solver    common.Address
+	src       tokens.Token
+	dest      tokens.Token
+}
+
+func (r cctpRoute) Name() string       { return "cctp" }
+func (r cctpRoute) Src() tokens.Token  { return r.src }
+func (r cctpRoute) Dest() tokens.Token { return r.dest }
+
+func (r cctpRoute) Estimate(gasUSD map[uint64]float64, _ *big.Int) RouteEstimate {
+	return RouteEstimate{
+		FeeUSD:    cctpSendGas*gasUSD[r.src.ChainID] + cctpMintGas*gasUSD[r.dest.ChainID],
+		Latency:   cctpLatency,
+		MaxAmount: maxSend,
+	}
+}
+
+func (r cctpRoute) Send(ctx context.Context, amount *big.Int) error {
+	backend, err := r.backends.Backend(r.src.ChainID)
+	if err != nil {
+		return errors.Wrap(err, "get backend")
+	}
+
+	if _, err := cctp.SendUSDC(ctx, r.db, r.networkID, backend, cctp.SendUSDCArgs{
+		Sender:      r.solver,
+		Recipient:   r.solver,
+		SrcChainID:  r.src.ChainID,
+		DestChainID: r.dest.ChainID,
+		Amount:      amount,
+	}); err != nil {
+		return errors.Wrap(err, "send usdc")
+	}
+
+	return nil
+}
+
+// usdt0Route bridges USDT via the USDT0 LayerZero OFT.
+type usdt0Route struct {
+	db       *usdt0.DB
+	backends ethbackend.Backends
+	solver   common.Address
+	src      tokens.Token
+	dest     tokens.Token
+}
+
+func (r usdt0Route) Name() string       { return "usdt0" }
+func (r usdt0Route) Src() tokens.Token  { return r.src }
+func (r usdt0Route) Dest() tokens.Token { return r.dest }
+
+func (r usdt0Route) Estimate(gasUSD map[uint64]float64, _ *big.Int) RouteEstimate {
+	return RouteEstimate{
+		FeeUSD:    usdt0SendGas*gasUSD[r.src.ChainID] + usdt0ReceiveGas*gasUSD[r.dest.ChainID],
+		Latency:   usdt0Latency,
+		MaxAmount: bi.Dec6(usdt0MaxSend),
+	}
+}
+
+func (r usdt0Route) Send(ctx context.Context, amount *big.Int) error {
+	backend, err := r.backends.Backend(r.src.ChainID)
+	if err != nil {
+		return errors.Wrap(err, "get backend")
+	}
+
+	if _, err := usdt0.Send(ctx, backend, r.solver, r.src.ChainID, r.dest.ChainID, amount, r.db); err != nil {
+		return errors.Wrap(err, "send usdt0")
+	}
+
+	return nil
+}
+
+// mantleRoute bridges USDC from Ethereum to Mantle via the native bridge.
+type mantleRoute struct {
+	backends ethbackend.Backends
+	solver   common.Address
+	src      tokens.Token
+	dest     tokens.Token
+}
+
+func (r mantleRoute) Name() string       { return "mantle" }
+func (r mantleRoute) Src() tokens.Token  { return r.src }
+func (r mantleRoute) Dest() tokens.Token { return r.dest }
+
+func (r mantleRoute) Estimate(gasUSD map[uint64]float64, _ *big.Int) RouteEstimate {
+	return RouteEstimate{
+		FeeUSD:  mantleDepositGas * gasUSD[r.src.ChainID],
+		Latency: mantleLatency,
+	}
+}
+
+func (r mantleRoute) Send(ctx context.Context, amount *big.Int) error {
+	backend, err := r.backends.Backend(r.src.ChainID)
+	if err != nil {
+		return errors.Wrap(err, "get backend")
+	}
+
+	if _, err := mantle.DepositUSDC(ctx, backend, r.solver, amount); err != nil {
+		return errors.Wrap(err, "deposit usdc")
+	}
+
+	return nil
+}
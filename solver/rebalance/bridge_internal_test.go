@@ -0,0 +1,114 @@
+package rebalance
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/ethclient/ethbackend"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/tokens"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRoute struct {
+	name     string
+	src      tokens.Token
+	dest     tokens.Token
+	estimate RouteEstimate
+}
+
+func (r fakeRoute) Name() string                                        { return r.name }
+func (r fakeRoute) Src() tokens.Token                                   { return r.src }
+func (r fakeRoute) Dest() tokens.Token                                  { return r.dest }
+func (r fakeRoute) Estimate(map[uint64]float64, *big.Int) RouteEstimate { return r.estimate }
+func (fakeRoute) Send(context.Context, *big.Int) error                  { return nil }
+
+func TestSelectRoute(t *testing.T) {
+	t.Parallel()
+
+	src := mustToken(evmchain.IDBase, tokens.USDC)
+	dest := mustToken(evmchain.IDEthereum, tokens.USDC)
+	other := mustToken(evmchain.IDArbitrumOne, tokens.USDC)
+
+	routes := []Route{
+		fakeRoute{name: "slow", src: src, dest: dest, estimate: RouteEstimate{FeeUSD: 1, Latency: 2 * time.Hour}},
+		fakeRoute{name: "pricey", src: src, dest: dest, estimate: RouteEstimate{FeeUSD: 10, Latency: time.Minute}},
+		fakeRoute{name: "cheap", src: src, dest: dest, estimate: RouteEstimate{FeeUSD: 5, Latency: 30 * time.Minute, MaxAmount: bi.Dec6(100)}},
+		fakeRoute{name: "other", src: other, dest: dest, estimate: RouteEstimate{FeeUSD: 0, Latency: time.Minute}},
+	}
+
+	// Cheapest route meeting the deadline
+	route, estimate, ok := selectRoute(routes, nil, src, dest, bi.Dec6(1000), time.Hour)
+	require.True(t, ok)
+	require.Equal(t, "cheap", route.Name())
+	require.Equal(t, bi.Dec6(100), capAmount(bi.Dec6(1000), estimate))
+	require.Equal(t, bi.Dec6(50), capAmount(bi.Dec6(50), estimate))
+
+	// Tighter deadline
+	route, _, ok = selectRoute(routes, nil, src, dest, bi.Dec6(1000), 10*time.Minute)
+	require.True(t, ok)
+	require.Equal(t, "pricey", route.Name())
+
+	// Relaxed deadline
+	route, _, ok = selectRoute(routes, nil, src, dest, bi.Dec6(1000), 3*time.Hour)
+	require.True(t, ok)
+	require.Equal(t, "slow", route.Name())
+
+	// No route
+	_, _, ok = selectRoute(routes, nil, dest, src, bi.Dec6(1000), time.Hour)
+	require.False(t, ok)
+}
+
+func TestCompetingRoutes(t *testing.T) {
+	t.Parallel()
+
+	network := netconf.Network{ID: netconf.Mainnet}
+	for _, chainID := range []uint64{evmchain.IDEthereum, evmchain.IDOptimism, evmchain.IDArbitrumOne, evmchain.IDHyperEVM} {
+		network.Chains = append(network.Chains, netconf.Chain{ID: chainID, Name: evmchain.Name(chainID)})
+	}
+
+	routes := newRoutes(network, ethbackend.Backends{}, common.Address{}, nil, nil)
+
+	// Both CCTP and USDT0 bridge Ethereum to Arbitrum
+	var names []string
+	for _, route := range routes {
+		if route.Src().ChainID == evmchain.IDEthereum && route.Dest().ChainID == evmchain.IDArbitrumOne {
+			names = append(names, route.Name())
+		}
+	}
+	require.ElementsMatch(t, []string{"cctp", "usdt0"}, names)
+
+	hypUSDT0 := mustToken(evmchain.IDHyperEVM, tokens.USDT0)
+	arbUSDT0 := mustToken(evmchain.IDArbitrumOne, tokens.USDT0)
+
+	srcOf := func(gasUSD map[uint64]float64) uint64 {
+		ranked := rankRoutes(routes, gasUSD, hypUSDT0, bi.Dec6(1000), time.Hour)
+		require.Len(t, ranked, 3) // From Ethereum, Optimism and Arbitrum
+
+		return ranked[0].Route.Src().ChainID
+	}
+
+	// Cheapest source chain wins
+	require.Equal(t, evmchain.IDArbitrumOne, srcOf(map[uint64]float64{
+		evmchain.IDEthereum:    1e-6,
+		evmchain.IDOptimism:    1e-8,
+		evmchain.IDArbitrumOne: 1e-9,
+	}))
+	require.Equal(t, evmchain.IDEthereum, srcOf(map[uint64]float64{
+		evmchain.IDEthereum:    1e-10,
+		evmchain.IDOptimism:    1e-8,
+		evmchain.IDArbitrumOne: 1e-9,
+	}))
+
+	// Explicit source still selects its route
+	route, _, ok := selectRoute(routes, nil, arbUSDT0, hypUSDT0, bi.Dec6(1000), time.Hour)
+	require.True(t, ok)
+	require.Equal(t, "usdt0", route.Name())
+}
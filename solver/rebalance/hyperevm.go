@@ -13,7 +13,6 @@ import (
 	"github.com/omni-network/omni/lib/tokens"
 	"github.com/omni-network/omni/lib/tokens/tokenutil"
 	"github.com/omni-network/omni/lib/uniswap"
-	"github.com/omni-network/omni/lib/usdt0"
 	"github.com/omni-network/omni/solver/fundthresh"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -32,7 +31,8 @@ func rebalanceHyperEVMForever(
 	interval time.Duration,
 	backends ethbackend.Backends,
 	solver common.Address,
-	db *usdt0.DB,
+	r router,
+	swapOpts []uniswap.SwapOption,
 ) {
 	_, err := backends.Backend(evmchain.IDHyperEVM)
 	if err != nil {
@@ -45,7 +45,7 @@ func rebalanceHyperEVMForever(
 	for {
 		start := time.Now()
 
-		err := rebalanceHyperEVMOnce(ctx, backends, solver, db)
+		err := rebalanceHyperEVMOnce(ctx, backends, solver, r, swapOpts)
 		if err != nil {
 			log.Warn(ctx, "Failed to rebalance HyperVM (will retry)", err)
 		}
@@ -64,7 +64,8 @@ func rebalanceHyperEVMOnce(
 	ctx context.Context,
 	backends ethbackend.Backends,
 	solver common.Address,
-	db *usdt0.DB,
+	r router,
+	swapOpts []uniswap.SwapOption,
 ) error {
 	log.Debug(ctx, "Rebalancing HyperEVM USDT0; trying lock")
 	defer lock(evmchain.IDEthereum, evmchain.IDHyperEVM)()
@@ -101,7 +102,7 @@ func rebalanceHyperEVMOnce(
 
 	// If we have enough, send USDT right to HyperEVM
 	if bi.GTE(surplusUSDT, deficitUSDT0) {
-		return sendUSDTToHyperEVM(ctx, ethBackend, solver, deficitUSDT0, db)
+		return sendUSDTToHyperEVM(ctx, r, deficitUSDT0)
 	}
 
 	// If we don't, check if we have USDC surplus to swap
@@ -129,7 +130,7 @@ func rebalanceHyperEVMOnce(
 	}
 
 	// Swap to USDT
-	_, err = uniswap.SwapFromUSDC(ctx, ethBackend, solver, ethUSDT, toSwap)
+	_, err = uniswap.SwapFromUSDC(ctx, ethBackend, solver, ethUSDT, toSwap, swapOpts...)
 	if err != nil {
 		return errors.Wrap(err, "swap usdc to usdt")
 	}
@@ -145,39 +146,29 @@ func rebalanceHyperEVMOnce(
 		toSend = surplusUSDT
 	}
 
-	return sendUSDTToHyperEVM(ctx, ethBackend, solver, toSend, db)
+	return sendUSDTToHyperEVM(ctx, r, toSend)
 }
 
-// sendUSDTToHyperEVM sends USDT from Ethereum to HyperEVM USDT0.
+// sendUSDTToHyperEVM sends USDT from Ethereum to HyperEVM USDT0, via the cheapest bridge route.
 func sendUSDTToHyperEVM(
 	ctx context.Context,
-	ethBackend *ethbackend.Backend,
-	solver common.Address,
+	r router,
 	amount *big.Int,
-	db *usdt0.DB,
 ) error {
-	const maxSend = 10000 // 10k USDT
-	toSend := amount
-	if bi.GT(amount, bi.Dec6(maxSend)) {
-		toSend = bi.Dec6(maxSend)
+	route, estimate, err := r.Select(ctx, ethUSDT, hypUSDT0, amount)
+	if err != nil {
+		return errors.Wrap(err, "select route")
 	}
 
+	toSend := capAmount(amount, estimate)
+
 	const minSend = 100 // 100 USDT
 	if bi.LT(toSend, bi.Dec6(minSend)) {
 		return nil
 	}
 
-	_, err := usdt0.Send(
-		ctx,
-		ethBackend,
-		solver,
-		evmchain.IDEthereum,
-		evmchain.IDHyperEVM,
-		toSend,
-		db,
-	)
-	if err != nil {
-		return errors.Wrap(err, "deposit usdt0")
+	if err := route.Send(ctx, toSend); err != nil {
+		return errors.Wrap(err, "deposit usdt0", "route", route.Name())
 	}
 
 	return nil
@@ -15,6 +15,7 @@ import (
 	"github.com/omni-network/omni/lib/ethclient/ethbackend"
 	"github.com/omni-network/omni/lib/evmchain"
 	"github.com/omni-network/omni/lib/layerzero"
+	"github.com/omni-network/omni/lib/netconf"
 	"github.com/omni-network/omni/lib/tokens"
 	"github.com/omni-network/omni/lib/tutil"
 	"github.com/omni-network/omni/lib/usdt0"
@@ -74,22 +75,74 @@ func TestRebalanceHyperEVMOnce(t *testing.T) {
 	startBlock, err := clients[evmchain.IDEthereum].BlockNumber(ctx)
 	tutil.RequireNoError(t, err)
 
+	network := netconf.Network{ID: netconf.Mainnet, Chains: []netconf.Chain{
+		{ID: evmchain.IDEthereum, Name: evmchain.Name(evmchain.IDEthereum)},
+		{ID: evmchain.IDHyperEVM, Name: evmchain.Name(evmchain.IDHyperEVM)},
+	}}
+	r := router{
+		routes:   newRoutes(network, backends, solver, nil, nil),
+		gasUSD:   func(context.Context, uint64) (float64, error) { return 0, nil },
+		deadline: defaultRouteDeadline,
+	}
+
+	// Get initial HyperEVM USDT0 deficit, filled by the delivered send
+	hypBackend, err := backends.Backend(evmchain.IDHyperEVM)
+	tutil.RequireNoError(t, err)
+	deficit0, err := GetDeficit(ctx, hypBackend, hypUSDT0, solver)
+	tutil.RequireNoError(t, err)
+
 	// Run single rebalance
-	err = rebalanceHyperEVMOnce(ctx, backends, solver, nil)
+	err = rebalanceHyperEVMOnce(ctx, backends, solver, r, nil)
+	tutil.RequireNoError(t, err)
+
+	sent := waitOFTSent(t, ctx, clients[evmchain.IDEthereum], oft, startBlock, solver)
+	require.Equal(t, mustEID(t, evmchain.IDHyperEVM), sent.DstEid)
+	require.Equal(t, solver, sent.FromAddress)
+
+	// Verify amount send is at least 999 (unless to account for swap fees)
+	tutil.RequireGT(t, sent.AmountSentLD, bi.Dec6(999))
+	tutil.RequireGT(t, sent.AmountReceivedLD, bi.Dec6(999))
+
+	// Deliver the send to HyperEVM via the LayerZero stand-in
+	lzClient := layerzero.NewDevClient(clients)
+	msgs, err := lzClient.GetMessagesByTx(ctx, sent.Raw.TxHash.Hex())
 	tutil.RequireNoError(t, err)
+	require.Len(t, msgs, 1)
+	require.True(t, msgs[0].IsDelivered())
+
+	// Delivery is idempotent
+	msgs, err = lzClient.GetMessagesByTx(ctx, sent.Raw.TxHash.Hex())
+	tutil.RequireNoError(t, err)
+	require.True(t, msgs[0].IsDelivered())
+
+	// HyperEVM deficit is reduced by the amount received
+	deficit1, err := GetDeficit(ctx, hypBackend, hypUSDT0, solver)
+	tutil.RequireNoError(t, err)
+	require.Equal(t, bi.Sub(deficit0, sent.AmountReceivedLD).String(), deficit1.String())
+}
+
+// waitOFTSent waits for the first OFTSent event sent by the solver since the start block.
+func waitOFTSent(
+	t *testing.T,
+	ctx context.Context,
+	client ethclient.Client,
+	oft *usdt0.IOFT,
+	startBlock uint64,
+	solver common.Address,
+) *usdt0.IOFTOFTSent {
+	t.Helper()
 
 	timeout := time.After(30 * time.Second)
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	// Wait for OFTSent event to be emitted
 	for {
 		select {
 		case <-timeout:
 			t.Fatal("timeout waiting for OFTSent event")
 		case <-ticker.C:
 			// Query OFTSent logs from start block to latest
-			endBlock, err := clients[evmchain.IDEthereum].BlockNumber(ctx)
+			endBlock, err := client.BlockNumber(ctx)
 			tutil.RequireNoError(t, err)
 			logs, err := oft.FilterOFTSent(&bind.FilterOpts{
 				Start: startBlock,
@@ -98,16 +151,8 @@ func TestRebalanceHyperEVMOnce(t *testing.T) {
 			tutil.RequireNoError(t, err)
 
 			// Any event must match (we are only eoa transacting)
-			for logs.Next() {
-				event := logs.Event
-				require.Equal(t, mustEID(t, evmchain.IDHyperEVM), event.DstEid)
-				require.Equal(t, solver, event.FromAddress)
-
-				// Verify amount send is at least 999 (unless to account for swap fees)
-				tutil.RequireGT(t, event.AmountSentLD, bi.Dec6(999))
-				tutil.RequireGT(t, event.AmountReceivedLD, bi.Dec6(999))
-
-				return
+			if logs.Next() {
+				return logs.Event
 			}
 
 			tutil.RequireNoError(t, logs.Error())
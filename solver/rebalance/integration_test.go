@@ -392,11 +392,10 @@ func mustMeta(t *testing.T, chainID uint64) evmchain.Metadata {
 
 func newPricer(ctx context.Context) tokenpricer.Pricer {
 	apiKey := os.Getenv("COINGECKO_API_KEY")
-	pricer := tokenpricer.NewCached(coingecko.New(coingecko.WithAPIKey(apiKey)))
-
 	// use cached pricer avoid spamming coingecko public api
-	const priceCacheEvictInterval = time.Minute * 10
-	go pricer.ClearCacheForever(ctx, priceCacheEvictInterval)
+	const priceCacheTTL = time.Minute * 10
+	pricer := tokenpricer.NewCached(coingecko.New(coingecko.WithAPIKey(apiKey)), tokenpricer.WithTTL(priceCacheTTL))
+	go pricer.RefreshForever(ctx, priceCacheTTL/2)
 
 	return pricer
 }
@@ -9,7 +9,6 @@ import (
 	"github.com/omni-network/omni/lib/ethclient/ethbackend"
 	"github.com/omni-network/omni/lib/evmchain"
 	"github.com/omni-network/omni/lib/log"
-	"github.com/omni-network/omni/lib/mantle"
 	"github.com/omni-network/omni/lib/tokens"
 	"github.com/omni-network/omni/lib/tokens/tokenutil"
 	"github.com/omni-network/omni/solver/fundthresh"
@@ -29,6 +28,7 @@ func rebalanceMantleForever(
 	interval time.Duration,
 	backends ethbackend.Backends,
 	solver common.Address,
+	r router,
 ) {
 	_, err := backends.Backend(evmchain.IDMantle)
 	if err != nil {
@@ -42,7 +42,7 @@ func rebalanceMantleForever(
 		start := time.Now()
 		elapsed := time.Since(start)
 
-		err := rebalanceMantleOnce(ctx, backends, solver)
+		err := rebalanceMantleOnce(ctx, backends, solver, r)
 		if err != nil {
 			log.Warn(ctx, "Failed to rebalance mantle (will retry)", err)
 		}
@@ -61,6 +61,7 @@ func rebalanceMantleOnce(
 	ctx context.Context,
 	backends ethbackend.Backends,
 	solver common.Address,
+	r router,
 ) error {
 	log.Debug(ctx, "Rebalancing Mantle USDC; trying lock")
 	defer lock(evmchain.IDEthereum, evmchain.IDMantle)()
@@ -114,14 +115,13 @@ func rebalanceMantleOnce(
 		return errors.New("deficit > half of l1 balance")
 	}
 
-	_, err = mantle.DepositUSDC(
-		ctx,
-		l1Backend,
-		solver,
-		deficit,
-	)
+	route, estimate, err := r.Select(ctx, l1USDC, l2USDC, deficit)
 	if err != nil {
-		return errors.Wrap(err, "deposit usdc")
+		return errors.Wrap(err, "select route")
+	}
+
+	if err := route.Send(ctx, capAmount(deficit, estimate)); err != nil {
+		return errors.Wrap(err, "deposit usdc", "route", route.Name())
 	}
 
 	return nil
@@ -27,7 +27,7 @@ var (
 		Help:      "The current balance for a token on a chain",
 	}, []string{"chain", "token"})
 
-	// Threshold gauges will mostly be static, unless they are changed via upgrade.
+	// Threshold gauges will mostly be static, unless they are changed via upgrade or thresholds file reload.
 
 	thresholdTarget = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "solver",
@@ -49,4 +49,25 @@ var (
 		Name:      "threshold_min",
 		Help:      "The minimum threshold for a token on a chain",
 	}, []string{"chain", "token"})
+
+	planSteps = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "solver",
+		Subsystem: "rebalance",
+		Name:      "plan_steps",
+		Help:      "The number of steps in the latest rebalance plan",
+	})
+
+	planCost = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "solver",
+		Subsystem: "rebalance",
+		Name:      "plan_cost_usd",
+		Help:      "The estimated USD cost of the latest rebalance plan",
+	})
+
+	planUnmet = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "solver",
+		Subsystem: "rebalance",
+		Name:      "plan_unmet_usd",
+		Help:      "The USD deficit of a chain not resolved by the latest rebalance plan",
+	}, []string{"chain"})
 )
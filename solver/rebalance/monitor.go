@@ -65,8 +65,6 @@ func monitorChainOnce(
 	chainName := evmchain.Name(chainID)
 
 	for _, token := range tokens.ByChain(chainID) {
-		thresh := fundthresh.Get(token)
-
 		balance, err := tokenutil.BalanceOf(ctx, client, token, solver)
 		if err != nil {
 			return errors.Wrap(err, "get balance")
@@ -86,14 +84,34 @@ func monitorChainOnce(
 		balanceSurplus.WithLabelValues(chainName, token.Asset.String()).Set(bi.ToF64(surplus, token.Decimals))
 		balanceCurrent.WithLabelValues(chainName, token.Asset.String()).Set(bi.ToF64(balance, token.Decimals))
 
-		thresholdTarget.WithLabelValues(chainName, token.Asset.String()).Set(bi.ToF64(thresh.Target(), token.Decimals))
-		thresholdMin.WithLabelValues(chainName, token.Asset.String()).Set(bi.ToF64(thresh.Min(), token.Decimals))
+		setThresholdMetrics(token)
+	}
+
+	return nil
+}
 
-		// only gauge surplus if it is not infinite
-		if !thresh.NeverSurplus() {
-			thresholdSurplus.WithLabelValues(chainName, token.Asset.String()).Set(bi.ToF64(thresh.Surplus(), token.Decimals))
+// UpdateThresholdMetrics sets the threshold gauges of all network tokens to the active fund thresholds.
+// Call it after reloading fund thresholds, so the gauges don't lag until the next monitor tick.
+func UpdateThresholdMetrics(network netconf.Network) {
+	for _, chain := range network.EVMChains() {
+		for _, token := range tokens.ByChain(chain.ID) {
+			setThresholdMetrics(token)
 		}
 	}
+}
 
-	return nil
+// setThresholdMetrics sets the threshold gauges of the token to its active fund threshold.
+func setThresholdMetrics(token tokens.Token) {
+	thresh := fundthresh.Get(token)
+	chainName := evmchain.Name(token.ChainID)
+
+	thresholdTarget.WithLabelValues(chainName, token.Asset.String()).Set(bi.ToF64(thresh.Target(), token.Decimals))
+	thresholdMin.WithLabelValues(chainName, token.Asset.String()).Set(bi.ToF64(thresh.Min(), token.Decimals))
+
+	// only gauge surplus if it is not infinite, dropping a previously finite surplus
+	if thresh.NeverSurplus() {
+		thresholdSurplus.DeleteLabelValues(chainName, token.Asset.String())
+	} else {
+		thresholdSurplus.WithLabelValues(chainName, token.Asset.String()).Set(bi.ToF64(thresh.Surplus(), token.Decimals))
+	}
 }
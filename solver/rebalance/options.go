@@ -2,6 +2,10 @@ package rebalance
 
 import (
 	"time"
+
+	"github.com/omni-network/omni/lib/layerzero"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/uniswap"
 )
 
 type Options func(*options)
@@ -13,13 +17,97 @@ func WithInterval(interval time.Duration) Options {
 	}
 }
 
+// WithLayerZeroClient sets the LayerZero client used to monitor USDT0 sends.
+// Defaults to the LayerZero scan API of the network.
+func WithLayerZeroClient(client layerzero.Client) Options {
+	return func(o *options) {
+		o.lzClient = client
+	}
+}
+
+// WithPlanner replaces the per-chain CCTP, Mantle and HyperEVM rebalancing loops with the global rebalance planner.
+func WithPlanner() Options {
+	return func(o *options) {
+		o.planner = true
+	}
+}
+
+// WithDryRun runs the global rebalance planner without executing its plans.
+// Plans are only logged and exported, while per-chain rebalancing continues.
+func WithDryRun() Options {
+	return func(o *options) {
+		o.dryRun = true
+	}
+}
+
+// WithPlanFile sets the file the global rebalance planner exports its latest plan to (as JSON).
+func WithPlanFile(path string) Options {
+	return func(o *options) {
+		o.planFile = path
+	}
+}
+
+// WithRouteDeadline sets the maximum latency of bridge routes used for rebalancing.
+func WithRouteDeadline(deadline time.Duration) Options {
+	return func(o *options) {
+		o.routeDeadline = deadline
+	}
+}
+
+// WithMaxSlippage sets the max slippage in bips of realized vs quoted Uniswap swap outputs.
+func WithMaxSlippage(bips uint64) Options {
+	return func(o *options) {
+		o.maxSlippageBips = bips
+	}
+}
+
+// WithMaxPriceDeviation sets the max deviation in bips of quoted Uniswap swap outputs vs reference prices.
+// Swaps with quotes deviating further are aborted.
+func WithMaxPriceDeviation(bips uint64) Options {
+	return func(o *options) {
+		o.maxDeviationBips = bips
+	}
+}
+
 type options struct {
 	// interval at which to rebalance the solver'balance.
 	interval time.Duration
+
+	// lzClient monitors USDT0 sends.
+	lzClient layerzero.Client
+
+	// planner enables the global rebalance planner.
+	planner bool
+
+	// dryRun only logs and exports planner plans, without executing them.
+	dryRun bool
+
+	// planFile is the file the latest plan is exported to, if non-empty.
+	planFile string
+
+	// routeDeadline is the maximum latency of bridge routes.
+	routeDeadline time.Duration
+
+	// maxSlippageBips is the max slippage of realized vs quoted swap outputs.
+	maxSlippageBips uint64
+
+	// maxDeviationBips is the max deviation of quoted swap outputs vs reference prices.
+	maxDeviationBips uint64
 }
 
 func defaultOps() options {
 	return options{
-		interval: 30 * time.Minute,
+		interval:         30 * time.Minute,
+		routeDeadline:    defaultRouteDeadline,
+		maxSlippageBips:  uniswap.DefaultMaxSlippageBips,
+		maxDeviationBips: uniswap.DefaultMaxDeviationBips,
+	}
+}
+
+// swapOpts returns the Uniswap swap options, checking quotes against `pricer` reference prices.
+func (o options) swapOpts(pricer tokenpricer.Pricer) []uniswap.SwapOption {
+	return []uniswap.SwapOption{
+		uniswap.WithMaxSlippage(o.maxSlippageBips),
+		uniswap.WithPriceCheck(pricer, o.maxDeviationBips),
 	}
 }
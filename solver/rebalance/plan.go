@@ -0,0 +1,577 @@
+package rebalance
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/cctp"
+	cctpdb "github.com/omni-network/omni/lib/cctp/db"
+	"github.com/omni-network/omni/lib/errors"
+	"github.com/omni-network/omni/lib/ethclient/ethbackend"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/log"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/tokenpricer"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/lib/tokens/tokenutil"
+	"github.com/omni-network/omni/lib/uniswap"
+	"github.com/omni-network/omni/solver/fundthresh"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// swapGas is the gas estimate of a Uniswap V3 (multi-hop) swap, including approval.
+const swapGas = 250_000
+
+// swapFeeRates estimates pool fees of swaps to/from USDC, see lib/uniswap swap routes.
+var swapFeeRates = map[tokens.Asset]float64{
+	tokens.USDT:   0.0001, // USDT/USDC 0.01%
+	tokens.ETH:    0.0005, // WETH/USDC 0.05%
+	tokens.WSTETH: 0.0006, // WSTETH/WETH 0.01% + WETH/USDC 0.05%
+}
+
+// StepKind is the kind of plan step.
+type StepKind string
+
+const (
+	StepSwap StepKind = "swap" // Uniswap swap on a single chain
+	StepSend StepKind = "send" // Bridge route send between chains
+)
+
+// PlanStep is a single swap or send of a rebalance plan.
+type PlanStep struct {
+	Kind        StepKind `json:"kind"`
+	Route       string   `json:"route,omitempty"` // Only for sends
+	ChainID     uint64   `json:"chain_id"`
+	Chain       string   `json:"chain"`
+	DestChainID uint64   `json:"dest_chain_id,omitempty"` // Only for sends
+	DestChain   string   `json:"dest_chain,omitempty"`    // Only for sends
+	TokenIn     string   `json:"token_in"`
+	TokenOut    string   `json:"token_out"`
+	Amount      *big.Int `json:"amount"` // Amount of TokenIn in base units
+	AmountUSD   float64  `json:"amount_usd"`
+	CostUSD     float64  `json:"cost_usd"` // Estimated gas and pool fees
+
+	tokenIn  tokens.Token
+	tokenOut tokens.Token
+	route    Route
+}
+
+// Plan is a global rebalance plan, computed from a snapshot of balances across all chains.
+type Plan struct {
+	CreatedAt time.Time  `json:"created_at"`
+	Steps     []PlanStep `json:"steps"`
+	CostUSD   float64    `json:"cost_usd"`
+	// UnmetUSD is the USD deficit by chain not resolved by the plan.
+	// Sent USDC is swapped to deficit tokens on the destination chain by subsequent plans.
+	UnmetUSD map[string]float64 `json:"unmet_usd"`
+}
+
+func (p *Plan) add(step PlanStep) {
+	p.Steps = append(p.Steps, step)
+	p.CostUSD += step.CostUSD
+}
+
+// TokenBalance is a snapshot of the solver's balance of a token.
+type TokenBalance struct {
+	Token   tokens.Token
+	Balance *big.Int
+	Price   float64 // USD price of the token
+}
+
+// Snapshot is a snapshot of solver balances across all chains.
+type Snapshot struct {
+	Balances []TokenBalance
+	Inflight map[uint64]*big.Int // Inflight USDC by destination chain
+	GasUSD   map[uint64]float64  // USD cost of a single unit of gas by chain
+}
+
+// takeSnapshot returns a snapshot of solver balances across all chains in the network.
+func takeSnapshot(
+	ctx context.Context,
+	db *cctpdb.DB,
+	network netconf.Network,
+	pricer tokenpricer.Pricer,
+	backends ethbackend.Backends,
+	solver common.Address,
+) (Snapshot, error) {
+	snap := Snapshot{
+		Inflight: make(map[uint64]*big.Int),
+		GasUSD:   make(map[uint64]float64),
+	}
+
+	for _, chain := range network.EVMChains() {
+		backend, err := backends.Backend(chain.ID)
+		if err != nil {
+			return Snapshot{}, errors.Wrap(err, "get backend")
+		}
+
+		for _, token := range tokens.ByChain(chain.ID) {
+			balance, err := tokenutil.BalanceOf(ctx, backend, token, solver)
+			if err != nil {
+				return Snapshot{}, errors.Wrap(err, "get balance", "token", token)
+			}
+
+			price, err := pricer.USDPrice(ctx, token.Asset)
+			if err != nil {
+				return Snapshot{}, errors.Wrap(err, "get price", "token", token)
+			}
+
+			snap.Balances = append(snap.Balances, TokenBalance{Token: token, Balance: balance, Price: price})
+		}
+
+		gasPrice, err := backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return Snapshot{}, errors.Wrap(err, "suggest gas price", "chain", chain.Name)
+		}
+
+		native, ok := tokens.Native(chain.ID)
+		if !ok {
+			return Snapshot{}, errors.New("no native token", "chain", chain.Name)
+		}
+
+		nativePrice, err := pricer.USDPrice(ctx, native.Asset)
+		if err != nil {
+			return Snapshot{}, errors.Wrap(err, "get native price", "chain", chain.Name)
+		}
+
+		snap.GasUSD[chain.ID] = bi.ToF64(gasPrice, native.Decimals) * nativePrice
+
+		if db == nil || !cctp.IsSupportedChain(chain.ID) {
+			continue
+		}
+
+		inflight, err := cctp.GetInflightUSDC(ctx, db, chain.ID)
+		if err != nil {
+			return Snapshot{}, errors.Wrap(err, "get inflight")
+		}
+
+		snap.Inflight[chain.ID] = inflight
+	}
+
+	return snap, nil
+}
+
+// computePlan returns the cheapest plan to satisfy all fund threshold targets given the snapshot.
+// Dependent chains are first refilled from their parent chains, see planDependents.
+// Surplus tokens are swapped to USDC, which first fills deficits on the same chain (no bridging costs).
+// Remaining surplus USDC is then bridged to chains in deficit, via the cheapest routes within the deadline.
+func computePlan(snap Snapshot, routes []Route, deadline time.Duration) Plan {
+	plan := Plan{UnmetUSD: make(map[string]float64)}
+
+	balances := planDependents(&plan, snap, routes, deadline)
+
+	byChain := make(map[uint64][]TokenBalance)
+	var chainIDs []uint64
+	for _, b := range balances {
+		if _, ok := byChain[b.Token.ChainID]; !ok {
+			chainIDs = append(chainIDs, b.Token.ChainID)
+		}
+		byChain[b.Token.ChainID] = append(byChain[b.Token.ChainID], b)
+	}
+	slices.Sort(chainIDs)
+
+	available := make(map[uint64]*big.Int) // Available USDC (in USD) by chain
+	deficits := make(map[uint64]*big.Int)  // USD deficits by chain
+
+	for _, chainID := range chainIDs {
+		available[chainID], deficits[chainID] = planChain(&plan, snap, byChain[chainID])
+	}
+
+	// Dependent chains are refilled from their parent chain, so parents hold their deficits.
+	for _, chainID := range chainIDs {
+		for _, dep := range GetDependents(chainID) {
+			d, ok := deficits[dep]
+			if !ok {
+				continue
+			}
+
+			deficits[chainID] = bi.Add(deficits[chainID], d)
+			deficits[dep] = bi.Zero()
+		}
+	}
+
+	// Subtract inflight USDC and cover remaining deficits from available USDC on the same chain.
+	for _, chainID := range chainIDs {
+		if inflight, ok := snap.Inflight[chainID]; ok {
+			deficits[chainID] = bi.Sub(deficits[chainID], inflight)
+		}
+
+		covered := bi.Zero()
+		if bi.GT(deficits[chainID], bi.Zero()) {
+			covered = minAmt(deficits[chainID], available[chainID])
+		}
+
+		deficits[chainID] = bi.Sub(deficits[chainID], covered)
+		available[chainID] = bi.Sub(available[chainID], covered)
+	}
+
+	// Bridge surplus USDC to chains in deficit, largest deficits first, cheapest routes first.
+	dests := slices.Clone(chainIDs)
+	sort.SliceStable(dests, func(i, j int) bool {
+		return bi.GT(deficits[dests[i]], deficits[dests[j]])
+	})
+
+	for _, dest := range dests {
+		destUSDC, ok := tokens.ByAsset(dest, tokens.USDC)
+		if !ok {
+			continue
+		}
+
+		for _, ranked := range rankRoutes(routes, snap.GasUSD, destUSDC, bi.Rebase(deficits[dest], usdDecimals, destUSDC.Decimals), deadline) {
+			src := ranked.Route.Src()
+			if !src.Is(tokens.USDC) || !bi.GT(deficits[dest], bi.Zero()) {
+				continue
+			}
+
+			toSend := minAmt(deficits[dest], orZero(available[src.ChainID]), maxSend)
+			if ranked.Estimate.MaxAmount != nil {
+				toSend = minAmt(toSend, bi.Rebase(ranked.Estimate.MaxAmount, src.Decimals, usdDecimals))
+			}
+			if bi.LT(toSend, minSend) {
+				continue
+			}
+
+			plan.add(PlanStep{
+				Kind:        StepSend,
+				Route:       ranked.Route.Name(),
+				ChainID:     src.ChainID,
+				Chain:       evmchain.Name(src.ChainID),
+				DestChainID: dest,
+				DestChain:   evmchain.Name(dest),
+				TokenIn:     src.Symbol,
+				TokenOut:    destUSDC.Symbol,
+				Amount:      bi.Rebase(toSend, usdDecimals, src.Decimals),
+				AmountUSD:   bi.ToF64(toSend, usdDecimals),
+				CostUSD:     ranked.Estimate.FeeUSD,
+				tokenIn:     src,
+				tokenOut:    destUSDC,
+				route:       ranked.Route,
+			})
+
+			available[src.ChainID] = bi.Sub(available[src.ChainID], toSend)
+			deficits[dest] = bi.Sub(deficits[dest], toSend)
+		}
+	}
+
+	for _, chainID := range chainIDs {
+		if bi.GT(deficits[chainID], bi.Zero()) {
+			plan.UnmetUSD[evmchain.Name(chainID)] = bi.ToF64(deficits[chainID], usdDecimals)
+		}
+	}
+
+	return plan
+}
+
+// planDependents adds sends to the plan that refill dependent chain deficits from their parent chain
+// via the dependents' routes (e.g. Mantle bridge, USDT0), like the Mantle and HyperEVM rebalance loops.
+// Sends draw from the parent's balance (capped to half of it, to protect against over-spending), not only its surplus.
+// It returns the snapshot balances after the sends, so the parent is refilled and the dependent is not.
+func planDependents(plan *Plan, snap Snapshot, routes []Route, deadline time.Duration) []TokenBalance {
+	balances := slices.Clone(snap.Balances)
+
+	indexOf := func(token tokens.Token) (int, bool) {
+		for i, b := range balances {
+			if b.Token == token {
+				return i, true
+			}
+		}
+
+		return 0, false
+	}
+
+	var parents []uint64
+	for parent := range dependents {
+		parents = append(parents, parent)
+	}
+	slices.Sort(parents)
+
+	for _, parent := range parents {
+		for _, dep := range GetDependents(parent) {
+			for i, b := range balances {
+				thresh := fundthresh.Get(b.Token)
+				if b.Token.ChainID != dep || bi.GTE(b.Balance, thresh.Target()) {
+					continue
+				}
+
+				deficit := bi.Sub(thresh.Target(), b.Balance)
+
+				for _, ranked := range rankRoutes(routes, snap.GasUSD, b.Token, deficit, deadline) {
+					src := ranked.Route.Src()
+					j, ok := indexOf(src)
+					if !ok || src.ChainID != parent {
+						continue
+					}
+
+					toSend := minAmt(bi.Rebase(deficit, b.Token.Decimals, src.Decimals), bi.Div(balances[j].Balance, bi.N(2)))
+					if ranked.Estimate.MaxAmount != nil {
+						toSend = minAmt(toSend, ranked.Estimate.MaxAmount)
+					}
+
+					amountUSD := toUSD(balances[j], toSend)
+					if bi.LT(amountUSD, minSend) {
+						continue
+					}
+
+					plan.add(PlanStep{
+						Kind:        StepSend,
+						Route:       ranked.Route.Name(),
+						ChainID:     parent,
+						Chain:       evmchain.Name(parent),
+						DestChainID: dep,
+						DestChain:   evmchain.Name(dep),
+						TokenIn:     src.Symbol,
+						TokenOut:    b.Token.Symbol,
+						Amount:      toSend,
+						AmountUSD:   bi.ToF64(amountUSD, usdDecimals),
+						CostUSD:     ranked.Estimate.FeeUSD,
+						tokenIn:     src,
+						tokenOut:    b.Token,
+						route:       ranked.Route,
+					})
+
+					balances[j].Balance = bi.Sub(balances[j].Balance, toSend)
+					balances[i].Balance = bi.Add(b.Balance, bi.Rebase(toSend, src.Decimals, b.Token.Decimals))
+
+					break
+				}
+			}
+		}
+	}
+
+	return balances
+}
+
+// planChain adds swaps to the plan that resolve token surpluses and deficits on a single chain.
+// It returns the remaining available USDC (in USD) and the remaining USD deficit of the chain.
+func planChain(plan *Plan, snap Snapshot, balances []TokenBalance) (*big.Int, *big.Int) {
+	available := bi.Zero()
+	deficit := bi.Zero()
+
+	usdc, hasUSDC := tokens.ByAsset(balances[0].Token.ChainID, tokens.USDC)
+
+	// First, swap surplus tokens to USDC.
+	for _, b := range balances {
+		thresh := fundthresh.Get(b.Token)
+		surplus := bi.Zero()
+		if bi.GT(b.Balance, thresh.Surplus()) {
+			surplus = bi.Sub(b.Balance, thresh.Surplus())
+		}
+
+		if b.Token.Is(tokens.USDC) {
+			available = bi.Add(available, toUSD(b, surplus))
+			continue
+		}
+
+		if !hasUSDC || !isSwappable(b.Token) || bi.IsZero(thresh.MaxSwap()) || bi.LTE(surplus, thresh.MinSwap()) {
+			continue
+		}
+
+		toSwap := minAmt(surplus, thresh.MaxSwap())
+		amountUSD := toUSD(b, toSwap)
+
+		plan.add(newSwapStep(snap, b.Token, usdc, toSwap, amountUSD))
+		available = bi.Add(available, bi.MulF64(amountUSD, 1-swapFeeRates[b.Token.Asset]))
+	}
+
+	// Then, fill token deficits from available USDC.
+	for _, b := range balances {
+		thresh := fundthresh.Get(b.Token)
+		if bi.GTE(b.Balance, thresh.Target()) {
+			continue
+		}
+
+		deficitUSD := toUSD(b, bi.Sub(thresh.Target(), b.Balance))
+
+		if hasUSDC && isSwappable(b.Token) && !b.Token.Is(tokens.USDC) {
+			toSwap := minAmt(deficitUSD, available, bi.Rebase(fundthresh.Get(usdc).MaxSwap(), usdc.Decimals, usdDecimals))
+			minSwap := bi.Rebase(fundthresh.Get(usdc).MinSwap(), usdc.Decimals, usdDecimals)
+
+			if !bi.IsZero(toSwap) && bi.GTE(toSwap, minSwap) {
+				plan.add(newSwapStep(snap, usdc, b.Token, bi.Rebase(toSwap, usdDecimals, usdc.Decimals), toSwap))
+				available = bi.Sub(available, toSwap)
+				deficitUSD = bi.Sub(deficitUSD, toSwap)
+			}
+		}
+
+		deficit = bi.Add(deficit, deficitUSD)
+	}
+
+	return available, deficit
+}
+
+func newSwapStep(snap Snapshot, tokenIn, tokenOut tokens.Token, amount *big.Int, amountUSD *big.Int) PlanStep {
+	feeAsset := tokenIn.Asset
+	if tokenIn.Is(tokens.USDC) {
+		feeAsset = tokenOut.Asset
+	}
+
+	usd := bi.ToF64(amountUSD, usdDecimals)
+
+	return PlanStep{
+		Kind:      StepSwap,
+		ChainID:   tokenIn.ChainID,
+		Chain:     evmchain.Name(tokenIn.ChainID),
+		TokenIn:   tokenIn.Symbol,
+		TokenOut:  tokenOut.Symbol,
+		Amount:    amount,
+		AmountUSD: usd,
+		CostUSD:   swapGas*snap.GasUSD[tokenIn.ChainID] + usd*swapFeeRates[feeAsset],
+		tokenIn:   tokenIn,
+		tokenOut:  tokenOut,
+	}
+}
+
+// toUSD converts a token amount to USD, rebased to usdDecimals.
+func toUSD(b TokenBalance, amount *big.Int) *big.Int {
+	return bi.Rebase(bi.MulF64(amount, b.Price), b.Token.Decimals, usdDecimals)
+}
+
+func isSwappable(token tokens.Token) bool {
+	return slices.Contains(swappable, token)
+}
+
+// minAmt returns the smallest of the given amounts.
+func minAmt(n *big.Int, ns ...*big.Int) *big.Int {
+	resp := n
+	for _, x := range ns {
+		if bi.LT(x, resp) {
+			resp = x
+		}
+	}
+
+	return resp
+}
+
+func orZero(n *big.Int) *big.Int {
+	if n == nil {
+		return bi.Zero()
+	}
+
+	return n
+}
+
+// planForever periodically computes a global rebalance plan, exporting it and, unless dry-run, executing it.
+func planForever(
+	ctx context.Context,
+	interval time.Duration,
+	o options,
+	db *cctpdb.DB,
+	network netconf.Network,
+	routes []Route,
+	pricer tokenpricer.Pricer,
+	backends ethbackend.Backends,
+	solver common.Address,
+) {
+	ctx = log.WithCtx(ctx, "step", "plan", "dry_run", o.dryRun)
+
+	for {
+		start := time.Now()
+		if err := planOnce(ctx, o, db, network, routes, pricer, backends, solver); err != nil {
+			log.Warn(ctx, "Failed to plan rebalance (will retry)", err)
+		}
+		elapsed := time.Since(start)
+
+		// Sleep for the remaining time in the interval, if any.
+		if elapsed < interval {
+			time.Sleep(interval - elapsed)
+		}
+	}
+}
+
+// planOnce computes, exports and (unless dry-run) executes a single global rebalance plan.
+func planOnce(
+	ctx context.Context,
+	o options,
+	db *cctpdb.DB,
+	network netconf.Network,
+	routes []Route,
+	pricer tokenpricer.Pricer,
+	backends ethbackend.Backends,
+	solver common.Address,
+) error {
+	snap, err := takeSnapshot(ctx, db, network, pricer, backends, solver)
+	if err != nil {
+		return errors.Wrap(err, "take snapshot")
+	}
+
+	plan := computePlan(snap, routes, o.routeDeadline)
+	plan.CreatedAt = time.Now()
+
+	bz, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal plan")
+	}
+
+	log.Info(ctx, "Rebalance plan", "steps", len(plan.Steps), "cost_usd", plan.CostUSD, "plan", string(bz))
+
+	planSteps.Set(float64(len(plan.Steps)))
+	planCost.Set(plan.CostUSD)
+	planUnmet.Reset()
+	for chain, usd := range plan.UnmetUSD {
+		planUnmet.WithLabelValues(chain).Set(usd)
+	}
+
+	if o.planFile != "" {
+		if err := os.WriteFile(o.planFile, bz, 0o644); err != nil {
+			return errors.Wrap(err, "write plan file")
+		}
+	}
+
+	if o.dryRun {
+		return nil
+	}
+
+	for _, step := range plan.Steps {
+		if err := executeStep(ctx, backends, solver, step, o.swapOpts(pricer)); err != nil {
+			// Continue with other steps, the next plan accounts for failed steps.
+			log.Warn(ctx, "Failed to execute plan step", err, "kind", step.Kind, "chain", step.Chain, "token_in", step.TokenIn, "token_out", step.TokenOut)
+		}
+	}
+
+	return nil
+}
+
+// executeStep executes a single plan step.
+func executeStep(
+	ctx context.Context,
+	backends ethbackend.Backends,
+	solver common.Address,
+	step PlanStep,
+	swapOpts []uniswap.SwapOption,
+) error {
+	defer lock(step.ChainID)() // Lock the chain to prevent concurrent rebalancing.
+
+	backend, err := backends.Backend(step.ChainID)
+	if err != nil {
+		return errors.Wrap(err, "get backend")
+	}
+
+	switch step.Kind {
+	case StepSwap:
+		if step.tokenIn.Is(tokens.USDC) {
+			_, err = uniswap.SwapFromUSDC(ctx, backend, solver, step.tokenOut, step.Amount, swapOpts...)
+		} else {
+			_, err = uniswap.SwapToUSDC(ctx, backend, solver, step.tokenIn, step.Amount, swapOpts...)
+		}
+		if err != nil {
+			return errors.Wrap(err, "swap")
+		}
+
+		return nil
+	case StepSend:
+		if err := step.route.Send(ctx, step.Amount); err != nil {
+			return errors.Wrap(err, "send", "route", step.Route)
+		}
+
+		return nil
+	default:
+		return errors.New("unknown step kind", "kind", step.Kind)
+	}
+}
@@ -0,0 +1,173 @@
+package rebalance
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/omni-network/omni/lib/bi"
+	"github.com/omni-network/omni/lib/ethclient/ethbackend"
+	"github.com/omni-network/omni/lib/evmchain"
+	"github.com/omni-network/omni/lib/netconf"
+	"github.com/omni-network/omni/lib/tokens"
+	"github.com/omni-network/omni/solver/fundthresh"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputePlan(t *testing.T) {
+	t.Parallel()
+
+	chains := []uint64{evmchain.IDEthereum, evmchain.IDBase, evmchain.IDArbitrumOne}
+	prices := map[tokens.Asset]float64{
+		tokens.ETH:    2000,
+		tokens.WSTETH: 2400,
+	}
+
+	// overrides maps tokens to balances, all other tokens are at their target.
+	overrides := map[tokens.Token]*big.Int{
+		mustToken(evmchain.IDBase, tokens.ETH):         bi.Ether(8),    // 3 ETH surplus
+		mustToken(evmchain.IDArbitrumOne, tokens.USDT): bi.Dec6(5000),  // 5k USDT deficit
+		mustToken(evmchain.IDArbitrumOne, tokens.USDC): bi.Dec6(23000), // 3k USDC surplus
+		mustToken(evmchain.IDEthereum, tokens.USDC):    bi.Dec6(90000), // 10k USDC deficit
+	}
+
+	snap := Snapshot{
+		Inflight: make(map[uint64]*big.Int),
+		GasUSD: map[uint64]float64{
+			evmchain.IDEthereum:    1e-6,
+			evmchain.IDBase:        1e-8,
+			evmchain.IDArbitrumOne: 1e-8,
+		},
+	}
+	for _, chainID := range chains {
+		for _, token := range tokens.ByChain(chainID) {
+			balance, ok := overrides[token]
+			if !ok {
+				balance = fundthresh.Get(token).Target()
+			}
+
+			price, ok := prices[token.Asset]
+			if !ok {
+				price = 1
+			}
+
+			snap.Balances = append(snap.Balances, TokenBalance{Token: token, Balance: balance, Price: price})
+		}
+	}
+
+	network := netconf.Network{ID: netconf.Mainnet}
+	for _, chainID := range chains {
+		network.Chains = append(network.Chains, netconf.Chain{ID: chainID, Name: evmchain.Name(chainID)})
+	}
+
+	plan := computePlan(snap, newRoutes(network, ethbackend.Backends{}, common.Address{}, nil, nil), time.Hour)
+
+	require.Len(t, plan.Steps, 3)
+
+	// Base ETH surplus swapped to USDC
+	swapETH := plan.Steps[0]
+	require.Equal(t, StepSwap, swapETH.Kind)
+	require.Equal(t, evmchain.IDBase, swapETH.ChainID)
+	require.Equal(t, "ETH", swapETH.TokenIn)
+	require.Equal(t, "USDC", swapETH.TokenOut)
+	require.Equal(t, bi.Ether(3), swapETH.Amount)
+	require.InDelta(t, 6000, swapETH.AmountUSD, 1e-6)
+
+	// Arbitrum USDT deficit partially filled from local USDC surplus
+	swapUSDT := plan.Steps[1]
+	require.Equal(t, StepSwap, swapUSDT.Kind)
+	require.Equal(t, evmchain.IDArbitrumOne, swapUSDT.ChainID)
+	require.Equal(t, "USDC", swapUSDT.TokenIn)
+	require.Equal(t, "USDT", swapUSDT.TokenOut)
+	require.Equal(t, bi.Dec6(3000), swapUSDT.Amount)
+
+	// Swapped Base USDC sent to Ethereum, the largest deficit
+	send := plan.Steps[2]
+	require.Equal(t, StepSend, send.Kind)
+	require.Equal(t, "cctp", send.Route)
+	require.Equal(t, evmchain.IDBase, send.ChainID)
+	require.Equal(t, evmchain.IDEthereum, send.DestChainID)
+	require.InDelta(t, 5997, send.AmountUSD, 1e-3) // 6k minus 0.05% pool fee
+	require.InDelta(t, 150_000*1e-8+200_000*1e-6, send.CostUSD, 1e-9)
+
+	require.InDelta(t, 4003, plan.UnmetUSD["ethereum"], 1e-3)
+	require.InDelta(t, 2000, plan.UnmetUSD["arbitrum_one"], 1e-3)
+	require.NotContains(t, plan.UnmetUSD, "base")
+
+	var total float64
+	for _, step := range plan.Steps {
+		total += step.CostUSD
+	}
+	require.InDelta(t, total, plan.CostUSD, 1e-9)
+
+	// Plans are exported as JSON
+	bz, err := json.Marshal(plan)
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"kind":"send"`)
+	require.Contains(t, string(bz), `"dest_chain":"ethereum"`)
+}
+
+func TestComputePlanDependents(t *testing.T) {
+	t.Parallel()
+
+	chains := []uint64{evmchain.IDEthereum, evmchain.IDMantle, evmchain.IDHyperEVM}
+
+	ethUSDC := mustToken(evmchain.IDEthereum, tokens.USDC)
+	ethUSDT := mustToken(evmchain.IDEthereum, tokens.USDT)
+
+	// overrides maps tokens to balances, all other tokens are at their target.
+	overrides := map[tokens.Token]*big.Int{
+		mantleUSDC: bi.Sub(fundthresh.Get(mantleUSDC).Target(), bi.Dec6(2000)), // 2k USDC deficit
+		hypUSDT0:   bi.Sub(fundthresh.Get(hypUSDT0).Target(), bi.Dec6(3000)),   // 3k USDT0 deficit
+	}
+
+	snap := Snapshot{
+		Inflight: make(map[uint64]*big.Int),
+		GasUSD:   map[uint64]float64{evmchain.IDEthereum: 1e-6, evmchain.IDMantle: 1e-8, evmchain.IDHyperEVM: 1e-8},
+	}
+	for _, chainID := range chains {
+		for _, token := range tokens.ByChain(chainID) {
+			balance, ok := overrides[token]
+			if !ok {
+				balance = fundthresh.Get(token).Target()
+			}
+
+			snap.Balances = append(snap.Balances, TokenBalance{Token: token, Balance: balance, Price: 1})
+		}
+	}
+
+	network := netconf.Network{ID: netconf.Mainnet}
+	for _, chainID := range chains {
+		network.Chains = append(network.Chains, netconf.Chain{ID: chainID, Name: evmchain.Name(chainID)})
+	}
+
+	plan := computePlan(snap, newRoutes(network, ethbackend.Backends{}, common.Address{}, nil, nil), time.Hour)
+
+	require.Len(t, plan.Steps, 2)
+
+	// Mantle USDC refilled from Ethereum USDC via the Mantle bridge
+	mantle := plan.Steps[0]
+	require.Equal(t, StepSend, mantle.Kind)
+	require.Equal(t, "mantle", mantle.Route)
+	require.Equal(t, evmchain.IDEthereum, mantle.ChainID)
+	require.Equal(t, evmchain.IDMantle, mantle.DestChainID)
+	require.Equal(t, ethUSDC.Symbol, mantle.TokenIn)
+	require.Equal(t, bi.Dec6(2000), mantle.Amount)
+
+	// HyperEVM USDT0 refilled from Ethereum USDT via USDT0, capped to half the Ethereum USDT balance
+	hyperEVM := plan.Steps[1]
+	require.Equal(t, StepSend, hyperEVM.Kind)
+	require.Equal(t, "usdt0", hyperEVM.Route)
+	require.Equal(t, evmchain.IDHyperEVM, hyperEVM.DestChainID)
+	require.Equal(t, ethUSDT.Symbol, hyperEVM.TokenIn)
+	require.Equal(t, bi.Div(fundthresh.Get(ethUSDT).Target(), bi.N(2)), hyperEVM.Amount)
+
+	// Ethereum holds the sent and remaining HyperEVM deficits (5k total), to be refilled by subsequent plans
+	require.InDelta(t, 5000, plan.UnmetUSD["ethereum"], 1e-3)
+	require.NotContains(t, plan.UnmetUSD, "mantle")
+	require.NotContains(t, plan.UnmetUSD, "hyper_evm")
+}
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/omni-network/omni/lib/bi"
-	"github.com/omni-network/omni/lib/cctp"
 	cctpdb "github.com/omni-network/omni/lib/cctp/db"
 	"github.com/omni-network/omni/lib/errors"
 	"github.com/omni-network/omni/lib/ethclient/ethbackend"
@@ -38,10 +37,12 @@ func rebalanceCCTPForever(
 	pricer tokenpricer.Pricer,
 	backends ethbackend.Backends,
 	solver common.Address,
+	r router,
+	swapOpts []uniswap.SwapOption,
 ) {
 	for {
 		start := time.Now()
-		rebalanceCCTPOnce(ctx, db, network, pricer, backends, solver)
+		rebalanceCCTPOnce(ctx, db, network, pricer, backends, solver, r, swapOpts)
 		elapsed := time.Since(start)
 
 		// Sleep for the remaining time in the interval, if any.
@@ -59,6 +60,8 @@ func rebalanceCCTPOnce(
 	pricer tokenpricer.Pricer,
 	backends ethbackend.Backends,
 	solver common.Address,
+	r router,
+	swapOpts []uniswap.SwapOption,
 ) {
 	for _, chain := range network.EVMChains() {
 		func() {
@@ -68,24 +71,24 @@ func rebalanceCCTPOnce(
 			log.Info(ctx, "Rebalancing chain; locked")
 
 			// First, swap surplus tokens USDC.
-			if err := swapSurplusOnce(ctx, backends, chain.ID, solver); err != nil {
+			if err := swapSurplusOnce(ctx, backends, chain.ID, solver, swapOpts); err != nil {
 				log.Warn(ctx, "Failed to swap surplus", err)
 			}
 
 			// Then, fill deficits from surplus USDC.
-			if err := fillDeficitOnce(ctx, pricer, backends, chain.ID, solver); err != nil {
+			if err := fillDeficitOnce(ctx, pricer, backends, chain.ID, solver, swapOpts); err != nil {
 				log.Warn(ctx, "Failed to fill deficit", err)
 			}
 
 			// Finally, send remaining surplus USDC to other chains.
-			if err := sendSurplusOnce(ctx, db, network, pricer, backends, chain.ID, solver); err != nil {
+			if err := sendSurplusOnce(ctx, db, network, pricer, backends, chain.ID, solver, r); err != nil {
 				log.Warn(ctx, "Failed to send surplus", err)
 			}
 		}()
 	}
 }
 
-// sendSurplusOnce sends surplus USDC on `chainID` to chains in deficit.
+// sendSurplusOnce sends surplus USDC on `chainID` to chains in deficit, via the cheapest bridge routes.
 func sendSurplusOnce(
 	ctx context.Context,
 	db *cctpdb.DB,
@@ -94,6 +97,7 @@ func sendSurplusOnce(
 	backends ethbackend.Backends,
 	chainID uint64,
 	solver common.Address,
+	r router,
 ) error {
 	ctx = log.WithCtx(ctx, "step", "sendSurplus")
 
@@ -176,14 +180,20 @@ func sendSurplusOnce(
 			toSend = maxSend
 		}
 
-		if _, err = cctp.SendUSDC(ctx, db, network.ID, backend, cctp.SendUSDCArgs{
-			Sender:      solver,
-			Recipient:   solver,
-			SrcChainID:  chainID,
-			DestChainID: d.ChainID,
-			Amount:      toSend,
-		}); err != nil {
-			return errors.Wrap(err, "send usdc", "dest", evmchain.Name(d.ChainID))
+		destUSDC, ok := tokens.ByAsset(d.ChainID, tokens.USDC)
+		if !ok {
+			return errors.New("dest token not found", "dest", evmchain.Name(d.ChainID))
+		}
+
+		route, estimate, err := r.Select(ctx, usdc, destUSDC, toSend)
+		if err != nil {
+			return errors.Wrap(err, "select route", "dest", evmchain.Name(d.ChainID))
+		}
+
+		toSend = capAmount(toSend, estimate)
+
+		if err := route.Send(ctx, toSend); err != nil {
+			return errors.Wrap(err, "send usdc", "dest", evmchain.Name(d.ChainID), "route", route.Name())
 		}
 
 		// Decrement surplus by the amount sent.
@@ -199,6 +209,7 @@ func swapSurplusOnce(
 	backends ethbackend.Backends,
 	chainID uint64,
 	solver common.Address,
+	swapOpts []uniswap.SwapOption,
 ) error {
 	ctx = log.WithCtx(ctx, "step", "swapSurplus")
 
@@ -212,7 +223,7 @@ func swapSurplusOnce(
 			continue
 		}
 
-		if err := swapTokenSurplusOnce(ctx, backend, token, solver); err != nil {
+		if err := swapTokenSurplusOnce(ctx, backend, token, solver, swapOpts); err != nil {
 			return errors.Wrap(err, "swap surplus", "token", token)
 		}
 	}
@@ -226,6 +237,7 @@ func swapTokenSurplusOnce(
 	backend *ethbackend.Backend,
 	token tokens.Token,
 	solver common.Address,
+	swapOpts []uniswap.SwapOption,
 ) error {
 	surplus, err := GetSurplus(ctx, backend, token, solver)
 	if err != nil {
@@ -254,7 +266,7 @@ func swapTokenSurplusOnce(
 	log.Debug(ctx, "Swapping surplus", "amount", token.FormatAmt(toSwap))
 
 	// Swap surplus to USDC.
-	_, err = uniswap.SwapToUSDC(ctx, backend, solver, token, toSwap)
+	_, err = uniswap.SwapToUSDC(ctx, backend, solver, token, toSwap, swapOpts...)
 	if err != nil {
 		return errors.Wrap(err, "swap to usdc")
 	}
@@ -269,6 +281,7 @@ func fillDeficitOnce(
 	backends ethbackend.Backends,
 	chainID uint64,
 	solver common.Address,
+	swapOpts []uniswap.SwapOption,
 ) error {
 	ctx = log.WithCtx(ctx, "step", "fillDeficit")
 
@@ -282,7 +295,7 @@ func fillDeficitOnce(
 			continue
 		}
 
-		if err := fillTokenDeficitOnce(ctx, pricer, backend, token, solver); err != nil {
+		if err := fillTokenDeficitOnce(ctx, pricer, backend, token, solver, swapOpts); err != nil {
 			return errors.Wrap(err, "fill deficit", "token", token)
 		}
 	}
@@ -297,6 +310,7 @@ func fillTokenDeficitOnce(
 	backend *ethbackend.Backend,
 	token tokens.Token,
 	solver common.Address,
+	swapOpts []uniswap.SwapOption,
 ) error {
 	chainID := token.ChainID
 
@@ -351,7 +365,7 @@ func fillTokenDeficitOnce(
 
 	log.Debug(ctx, "Filling deficit", "deficit", token.FormatAmt(deficit), "usdc", usdc.FormatAmt(toSwap))
 
-	if _, err = uniswap.SwapFromUSDC(ctx, backend, solver, token, toSwap); err != nil {
+	if _, err = uniswap.SwapFromUSDC(ctx, backend, solver, token, toSwap, swapOpts...); err != nil {
 		return errors.Wrap(err, "swap from usdc")
 	}
 
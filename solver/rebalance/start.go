@@ -19,6 +19,7 @@ import (
 )
 
 // Start starts rebalancing the solver's balance on the given network.
+// Devnets should provide dev CCTP and LayerZero clients, see cctp.StartDevClient and WithLayerZeroClient.
 func Start(
 	ctx context.Context,
 	network netconf.Network,
@@ -29,17 +30,13 @@ func Start(
 	dbDir string,
 	opts ...Options,
 ) error {
-	if network.ID != netconf.Mainnet {
-		// Rebalancing is only supported on mainnet.
-		return nil
-	}
-
 	ctx = log.WithCtx(ctx, "process", "rebalance")
 
 	if err := monitorForever(ctx, network, backends.Clients(), solver); err != nil {
 		return errors.Wrap(err, "monitor forever")
 	}
 
+	fullNetwork := network
 	network = newCCTPNetwork(network)
 
 	cctpDB, err := newCCTPDB(dbDir)
@@ -52,24 +49,50 @@ func Start(
 		return errors.Wrap(err, "new usdt0 db")
 	}
 
-	usdt0.MonitorSendsForever(ctx, usdt0DB, layerzero.NewClient(layerzero.MainnetAPI))
+	o := defaultOps()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	lzClient := o.lzClient
+	if lzClient == nil {
+		lzClient = newLayerZeroClient(network.ID)
+	}
+
+	usdt0.MonitorSendsForever(ctx, usdt0DB, lzClient)
 
 	if err := cctp.MintAuditForever(ctx, cctpDB, cctpClient, network, backends, solver, solver); err != nil {
 		return errors.Wrap(err, "mint forever")
 	}
 
-	o := defaultOps()
-	for _, opt := range opts {
-		opt(&o)
+	routes := newRoutes(fullNetwork, backends, solver, cctpDB, usdt0DB)
+	r := router{routes: routes, gasUSD: newGasUSD(backends, pricer), deadline: o.routeDeadline}
+
+	swapOpts := o.swapOpts(pricer)
+
+	if o.planner || o.dryRun {
+		go planForever(ctx, o.interval, o, cctpDB, fullNetwork, routes, pricer, backends, solver)
 	}
 
-	go rebalanceCCTPForever(ctx, o.interval, cctpDB, network, pricer, backends, solver)
-	go rebalanceMantleForever(ctx, o.interval, backends, solver)
-	go rebalanceHyperEVMForever(ctx, o.interval, backends, solver, usdt0DB)
+	// The planner replaces all rebalance loops, refilling Mantle and HyperEVM via their routes.
+	if !o.planner || o.dryRun {
+		go rebalanceCCTPForever(ctx, o.interval, cctpDB, network, pricer, backends, solver, r, swapOpts)
+		go rebalanceMantleForever(ctx, o.interval, backends, solver, r)
+		go rebalanceHyperEVMForever(ctx, o.interval, backends, solver, r, swapOpts)
+	}
 
 	return nil
 }
 
+// newLayerZeroClient returns the LayerZero scan API client for the network.
+func newLayerZeroClient(networkID netconf.ID) layerzero.Client {
+	if networkID == netconf.Mainnet {
+		return layerzero.NewClient(layerzero.MainnetAPI)
+	}
+
+	return layerzero.NewClient(layerzero.TestnetAPI)
+}
+
 // newCCTPDB returns a new CCTP DB instance based on the given directory.
 func newCCTPDB(dbDir string) (*cctpdb.DB, error) {
 	if dbDir == "" {
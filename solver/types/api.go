@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"math/big"
 	"time"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // JSONErrorResponse is a json response for http errors (e.g 4xx, 5xx), not used for rejections.
@@ -36,13 +38,15 @@ type JSONError struct {
 // we'd need a more generic request / response format that discriminates on
 // order type hash.
 type CheckRequest struct {
-	SourceChainID      uint64    `json:"sourceChainId"`
-	DestinationChainID uint64    `json:"destChainId"`
-	FillDeadline       uint32    `json:"fillDeadline"`
-	Calls              []Call    `json:"calls"`
-	Expenses           []Expense `json:"expenses"`
-	Deposit            AddrAmt   `json:"deposit"`
-	Debug              bool      `json:"debug"`
+	SourceChainID      uint64       `json:"sourceChainId"`
+	DestinationChainID uint64       `json:"destChainId"`
+	FillDeadline       uint32       `json:"fillDeadline"`
+	Calls              []Call       `json:"calls"`
+	Expenses           []Expense    `json:"expenses"`
+	Deposit            AddrAmt      `json:"deposit"`
+	Debug              bool         `json:"debug"`
+	Simulate           bool         `json:"simulate"`          // Simulate the fill against a fork of the destination chain, if enabled
+	OrderID            *common.Hash `json:"orderId,omitempty"` // Expected order ID, if set firm quotes bound to it are honoured
 }
 
 // CheckResponse is the response json for the /check endpoint.
@@ -52,17 +56,62 @@ type CheckResponse struct {
 	RejectCode        RejectReason   `json:"rejectCode"`
 	RejectReason      string         `json:"rejectReason"`
 	RejectDescription string         `json:"rejectDescription"`
-	Trace             map[string]any `json:"trace"` // If debug is true, result of debug_traceCall
+	Trace             map[string]any `json:"trace"`                // If debug is true, result of debug_traceCall
+	Simulation        *Simulation    `json:"simulation,omitempty"` // If simulate is true, result of the fill simulation
+}
+
+// Simulation is the result of simulating an order fill, including token approvals
+// and native value, against a local fork of the destination chain.
+type Simulation struct {
+	Success     bool            `json:"success"`
+	Error       string          `json:"error,omitempty"` // Simulation error or revert reason
+	BlockNumber uint64          `json:"blockNumber"`     // Forked destination chain block
+	GasUsed     uint64          `json:"gasUsed"`
+	Logs        []SimulationLog `json:"logs"`
+	StateDiff   json.RawMessage `json:"stateDiff,omitempty"` // Pre and post state of modified accounts, see geth prestateTracer diff mode
+}
+
+// SimulationLog is a log emitted by a simulated fill.
+type SimulationLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
 }
 
 // QuoteRequest is the expected request body for the /api/v1/quote endpoint.
 // If deposit amount is omitted, the response will include the required deposit amount.
 // If expense amount is omitted, the response will include the required expense amount.
+//
+// Multi-leg quotes (a single deposit paying for multiple expenses) are requested by populating
+// Expenses instead of Expense. Exactly one leg (deposit or expense) amount must be omitted,
+// the response will include its amount. Orders only support a single deposit token.
 type QuoteRequest struct {
-	SourceChainID      uint64  `json:"sourceChainId"`
-	DestinationChainID uint64  `json:"destChainId"`
-	Deposit            AddrAmt `json:"deposit"`
-	Expense            AddrAmt `json:"expense"`
+	SourceChainID      uint64    `json:"sourceChainId"`
+	DestinationChainID uint64    `json:"destChainId"`
+	Deposit            AddrAmt   `json:"deposit"`
+	Expense            AddrAmt   `json:"expense"`
+	Expenses           []AddrAmt `json:"expenses,omitempty"`
+	Firm               bool      `json:"firm,omitempty"` // Request a signed firm quote, honoured until expiry
+
+	// User, Nonce and Gasless identify the order a firm quote is bound to; only that order is honoured.
+	// Nonce is the user's inbox onchain nonce (see getOnchainUserNonce), or the gasless order nonce.
+	User    *common.Address `json:"user,omitempty"`
+	Nonce   *hexutil.Big    `json:"nonce,omitempty"`
+	Gasless bool            `json:"gasless,omitempty"`
+}
+
+// IsMultiLeg returns true if the request uses Expenses instead of Expense.
+func (r QuoteRequest) IsMultiLeg() bool {
+	return len(r.Expenses) > 0
+}
+
+// ExpenseLegs returns the expenses of the request, either single or multi-leg.
+func (r QuoteRequest) ExpenseLegs() []AddrAmt {
+	if r.IsMultiLeg() {
+		return r.Expenses
+	}
+
+	return []AddrAmt{r.Expense}
 }
 
 type PriceRequest struct {
@@ -119,15 +168,78 @@ func (u *AddrAmt) UnmarshalJSON(bz []byte) error {
 }
 
 // QuoteResponse is the response json for the /api/v1/quote endpoint.
+// Expenses is only populated (instead of Expense) for multi-leg quote requests.
 type QuoteResponse struct {
 	Deposit           AddrAmt      `json:"deposit"`
 	Expense           AddrAmt      `json:"expense"`
+	Expenses          []AddrAmt    `json:"expenses,omitempty"`
+	Fees              []Fee        `json:"fees,omitempty"`
+	Firm              *FirmQuote   `json:"firm,omitempty"` // Only populated for firm quote requests
 	Rejected          bool         `json:"rejected"`
 	RejectCode        RejectReason `json:"rejectCode"`
 	RejectReason      string       `json:"rejectReason"`
 	RejectDescription string       `json:"rejectDescription"`
 }
 
+// FirmQuote is the solver's commitment to accept the order with OrderID if it matches the exact
+// quoted terms (chains, deposits and expenses) and is opened before expiry, regardless of subsequent
+// price movements. The quote ID is derived from the order ID and the quoted terms.
+// A firm quote is only honoured once; it is used when the order is filled or rejected.
+type FirmQuote struct {
+	ID        common.Hash    `json:"id"`
+	OrderID   common.Hash    `json:"orderId"` // Order the quote is bound to, derived from the requested user and nonce
+	Expiry    uint64         `json:"expiry"`  // Unix timestamp in seconds
+	Solver    common.Address `json:"solver"`
+	Signature hexutil.Bytes  `json:"signature"` // Solver signature of Digest()
+}
+
+// Digest returns the hash signed by the solver; keccak256(id || uint64(expiry)).
+func (q FirmQuote) Digest() common.Hash {
+	return crypto.Keccak256Hash(q.ID.Bytes(), binary.BigEndian.AppendUint64(nil, q.Expiry))
+}
+
+type feeJSON struct {
+	Rule    string         `json:"rule"`
+	Bips    int64          `json:"bips"`
+	Expense common.Address `json:"expense"`
+	Token   common.Address `json:"token"`
+	Amount  *hexutil.Big   `json:"amount"`
+}
+
+// Fee is the solver fee charged for a single expense of a quote.
+type Fee struct {
+	Rule    string         // Name of the fee schedule rule applied
+	Bips    int64          // Fee in basis points
+	Expense common.Address // Expense token the fee is charged for
+	Token   common.Address // Deposit token the fee is paid in
+	Amount  *big.Int       // Fee amount in deposit token
+}
+
+func (f Fee) MarshalJSON() ([]byte, error) {
+	return marshal(feeJSON{
+		Rule:    f.Rule,
+		Bips:    f.Bips,
+		Expense: f.Expense,
+		Token:   f.Token,
+		Amount:  (*hexutil.Big)(f.Amount),
+	})
+}
+
+func (f *Fee) UnmarshalJSON(bz []byte) error {
+	v := new(feeJSON)
+	if err := unmarshal(bz, v); err != nil {
+		return err
+	}
+
+	f.Rule = v.Rule
+	f.Bips = v.Bips
+	f.Expense = v.Expense
+	f.Token = v.Token
+	f.Amount = intOrZero(v.Amount)
+
+	return nil
+}
+
 // ContractsResponse is the response json for the /api/vi/contracts endpoint.
 type ContractsResponse struct {
 	Portal    common.Address `json:"portal"`
@@ -309,6 +421,8 @@ type RelayResponse struct {
 	TxHash common.Hash `json:"txHash,omitempty"`
 	// Order ID that was created
 	OrderID common.Hash `json:"orderId,omitempty"`
+	// Relay ID identifying the gasless order, used to query its status via /api/v1/relay/{id}
+	RelayID common.Hash `json:"relayId,omitempty"`
 	// Error details if submission failed
 	Error *RelayError `json:"error,omitempty"`
 }
@@ -319,3 +433,111 @@ type RelayError struct {
 	Message     string `json:"message"`
 	Description string `json:"description,omitempty"`
 }
+
+// RelayStatus is the status of a relayed gasless order.
+type RelayStatus string
+
+const (
+	RelayPending   RelayStatus = "pending"   // Validated, being submitted
+	RelaySubmitted RelayStatus = "submitted" // Submitted, awaiting confirmation
+	RelaySuccess   RelayStatus = "success"   // Mined successfully
+	RelayFailed    RelayStatus = "failed"    // Submission failed or reverted
+)
+
+// RelayStatusRequest is the request for the /api/v1/relay/{id} endpoint, populated from the path.
+type RelayStatusRequest struct {
+	ID common.Hash
+}
+
+// RelayStatusResponse is the response json for the /api/v1/relay/{id} endpoint.
+type RelayStatusResponse struct {
+	RelayID   common.Hash    `json:"relayId"`
+	Status    RelayStatus    `json:"status"`
+	ChainID   uint64         `json:"chainId"`
+	User      common.Address `json:"user"`
+	TxHash    string         `json:"txHash,omitempty"`
+	OrderID   common.Hash    `json:"orderId,omitempty"`
+	FeeUSD    float64        `json:"feeUsd,omitempty"` // Sponsored tx fee in USD
+	Error     *RelayError    `json:"error,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// OrderRequest is the request for the /api/v1/orders/{id} endpoint, populated from the path.
+type OrderRequest struct {
+	ID common.Hash
+}
+
+// OrderResponse is the response json for the /api/v1/orders/{id} endpoint.
+// It contains the lifecycle history of an order as processed by the solver.
+type OrderResponse struct {
+	OrderID common.Hash         `json:"orderId"`
+	Status  string              `json:"status"` // Latest known on-chain order status
+	History []OrderHistoryEntry `json:"history"`
+}
+
+// OrderHistoryEntry is a single order lifecycle entry; either an order event processed
+// by the solver (e.g. event, fill, reject), or a solver transaction (e.g. Outbox:Fill).
+type OrderHistoryEntry struct {
+	Action       string       `json:"action"`
+	Status       string       `json:"status"`
+	SrcChainID   uint64       `json:"srcChainId"`
+	DestChainID  uint64       `json:"destChainId,omitempty"`
+	EventTx      string       `json:"eventTx,omitempty"`
+	EventHeight  uint64       `json:"eventHeight,omitempty"`
+	Tx           string       `json:"tx,omitempty"`
+	RejectCode   RejectReason `json:"rejectCode,omitempty"`
+	RejectReason string       `json:"rejectReason,omitempty"`
+	PnLUSD       float64      `json:"pnlUsd,omitempty"`
+	Timestamp    time.Time    `json:"timestamp"`
+}
+
+// OrderUpdate is a server-sent event of the /api/v1/orders/stream endpoint.
+// It is an order lifecycle entry as processed by the solver, identical to OrderHistoryEntry.
+type OrderUpdate struct {
+	OrderID common.Hash    `json:"orderId"`
+	User    common.Address `json:"user"`
+	OrderHistoryEntry
+}
+
+// PauseScope is the solver activity paused via the admin API.
+type PauseScope string
+
+const (
+	PauseFill  PauseScope = "fill"  // Pause filling orders, new orders are rejected.
+	PauseClaim PauseScope = "claim" // Pause claiming filled orders, claims are retried until resumed.
+	PauseQuote PauseScope = "quote" // Pause quoting and checking orders, requests are rejected.
+)
+
+// Validate returns an error if the scope is unknown.
+func (s PauseScope) Validate() error {
+	switch s {
+	case PauseFill, PauseClaim, PauseQuote:
+		return nil
+	default:
+		return errors.New("invalid pause scope", "scope", s)
+	}
+}
+
+// PauseRequest is the request body for the /admin/v1/pause and /admin/v1/resume endpoints.
+// Zero chain ID applies to all chains, an empty token applies to all tokens.
+type PauseRequest struct {
+	Scope   PauseScope `json:"scope"`
+	ChainID uint64     `json:"chainId"`
+	Token   string     `json:"token"`  // Token symbol, e.g. "USDC"
+	Reason  string     `json:"reason"` // Optional pause reason, ignored when resuming
+}
+
+// Pause is an active pause.
+type Pause struct {
+	Scope     PauseScope `json:"scope"`
+	ChainID   uint64     `json:"chainId"`
+	Token     string     `json:"token"`
+	Reason    string     `json:"reason"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// PausesResponse is the response json for the admin pause endpoints, listing all active pauses.
+type PausesResponse struct {
+	Pauses []Pause `json:"pauses"`
+}
@@ -17,4 +17,5 @@ func TestRejectReasonValues(t *testing.T) {
 	// ...
 	require.EqualValues(t, 11, types.RejectExpenseOverMax)
 	require.EqualValues(t, 12, types.RejectExpenseUnderMin)
+	require.EqualValues(t, 14, types.RejectPaused)
 }